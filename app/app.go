@@ -4,15 +4,18 @@ import (
 	"context"
 	_ "embed"
 	"fmt"
+	"sync"
 
 	"github.com/iskandervdh/spinup/common"
 	"github.com/iskandervdh/spinup/core"
 )
 
 type App struct {
-	ctx             context.Context
-	core            *core.Core
-	runningProjects map[string]*runningProject
+	ctx  context.Context
+	core *core.Core
+
+	// Channels to stop following the logs of a project, by project name
+	followingLogs sync.Map
 }
 
 func NewApp() *App {
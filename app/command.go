@@ -33,13 +33,19 @@ func (a *App) GetCommands() []core.Command {
 	return commands
 }
 
-func (a *App) AddCommand(name string, command string) error {
+func (a *App) AddCommand(name string, command string, settings core.CommandSettings) error {
 	err := a.core.FetchCommands()
 
 	if err != nil {
 		return fmt.Errorf("error getting commands config: %s", err)
 	}
 
+	err = core.ValidateCommandSettings(settings)
+
+	if err != nil {
+		return fmt.Errorf("invalid command settings: %s", err)
+	}
+
 	msg := a.core.AddCommand(name, command)
 
 	if _, ok := msg.(*common.ErrMsg); ok {
@@ -47,16 +53,29 @@ func (a *App) AddCommand(name string, command string) error {
 		return fmt.Errorf("%s", msg.GetText())
 	}
 
+	msg = a.core.SetCommandSettings(name, settings)
+
+	if _, ok := msg.(*common.ErrMsg); ok {
+		fmt.Println(msg.GetText())
+		return fmt.Errorf("%s", msg.GetText())
+	}
+
 	return nil
 }
 
-func (a *App) UpdateCommand(id int64, name string, command string) error {
+func (a *App) UpdateCommand(id int64, name string, command string, settings core.CommandSettings) error {
 	err := a.core.FetchCommands()
 
 	if err != nil {
 		return fmt.Errorf("error getting commands config: %s", err)
 	}
 
+	err = core.ValidateCommandSettings(settings)
+
+	if err != nil {
+		return fmt.Errorf("invalid command settings: %s", err)
+	}
+
 	msg := a.core.UpdateCommandById(id, name, command)
 
 	if _, ok := msg.(*common.ErrMsg); ok {
@@ -64,6 +83,13 @@ func (a *App) UpdateCommand(id int64, name string, command string) error {
 		return fmt.Errorf("%s", msg.GetText())
 	}
 
+	msg = a.core.SetCommandSettingsById(id, settings)
+
+	if _, ok := msg.(*common.ErrMsg); ok {
+		fmt.Println(msg.GetText())
+		return fmt.Errorf("%s", msg.GetText())
+	}
+
 	return nil
 }
 
@@ -83,3 +109,91 @@ func (a *App) RemoveCommand(id int64) error {
 
 	return nil
 }
+
+func (a *App) GetCommandDependencies(name string) []string {
+	err := a.core.FetchCommands()
+
+	if err != nil {
+		fmt.Println("Error getting commands config:", err)
+
+		return nil
+	}
+
+	dependencies, err := a.core.GetCommandDependencies(name)
+
+	if err != nil {
+		fmt.Println("Error getting command dependencies:", err)
+
+		return nil
+	}
+
+	return dependencies
+}
+
+func (a *App) SetCommandDependencies(name string, dependsOn []string) error {
+	err := a.core.FetchCommands()
+
+	if err != nil {
+		return fmt.Errorf("error getting commands config: %s", err)
+	}
+
+	msg := a.core.SetCommandDependencies(name, dependsOn)
+
+	if _, ok := msg.(*common.ErrMsg); ok {
+		fmt.Println(msg.GetText())
+		return fmt.Errorf("%s", msg.GetText())
+	}
+
+	return nil
+}
+
+func (a *App) SetCommandReadiness(name string, check string, value string) error {
+	err := a.core.FetchCommands()
+
+	if err != nil {
+		return fmt.Errorf("error getting commands config: %s", err)
+	}
+
+	msg := a.core.SetCommandReadiness(name, check, value)
+
+	if _, ok := msg.(*common.ErrMsg); ok {
+		fmt.Println(msg.GetText())
+		return fmt.Errorf("%s", msg.GetText())
+	}
+
+	return nil
+}
+
+func (a *App) SetCommandRestartPolicy(name string, policy string, maxRetries int64) error {
+	err := a.core.FetchCommands()
+
+	if err != nil {
+		return fmt.Errorf("error getting commands config: %s", err)
+	}
+
+	msg := a.core.SetCommandRestartPolicy(name, policy, maxRetries)
+
+	if _, ok := msg.(*common.ErrMsg); ok {
+		fmt.Println(msg.GetText())
+		return fmt.Errorf("%s", msg.GetText())
+	}
+
+	return nil
+}
+
+func (a *App) SetCommandStopSignal(name string, signal string, gracePeriod int64) error {
+	err := a.core.FetchCommands()
+
+	if err != nil {
+		return fmt.Errorf("error getting commands config: %s", err)
+	}
+
+	msg := a.core.SetCommandStopSignal(name, signal, gracePeriod)
+
+	if _, ok := msg.(*common.ErrMsg); ok {
+		fmt.Println(msg.GetText())
+		return fmt.Errorf("%s", msg.GetText())
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/iskandervdh/spinup/common"
+)
+
+func (a *App) AddEnvVariable(projectName string, key string, value string) error {
+	err := a.core.FetchProjects()
+
+	if err != nil {
+		return fmt.Errorf("error getting projects config: %s", err)
+	}
+
+	msg := a.core.AddEnvVariable(projectName, key, value)
+
+	if _, ok := msg.(*common.ErrMsg); ok {
+		fmt.Println(msg.GetText())
+		return fmt.Errorf("%s", msg.GetText())
+	}
+
+	return nil
+}
+
+func (a *App) RemoveEnvVariable(projectName string, key string) error {
+	err := a.core.FetchProjects()
+
+	if err != nil {
+		return fmt.Errorf("error getting projects config: %s", err)
+	}
+
+	msg := a.core.RemoveEnvVariable(projectName, key)
+
+	if _, ok := msg.(*common.ErrMsg); ok {
+		fmt.Println(msg.GetText())
+		return fmt.Errorf("%s", msg.GetText())
+	}
+
+	return nil
+}
+
+func (a *App) AddEnvFile(projectName string, path string) error {
+	err := a.core.FetchProjects()
+
+	if err != nil {
+		return fmt.Errorf("error getting projects config: %s", err)
+	}
+
+	msg := a.core.AddEnvFile(projectName, path)
+
+	if _, ok := msg.(*common.ErrMsg); ok {
+		fmt.Println(msg.GetText())
+		return fmt.Errorf("%s", msg.GetText())
+	}
+
+	return nil
+}
+
+func (a *App) RemoveEnvFile(projectName string, path string) error {
+	err := a.core.FetchProjects()
+
+	if err != nil {
+		return fmt.Errorf("error getting projects config: %s", err)
+	}
+
+	msg := a.core.RemoveEnvFile(projectName, path)
+
+	if _, ok := msg.(*common.ErrMsg); ok {
+		fmt.Println(msg.GetText())
+		return fmt.Errorf("%s", msg.GetText())
+	}
+
+	return nil
+}
@@ -0,0 +1,172 @@
+package app
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/iskandervdh/spinup/common"
+	"github.com/iskandervdh/spinup/core"
+	"github.com/iskandervdh/spinup/daemon"
+)
+
+func (a *App) GetGroups() (core.Groups, error) {
+	groups, err := a.core.GetGroups()
+
+	if err != nil {
+		fmt.Println("Error getting groups:", err)
+		return nil, err
+	}
+
+	return groups, nil
+}
+
+func (a *App) AddGroup(name string, projectNames []string) error {
+	err := a.core.FetchProjects()
+
+	if err != nil {
+		return fmt.Errorf("error getting projects config: %s", err)
+	}
+
+	msg := a.core.AddGroup(name, projectNames)
+
+	if _, ok := msg.(*common.ErrMsg); ok {
+		fmt.Println(msg.GetText())
+		return fmt.Errorf("%s", msg.GetText())
+	}
+
+	return nil
+}
+
+func (a *App) RemoveGroup(name string) error {
+	msg := a.core.RemoveGroup(name)
+
+	if _, ok := msg.(*common.ErrMsg); ok {
+		fmt.Println(msg.GetText())
+		return fmt.Errorf("%s", msg.GetText())
+	}
+
+	return nil
+}
+
+func (a *App) AddProjectToGroup(groupName string, projectName string) error {
+	err := a.core.FetchProjects()
+
+	if err != nil {
+		return fmt.Errorf("error getting projects config: %s", err)
+	}
+
+	msg := a.core.AddProjectToGroup(groupName, projectName)
+
+	if _, ok := msg.(*common.ErrMsg); ok {
+		fmt.Println(msg.GetText())
+		return fmt.Errorf("%s", msg.GetText())
+	}
+
+	return nil
+}
+
+func (a *App) RemoveProjectFromGroup(groupName string, projectName string) error {
+	err := a.core.FetchProjects()
+
+	if err != nil {
+		return fmt.Errorf("error getting projects config: %s", err)
+	}
+
+	msg := a.core.RemoveProjectFromGroup(groupName, projectName)
+
+	if _, ok := msg.(*common.ErrMsg); ok {
+		fmt.Println(msg.GetText())
+		return fmt.Errorf("%s", msg.GetText())
+	}
+
+	return nil
+}
+
+// Get the names of the projects of a group and the names of the projects that are running in the daemon.
+func (a *App) groupProjects(client *daemon.Client, groupName string) ([]string, []string, error) {
+	projectNames, msg := a.core.GetGroupProjectNames(groupName)
+
+	if msg != nil {
+		return nil, nil, fmt.Errorf("%s", msg.GetText())
+	}
+
+	statuses, err := client.Status()
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	running := []string{}
+
+	for _, status := range statuses {
+		running = append(running, status.Project)
+	}
+
+	return projectNames, running, nil
+}
+
+// Start all projects of a group in the daemon, skipping the ones that are already running.
+func (a *App) StartGroup(groupName string) error {
+	client, err := a.connectToDaemon()
+
+	if err != nil {
+		return err
+	}
+
+	defer client.Close()
+
+	projectNames, running, err := a.groupProjects(client, groupName)
+
+	if err != nil {
+		fmt.Println("Error starting group:", err)
+		return err
+	}
+
+	for _, projectName := range projectNames {
+		if slices.Contains(running, projectName) {
+			continue
+		}
+
+		_, err = client.Start(projectName)
+
+		if err != nil {
+			fmt.Println("Error running project:", err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Stop all projects of a group that are running in the daemon.
+func (a *App) StopGroup(groupName string) error {
+	client, err := daemon.Dial(a.core.GetConfig())
+
+	if err != nil {
+		return fmt.Errorf("group '%s' is not running", groupName)
+	}
+
+	defer client.Close()
+
+	projectNames, running, err := a.groupProjects(client, groupName)
+
+	if err != nil {
+		fmt.Println("Error stopping group:", err)
+		return err
+	}
+
+	for _, projectName := range projectNames {
+		if !slices.Contains(running, projectName) {
+			continue
+		}
+
+		_, err = client.Stop(projectName)
+
+		if err != nil {
+			fmt.Println("Error stopping project:", err)
+			return err
+		}
+	}
+
+	return nil
+}
@@ -1,78 +1,53 @@
 package app
 
 import (
-	"bufio"
 	"fmt"
-	"io"
-	"os"
-	"time"
 
+	"github.com/iskandervdh/spinup/core"
 	"github.com/wailsapp/wails/v2/pkg/runtime"
 )
 
-type LogEvent struct {
-	NewLogData string `json:"newLogData"`
-}
-
-func (a *App) FollowProjectLogs(projectName string) error {
-	runningProject, ok := a.runningProjects[projectName]
-
-	if !ok {
-		return fmt.Errorf("project '%s' is not running", projectName)
-	}
-
-	logFilePath, err := runningProject.GetLogFilePath()
+// Get a page of the log lines of the last run of a project that match the given filter.
+//
+// The last page is returned if before is 0, older pages can be requested with the Before of the previous page.
+func (a *App) SearchProjectLogs(projectName string, filter core.LogFilter, before int64, limit int) (core.LogPage, error) {
+	page, err := a.core.SearchLogs(projectName, filter, before, limit)
 
 	if err != nil {
-		return err
+		fmt.Println(err)
+		return core.LogPage{}, err
 	}
 
-	logFile, err := os.Open(logFilePath)
-
-	if err != nil {
-		return err
-	}
-
-	defer logFile.Close()
-
-	reader := bufio.NewReader(logFile)
-	runningProject.readingLogs = true
-
-	logChannel := make(chan string, 100)
-	defer close(logChannel)
-
-	go func() {
-		for logLine := range logChannel {
-			runtime.EventsEmit(a.ctx, "log", logLine)
-		}
-	}()
+	return page, nil
+}
 
-	for runningProject.readingLogs {
-		line, err := reader.ReadString('\n')
+// Follow the logs of a project starting at the given offset, emitting a "log" event for every new line that
+// matches the given filter until StopFollowingProjectLogs is called.
+func (a *App) FollowProjectLogs(projectName string, filter core.LogFilter, offset int64) error {
+	stop := make(chan struct{})
 
-		if err != nil {
-			if err == io.EOF {
-				time.Sleep(100 * time.Millisecond) // Reduce sleep time for faster response
-				continue
-			}
+	// Only follow the logs of a project once, with the filter that was set last
+	if previous, ok := a.followingLogs.Swap(projectName, stop); ok {
+		close(previous.(chan struct{}))
+	}
 
-			return err // Return error if it's not EOF
-		}
+	err := a.core.FollowFilteredLogs(projectName, filter, offset, stop, func(line core.LogLine) {
+		runtime.EventsEmit(a.ctx, "log", line)
+	})
 
-		logChannel <- line
-	}
+	a.followingLogs.CompareAndDelete(projectName, stop)
 
-	return nil
+	return err
 }
 
 func (a *App) StopFollowingProjectLogs(projectName string) error {
-	runningProject, ok := a.runningProjects[projectName]
+	stop, ok := a.followingLogs.LoadAndDelete(projectName)
 
 	if !ok {
-		return fmt.Errorf("project '%s' is not running", projectName)
+		return fmt.Errorf("not following the logs of project '%s'", projectName)
 	}
 
-	runningProject.readingLogs = false
+	close(stop.(chan struct{}))
 
 	return nil
 }
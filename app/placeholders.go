@@ -19,3 +19,11 @@ func (a *App) VariablePlaceholder() core.Variable {
 func (a *App) DomainAliasPlaceholder() core.DomainAlias {
 	return core.DomainAlias{}
 }
+
+func (a *App) EnvVariablePlaceholder() core.EnvVariable {
+	return core.EnvVariable{}
+}
+
+func (a *App) EnvFilePlaceholder() core.EnvFile {
+	return core.EnvFile{}
+}
@@ -1,152 +1,138 @@
 package app
 
 import (
-	"errors"
 	"fmt"
-	"os"
-	"path"
-	"sync"
-	"syscall"
 
-	"github.com/iskandervdh/spinup/common"
 	"github.com/iskandervdh/spinup/core"
+	"github.com/iskandervdh/spinup/daemon"
 )
 
-type runningProject struct {
-	core        *core.Core
-	msgChan     *chan common.Msg
-	msgChanWg   *sync.WaitGroup
-	logFile     *os.File
-	readingLogs bool
+// Connect to the daemon that runs the projects, starting it if needed.
+func (a *App) connectToDaemon() (*daemon.Client, error) {
+	client, err := daemon.Connect(a.core.GetConfig())
+
+	if err != nil {
+		return nil, fmt.Errorf("could not connect to daemon: %s", err)
+	}
+
+	return client, nil
 }
 
-func NewRunningProject() *runningProject {
-	// Create output file to write logs to
-	msgChan := make(chan common.Msg, 100)
-	msgChanWg := sync.WaitGroup{}
-	core := core.New(core.WithMsgChan(&msgChan))
+func (a *App) RunProject(projectName string) error {
+	client, err := a.connectToDaemon()
+
+	if err != nil {
+		return err
+	}
 
-	core.FetchCommands()
-	core.FetchProjects()
+	defer client.Close()
 
-	rp := &runningProject{
-		core:      core,
-		msgChan:   &msgChan,
-		msgChanWg: &msgChanWg,
+	_, err = client.Start(projectName)
+
+	if err != nil {
+		fmt.Println("Error running project:", err)
+		return err
 	}
 
-	rp.msgChanWg.Add(1)
+	return nil
+}
 
-	go func() {
-		defer msgChanWg.Done()
-		defer rp.logFile.Close()
+func (a *App) StopProject(projectName string) string {
+	client, err := daemon.Dial(a.core.GetConfig())
 
-		for msg := range *rp.msgChan {
-			// If there is no log file linked to the currently running project
-			// we can just forward the output to stdout
-			if rp.logFile == nil {
-				fmt.Println(msg.GetText())
+	if err != nil {
+		return fmt.Sprintf("project '%s' is not running", projectName)
+	}
 
-				continue
-			}
+	defer client.Close()
 
-			// Write the output of the running project to the log file specified on the running project instance
-			_, err := rp.logFile.Write([]byte(fmt.Sprintln(msg.GetText())))
+	message, err := client.Stop(projectName)
 
-			if err != nil {
-				fmt.Println("Error writing to log file:", err)
-			}
-		}
-	}()
+	if err != nil {
+		return err.Error()
+	}
 
-	return rp
+	return message
 }
 
-func (rp *runningProject) useLogFile(projectName string) error {
-	configDir := rp.core.GetConfig().GetConfigDir()
-	logDirPath := path.Join(configDir, "logs")
-
-	// Make a log directory for the project if it does not exist
-	err := os.MkdirAll(logDirPath, 0755)
+// Stop a single command of a running project without stopping the other commands.
+func (a *App) StopCommand(projectName string, commandName string) error {
+	client, err := daemon.Dial(a.core.GetConfig())
 
 	if err != nil {
-		return err
+		return fmt.Errorf("project '%s' is not running", projectName)
 	}
 
-	// Determine the name of the log file
-	logFilePath := path.Join(logDirPath, projectName+".log")
-	logFile, err := os.Create(logFilePath)
+	defer client.Close()
+
+	_, err = client.StopCommand(projectName, commandName)
 
 	if err != nil {
+		fmt.Println("Error stopping command:", err)
 		return err
 	}
 
-	rp.logFile = logFile
-
-	rp.core.SetOut(rp.logFile)
-	rp.core.SetErr(rp.logFile)
-
 	return nil
 }
 
-func (rp *runningProject) GetLogFilePath() (string, error) {
-	if rp.logFile == nil {
-		return "", errors.New("no logs available for project")
+// Start a single command of a running project after it was stopped or exited.
+func (a *App) StartCommand(projectName string, commandName string) error {
+	client, err := daemon.Dial(a.core.GetConfig())
+
+	if err != nil {
+		return fmt.Errorf("project '%s' is not running", projectName)
 	}
 
-	return rp.logFile.Name(), nil
-}
+	defer client.Close()
 
-func (a *App) RunProject(projectName string) error {
-	if a.runningProjects == nil {
-		a.runningProjects = make(map[string]*runningProject)
-	}
+	_, err = client.StartCommand(projectName, commandName)
 
-	if _, ok := a.runningProjects[projectName]; ok {
-		return fmt.Errorf("project '%s' is already running", projectName)
+	if err != nil {
+		fmt.Println("Error starting command:", err)
+		return err
 	}
 
-	runningProject := NewRunningProject()
-
-	a.runningProjects[projectName] = runningProject
+	return nil
+}
 
-	err := runningProject.useLogFile(projectName)
+// Restart a single command of a running project without restarting the other commands.
+func (a *App) RestartCommand(projectName string, commandName string) error {
+	client, err := daemon.Dial(a.core.GetConfig())
 
 	if err != nil {
-		return err
+		return fmt.Errorf("project '%s' is not running", projectName)
 	}
 
-	msg := runningProject.core.TryToRun(projectName)
+	defer client.Close()
 
-	if _, ok := msg.(*common.ErrMsg); ok {
-		fmt.Println(msg)
-		return errors.New(msg.GetText())
-	} else if msg == nil {
-		return fmt.Errorf("project '%s' does not exist", projectName)
+	_, err = client.RestartCommand(projectName, commandName)
+
+	if err != nil {
+		fmt.Println("Error restarting command:", err)
+		return err
 	}
 
-	return errors.New(msg.GetText())
+	return nil
 }
 
-func (a *App) StopProject(projectName string) string {
-	if a.runningProjects == nil {
-		return fmt.Sprintf("project '%s' is not running", projectName)
-	}
-
-	runningProject, ok := a.runningProjects[projectName]
+// Get the status of the projects that are running in the daemon and their commands.
+//
+// Returns an empty list if the daemon is not running.
+func (a *App) GetRunningProjects() ([]core.ProjectStatus, error) {
+	client, err := daemon.Dial(a.core.GetConfig())
 
-	if !ok {
-		return fmt.Sprintf("project '%s' is not running", projectName)
+	if err != nil {
+		return []core.ProjectStatus{}, nil
 	}
 
-	sigChan := runningProject.core.GetSigChan()
-	*sigChan <- syscall.SIGINT
+	defer client.Close()
 
-	if _, ok := a.runningProjects[projectName]; ok {
-		delete(a.runningProjects, projectName)
+	statuses, err := client.Status()
 
-		return fmt.Sprintf("project '%s' stopped", projectName)
+	if err != nil {
+		fmt.Println("Error getting running projects:", err)
+		return nil, err
 	}
 
-	return fmt.Sprintf("project '%s' is not running", projectName)
+	return statuses, nil
 }
@@ -4,11 +4,13 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"slices"
 	"strings"
 	"sync"
 
 	"github.com/iskandervdh/spinup/common"
 	"github.com/iskandervdh/spinup/core"
+	"github.com/iskandervdh/spinup/daemon"
 )
 
 // CLI struct that that determines the input and output of the CLI.
@@ -94,7 +96,116 @@ func (c *CLI) sendMsg(msg common.Msg) {
 }
 
 func (c *CLI) sendHelpMsg() {
-	c.sendMsg(common.NewRegularMsg("Usage: %s <command|project|variable|run|init> [args...]\n", common.ProgramName))
+	c.sendMsg(common.NewRegularMsg("Usage: %s <command|project|group|variable|env|domain-alias|route|run|start|stop|restart|ps|status|logs|export|import|db|proxy|https|tld|daemon|init> [args...]\n", common.ProgramName))
+}
+
+// Handle the run subcommand, running one or more projects in the foreground or in the background with --detach.
+func (c *CLI) handleRun() {
+	args := []string{}
+	projectNames := []string{}
+	detach := false
+	all := false
+
+	for _, arg := range os.Args[2:] {
+		switch arg {
+		case "--detach", "-d":
+			detach = true
+		case "--all", "-a":
+			all = true
+		default:
+			args = append(args, arg)
+		}
+	}
+
+	if all && len(args) > 0 {
+		c.sendMsg(common.NewRegularMsg("Usage: %s run <project...|@group...>|--all|-a [--detach|-d]\n", common.ProgramName))
+		c.sendMsg(common.NewRegularMsg("Projects and groups cannot be combined with --all\n"))
+		return
+	}
+
+	for _, arg := range args {
+		names := []string{arg}
+
+		// Run all projects of a group with @group
+		if groupName, ok := strings.CutPrefix(arg, core.GroupPrefix); ok {
+			groupProjectNames, msg := c.core.GetGroupProjectNames(groupName)
+
+			if msg != nil {
+				c.sendMsg(msg)
+				return
+			}
+
+			names = groupProjectNames
+		}
+
+		for _, name := range names {
+			if !slices.Contains(projectNames, name) {
+				projectNames = append(projectNames, name)
+			}
+		}
+	}
+
+	if all {
+		projectNames = c.core.GetProjectNames()
+
+		if len(projectNames) == 0 {
+			c.sendMsg(common.NewErrMsg("No projects found\n"))
+			return
+		}
+	}
+
+	// Run the projects of the spinup file of the repository the current directory is part of
+	if len(projectNames) == 0 {
+		path, found := core.FindSpinupFile(".")
+
+		if !found {
+			c.sendMsg(common.NewRegularMsg("Usage: %s run <project...|@group...>|--all|-a [--detach|-d]\n", common.ProgramName))
+			c.sendMsg(common.NewRegularMsg("Without a project name the projects of a %s file in the current directory or its parents are run\n", core.SpinupFileNames[0]))
+			return
+		}
+
+		names, msg := c.core.RegisterSpinupFile(path)
+
+		if msg != nil {
+			c.sendMsg(msg)
+			return
+		}
+
+		projectNames = names
+	}
+
+	for _, projectName := range projectNames {
+		if exists, _ := c.core.ProjectExists(projectName); !exists {
+			c.sendMsg(common.NewErrMsg("Unknown project '%s'\n", projectName))
+			return
+		}
+
+		if c.isRunningDetached(projectName) {
+			c.sendMsg(common.NewErrMsg("Project '%s' is already running in the background\n", projectName))
+			return
+		}
+	}
+
+	if detach {
+		for _, projectName := range projectNames {
+			c.runDetached(projectName)
+		}
+
+		return
+	}
+
+	var result common.Msg
+
+	if len(projectNames) == 1 {
+		result = c.core.TryToRun(projectNames[0])
+	} else {
+		result = c.core.RunProjects(projectNames)
+	}
+
+	if _, ok := result.(*common.ErrMsg); ok {
+		c.ErrorPrint(result)
+		os.Exit(1)
+	}
 }
 
 // Function to be called after the CLI has been initialized.
@@ -117,26 +228,42 @@ func (c *CLI) Handle() {
 			c.handleCommand()
 		case "project", "p":
 			c.handleProject()
+		case "group", "g":
+			c.handleGroup()
 		case "variable", "v":
 			c.handleVariable()
+		case "env":
+			c.handleEnv()
 		case "domain-alias", "da":
 			c.handleDomainAlias()
+		case "route":
+			c.handleRoute()
 		case "run":
-			if len(os.Args) < 3 {
-				c.sendMsg(common.NewRegularMsg("Usage: %s run <project>\n", common.ProgramName))
-				break
-			}
-
-			result := c.core.TryToRun(os.Args[2])
-
-			if _, ok := result.(*common.ErrMsg); ok {
-				c.ErrorPrint(result)
-				os.Exit(1)
-			}
-
-			if result == nil {
-				c.sendMsg(common.NewErrMsg("Unknown project '%s'\n", os.Args[2]))
-			}
+			c.handleRun()
+		case "stop":
+			c.handleStop()
+		case "start":
+			c.handleStart()
+		case "restart":
+			c.handleRestart()
+		case "ps", "status":
+			c.handleStatus()
+		case "logs":
+			c.handleLogs()
+		case "export":
+			c.handleExport()
+		case "import":
+			c.handleImport()
+		case "db":
+			c.handleDB()
+		case "proxy", "nginx":
+			c.handleProxy()
+		case "https":
+			c.handleHTTPS()
+		case "tld":
+			c.handleTLD()
+		case "daemon":
+			daemon.Main()
 		default:
 			result := c.core.TryToRun(os.Args[1])
 
@@ -258,4 +258,8 @@ func TestCLIHandleRun(t *testing.T) {
 	c = TestingCLI("handle_run_no_arg")
 	os.Args = []string{common.ProgramName, "run"}
 	c.Handle()
+
+	c = TestingCLI("handle_run_all_with_project")
+	os.Args = []string{common.ProgramName, "run", "--all", "test"}
+	c.Handle()
 }
@@ -3,10 +3,77 @@ package cli
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/iskandervdh/spinup/common"
+	"github.com/iskandervdh/spinup/core"
 )
 
+const commandSettingsFlagsUsage = "[--shell|--no-shell] [--dir <dir>] [--env KEY=VALUE...] [--force-color|--no-force-color] [--watch <glob>...] [--watch-exclude <glob>...] [--no-watch]"
+
+// Parse the command settings flags in the given arguments and apply them to the given settings.
+//
+// When one or more --env, --watch or --watch-exclude flags are given they replace the existing values of the settings.
+func parseCommandSettingsFlags(args []string, settings core.CommandSettings) (core.CommandSettings, error) {
+	envReplaced := false
+	watchReplaced := false
+	watchExcludeReplaced := false
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--shell":
+			settings.Shell = true
+		case "--no-shell":
+			settings.Shell = false
+		case "--force-color":
+			settings.ForceColor = true
+		case "--no-force-color":
+			settings.ForceColor = false
+		case "--no-watch":
+			settings.Watch = nil
+			settings.WatchExclude = nil
+		case "--dir", "--env", "--watch", "--watch-exclude":
+			if i+1 >= len(args) {
+				return settings, fmt.Errorf("flag '%s' requires a value", args[i])
+			}
+
+			switch args[i] {
+			case "--dir":
+				settings.Dir = args[i+1]
+			case "--env":
+				if !envReplaced {
+					settings.Env = nil
+					envReplaced = true
+				}
+
+				settings.Env = append(settings.Env, args[i+1])
+			case "--watch":
+				if !watchReplaced {
+					settings.Watch = nil
+					watchReplaced = true
+				}
+
+				settings.Watch = append(settings.Watch, args[i+1])
+			case "--watch-exclude":
+				if !watchExcludeReplaced {
+					settings.WatchExclude = nil
+					watchExcludeReplaced = true
+				}
+
+				settings.WatchExclude = append(settings.WatchExclude, args[i+1])
+			}
+
+			i++
+		default:
+			return settings, fmt.Errorf("unknown flag '%s'", args[i])
+		}
+	}
+
+	return settings, nil
+}
+
 // Print a list of all commands to the output of the CLI.
 func (c *CLI) listCommands() {
 	commands, err := c.core.GetCommands()
@@ -16,10 +83,16 @@ func (c *CLI) listCommands() {
 		return
 	}
 
-	fmt.Fprintf(c.out, "%-20s %-30s\n", "Name", "Command")
+	fmt.Fprintf(c.out, "%-20s %-30s %-6s %-15s %-20s\n", "Name", "Command", "Shell", "Dir", "Env")
 
 	for _, command := range commands {
-		fmt.Fprintf(c.out, "%-20s %-30s\n", command.Name, command.Command)
+		fmt.Fprintf(c.out, "%-20s %-30s %-6t %-15s %-20s\n",
+			command.Name,
+			command.Command,
+			command.Shell,
+			command.Dir.String,
+			strings.Join(core.GetCommandSettings(command).Env, " "),
+		)
 	}
 }
 
@@ -93,7 +166,7 @@ func (c *CLI) editCommandInteractive() {
 // Handle the command subcommand.
 func (c *CLI) handleCommand() {
 	if len(os.Args) < 3 {
-		c.sendMsg(common.NewRegularMsg("Usage: spinup command <add|remove|edit|rename|list> [args...]\n"))
+		c.sendMsg(common.NewRegularMsg("Usage: spinup command <add|remove|edit|rename|set-shell|depends-on|ready|restart|stop-signal|list> [args...]\n"))
 		return
 	}
 
@@ -109,11 +182,29 @@ func (c *CLI) handleCommand() {
 		}
 
 		if len(os.Args) < 5 {
-			c.sendMsg(common.NewRegularMsg("Usage: %s command|c add <name> <command>\n", common.ProgramName))
+			c.sendMsg(common.NewRegularMsg("Usage: %s command|c add <name> <command> %s\n", common.ProgramName, commandSettingsFlagsUsage))
 			return
 		}
 
-		c.sendMsg(c.core.AddCommand(os.Args[3], os.Args[4]))
+		settings, err := parseCommandSettingsFlags(os.Args[5:], core.DefaultCommandSettings())
+
+		if err == nil {
+			err = core.ValidateCommandSettings(settings)
+		}
+
+		if err != nil {
+			c.ErrorPrint(err)
+			return
+		}
+
+		msg := c.core.AddCommand(os.Args[3], os.Args[4])
+		c.sendMsg(msg)
+
+		if _, ok := msg.(*common.ErrMsg); ok || len(os.Args) == 5 {
+			return
+		}
+
+		c.sendMsg(c.core.SetCommandSettings(os.Args[3], settings))
 	case "remove", "rm":
 		if len(os.Args) == 3 {
 			c.removeCommandInteractive()
@@ -133,11 +224,36 @@ func (c *CLI) handleCommand() {
 		}
 
 		if len(os.Args) < 5 {
-			c.sendMsg(common.NewRegularMsg("Usage: %s command|c edit|e <name> <command>\n", common.ProgramName))
+			c.sendMsg(common.NewRegularMsg("Usage: %s command|c edit|e <name> <command> %s\n", common.ProgramName, commandSettingsFlagsUsage))
 			return
 		}
 
-		c.sendMsg(c.core.UpdateCommand(os.Args[3], os.Args[4]))
+		exists, command := c.core.CommandExists(os.Args[3])
+
+		if !exists {
+			c.sendMsg(common.NewErrMsg("Command '%s' does not exist", os.Args[3]))
+			return
+		}
+
+		settings, err := parseCommandSettingsFlags(os.Args[5:], core.GetCommandSettings(command))
+
+		if err == nil {
+			err = core.ValidateCommandSettings(settings)
+		}
+
+		if err != nil {
+			c.ErrorPrint(err)
+			return
+		}
+
+		msg := c.core.UpdateCommand(os.Args[3], os.Args[4])
+		c.sendMsg(msg)
+
+		if _, ok := msg.(*common.ErrMsg); ok || len(os.Args) == 5 {
+			return
+		}
+
+		c.sendMsg(c.core.SetCommandSettings(os.Args[3], settings))
 	case "rename", "mv":
 		if len(os.Args) < 5 {
 			c.sendMsg(common.NewRegularMsg("Usage: %s command|c rename|mv <old-name> <new-name>\n", common.ProgramName))
@@ -145,8 +261,85 @@ func (c *CLI) handleCommand() {
 		}
 
 		c.sendMsg(c.core.RenameCommand(os.Args[3], os.Args[4]))
+	case "set-shell", "ss":
+		if len(os.Args) < 5 {
+			c.sendMsg(common.NewRegularMsg("Usage: %s command|c set-shell|ss <name> <true|false>\n", common.ProgramName))
+			return
+		}
+
+		shell, err := strconv.ParseBool(os.Args[4])
+
+		if err != nil {
+			c.ErrorPrint("Shell mode must be either true or false")
+			return
+		}
+
+		c.sendMsg(c.core.SetCommandShell(os.Args[3], shell))
+	case "depends-on", "do":
+		if len(os.Args) < 4 {
+			c.sendMsg(common.NewRegularMsg("Usage: %s command|c depends-on|do <name> [commands...]\n", common.ProgramName))
+			return
+		}
+
+		c.sendMsg(c.core.SetCommandDependencies(os.Args[3], os.Args[4:]))
+	case "ready":
+		if len(os.Args) < 5 {
+			c.sendMsg(common.NewRegularMsg("Usage: %s command|c ready <name> <tcp|http|log|delay|none> [value]\n", common.ProgramName))
+			return
+		}
+
+		check := os.Args[4]
+		value := ""
+
+		if check == "none" {
+			check = core.ReadyCheckNone
+		}
+
+		if len(os.Args) > 5 {
+			value = os.Args[5]
+		}
+
+		c.sendMsg(c.core.SetCommandReadiness(os.Args[3], check, value))
+	case "restart":
+		if len(os.Args) < 5 {
+			c.sendMsg(common.NewRegularMsg("Usage: %s command|c restart <name> <never|on-failure|always> [max-retries]\n", common.ProgramName))
+			return
+		}
+
+		maxRetries := int64(core.DefaultMaxRetries)
+
+		if len(os.Args) > 5 {
+			var err error
+			maxRetries, err = strconv.ParseInt(os.Args[5], 10, 64)
+
+			if err != nil {
+				c.ErrorPrint("Maximum number of retries must be a number")
+				return
+			}
+		}
+
+		c.sendMsg(c.core.SetCommandRestartPolicy(os.Args[3], os.Args[4], maxRetries))
+	case "stop-signal", "sig":
+		if len(os.Args) < 5 {
+			c.sendMsg(common.NewRegularMsg("Usage: %s command|c stop-signal|sig <name> <SIGINT|SIGTERM|SIGQUIT> [grace-period]\n", common.ProgramName))
+			return
+		}
+
+		gracePeriod := int64(core.DefaultStopTimeout / time.Second)
+
+		if len(os.Args) > 5 {
+			var err error
+			gracePeriod, err = strconv.ParseInt(os.Args[5], 10, 64)
+
+			if err != nil {
+				c.ErrorPrint("Grace period must be a number of seconds")
+				return
+			}
+		}
+
+		c.sendMsg(c.core.SetCommandStopSignal(os.Args[3], os.Args[4], gracePeriod))
 	default:
 		c.sendMsg(common.NewErrMsg("Unknown subcommand '%s'\n", commandName))
-		c.sendMsg(common.NewRegularMsg("Expected 'add', 'remove', 'edit', 'rename' or 'list'\n"))
+		c.sendMsg(common.NewRegularMsg("Expected 'add', 'remove', 'edit', 'rename', 'set-shell', 'depends-on', 'ready', 'restart', 'stop-signal' or 'list'\n"))
 	}
 }
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/iskandervdh/spinup/common"
+	"github.com/iskandervdh/spinup/core"
 )
 
 func TestHandleCommandTooFewArguments(t *testing.T) {
@@ -26,3 +27,61 @@ func TestHandleCommandLs(t *testing.T) {
 	os.Args = []string{common.ProgramName, "c", "ls"}
 	c.Handle()
 }
+
+func TestParseCommandSettingsFlags(t *testing.T) {
+	settings, err := parseCommandSettingsFlags(
+		[]string{"--shell", "--dir", "api", "--env", "A=1", "--env", "B=2", "--no-force-color"},
+		core.CommandSettings{Env: []string{"OLD=1"}, ForceColor: true},
+	)
+
+	if err != nil {
+		t.Error("Expected no error, got", err)
+		return
+	}
+
+	if !settings.Shell || settings.Dir != "api" || settings.ForceColor {
+		t.Error("Expected flags to be applied, got", settings)
+	}
+
+	if len(settings.Env) != 2 || settings.Env[0] != "A=1" {
+		t.Error("Expected environment variables to be replaced, got", settings.Env)
+	}
+
+	_, err = parseCommandSettingsFlags([]string{"--dir"}, core.DefaultCommandSettings())
+
+	if err == nil {
+		t.Error("Expected error for missing flag value, got nil")
+	}
+
+	_, err = parseCommandSettingsFlags([]string{"--unknown"}, core.DefaultCommandSettings())
+
+	if err == nil {
+		t.Error("Expected error for unknown flag, got nil")
+	}
+}
+
+func TestParseCommandSettingsWatchFlags(t *testing.T) {
+	settings, err := parseCommandSettingsFlags(
+		[]string{"--watch", "**/*.go", "--watch", "go.mod", "--watch-exclude", "tmp"},
+		core.CommandSettings{Watch: []string{"*.js"}},
+	)
+
+	if err != nil {
+		t.Error("Expected no error, got", err)
+		return
+	}
+
+	if len(settings.Watch) != 2 || settings.Watch[0] != "**/*.go" {
+		t.Error("Expected watch patterns to be replaced, got", settings.Watch)
+	}
+
+	if len(settings.WatchExclude) != 1 || settings.WatchExclude[0] != "tmp" {
+		t.Error("Expected watch exclude pattern to be set, got", settings.WatchExclude)
+	}
+
+	settings, _ = parseCommandSettingsFlags([]string{"--no-watch"}, settings)
+
+	if len(settings.Watch) != 0 || len(settings.WatchExclude) != 0 {
+		t.Error("Expected watch patterns to be removed, got", settings.Watch, settings.WatchExclude)
+	}
+}
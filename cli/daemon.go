@@ -0,0 +1,262 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/iskandervdh/spinup/common"
+	"github.com/iskandervdh/spinup/core"
+	"github.com/iskandervdh/spinup/daemon"
+)
+
+// Run the given project in the background using the daemon, starting the daemon if needed.
+func (c *CLI) runDetached(projectName string) {
+	client, err := daemon.Connect(c.core.GetConfig())
+
+	if err != nil {
+		c.sendMsg(common.NewErrMsg("Could not connect to %s: %s\n", common.DaemonName, err))
+		return
+	}
+
+	defer client.Close()
+
+	message, err := client.Start(projectName)
+
+	if err != nil {
+		c.sendMsg(common.NewErrMsg("Could not start project '%s': %s\n", projectName, err))
+		return
+	}
+
+	c.sendMsg(common.NewSuccessMsg("%s in the background\n", message))
+	c.sendMsg(common.NewRegularMsg("Use '%s ps' to list running projects and '%s stop %s' to stop it\n", common.ProgramName, common.ProgramName, projectName))
+}
+
+// Check if the project with the given name is running in the daemon.
+func (c *CLI) isRunningDetached(projectName string) bool {
+	client, err := daemon.Dial(c.core.GetConfig())
+
+	if err != nil {
+		return false
+	}
+
+	defer client.Close()
+
+	statuses, err := client.Status()
+
+	if err != nil {
+		return false
+	}
+
+	for _, status := range statuses {
+		if status.Project == projectName {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Handle the stop subcommand, stopping a project or a single command of a project running in the background.
+func (c *CLI) handleStop() {
+	if len(os.Args) < 3 {
+		c.sendMsg(common.NewRegularMsg("Usage: %s stop <project> [command]\n", common.ProgramName))
+		return
+	}
+
+	client, err := daemon.Dial(c.core.GetConfig())
+
+	if err != nil {
+		c.sendMsg(common.NewErrMsg("No projects are running in the background\n"))
+		return
+	}
+
+	defer client.Close()
+
+	if len(os.Args) > 3 {
+		message, err := client.StopCommand(os.Args[2], os.Args[3])
+
+		if err != nil {
+			c.sendMsg(common.NewErrMsg("Could not stop command: %s\n", err))
+			return
+		}
+
+		c.sendMsg(common.NewSuccessMsg("%s\n", message))
+		return
+	}
+
+	message, err := client.Stop(os.Args[2])
+
+	if err != nil {
+		c.sendMsg(common.NewErrMsg("Could not stop project: %s\n", err))
+		return
+	}
+
+	c.sendMsg(common.NewSuccessMsg("%s\n", message))
+}
+
+// Handle the start subcommand, starting a single command of a project running in the background.
+func (c *CLI) handleStart() {
+	if len(os.Args) < 4 {
+		c.sendMsg(common.NewRegularMsg("Usage: %s start <project> <command>\n", common.ProgramName))
+		return
+	}
+
+	client, err := daemon.Dial(c.core.GetConfig())
+
+	if err != nil {
+		c.sendMsg(common.NewErrMsg("No projects are running in the background\n"))
+		return
+	}
+
+	defer client.Close()
+
+	message, err := client.StartCommand(os.Args[2], os.Args[3])
+
+	if err != nil {
+		c.sendMsg(common.NewErrMsg("Could not start command: %s\n", err))
+		return
+	}
+
+	c.sendMsg(common.NewSuccessMsg("%s\n", message))
+}
+
+// Handle the restart subcommand, restarting a project or a single command of a project running in the background.
+func (c *CLI) handleRestart() {
+	if len(os.Args) < 3 {
+		c.sendMsg(common.NewRegularMsg("Usage: %s restart <project> [command]\n", common.ProgramName))
+		return
+	}
+
+	client, err := daemon.Dial(c.core.GetConfig())
+
+	if err != nil {
+		c.sendMsg(common.NewErrMsg("No projects are running in the background\n"))
+		return
+	}
+
+	defer client.Close()
+
+	if len(os.Args) > 3 {
+		message, err := client.RestartCommand(os.Args[2], os.Args[3])
+
+		if err != nil {
+			c.sendMsg(common.NewErrMsg("Could not restart command: %s\n", err))
+			return
+		}
+
+		c.sendMsg(common.NewSuccessMsg("%s\n", message))
+		return
+	}
+
+	message, err := client.Restart(os.Args[2])
+
+	if err != nil {
+		c.sendMsg(common.NewErrMsg("Could not restart project: %s\n", err))
+		return
+	}
+
+	c.sendMsg(common.NewSuccessMsg("%s\n", message))
+}
+
+// Handle the ps and status subcommands by listing the projects running in the background and their commands.
+func (c *CLI) handleStatus() {
+	var projectName string
+	asJSON := false
+
+	for _, arg := range os.Args[2:] {
+		switch arg {
+		case "--json":
+			asJSON = true
+		default:
+			if projectName == "" {
+				projectName = arg
+			}
+		}
+	}
+
+	statuses := []core.ProjectStatus{}
+	client, err := daemon.Dial(c.core.GetConfig())
+
+	if err == nil {
+		defer client.Close()
+
+		statuses, err = client.Status()
+
+		if err != nil {
+			c.sendMsg(common.NewErrMsg("Could not get running projects: %s\n", err))
+			return
+		}
+	}
+
+	if projectName != "" {
+		statuses = slices.DeleteFunc(statuses, func(status core.ProjectStatus) bool {
+			return status.Project != projectName
+		})
+	}
+
+	if asJSON {
+		data, err := json.MarshalIndent(statuses, "", "  ")
+
+		if err != nil {
+			c.sendMsg(common.NewErrMsg("Could not encode status: %s\n", err))
+			return
+		}
+
+		fmt.Fprintln(c.out, string(data))
+		return
+	}
+
+	if len(statuses) == 0 {
+		if projectName != "" {
+			c.sendMsg(common.NewRegularMsg("Project '%s' is not running in the background\n", projectName))
+		} else {
+			c.sendMsg(common.NewRegularMsg("No projects are running in the background\n"))
+		}
+
+		return
+	}
+
+	fmt.Fprintf(c.out, "%-20s %-20s %-10s %-8s %-20s %-10s %-8s %-6s %-6s\n",
+		"Project", "Command", "State", "PID", "Started", "Uptime", "Restarts", "Exit", "Port")
+
+	for _, status := range statuses {
+		// Projects that are still starting do not have any commands yet
+		if len(status.Commands) == 0 {
+			fmt.Fprintf(c.out, "%-20s %-20s %-10s %-8s %-20s %-10s %-8s %-6s %-6s\n",
+				status.Project, "-", "starting", "-", status.StartedAt.Format(time.DateTime), "-", "-", "-", "-")
+			continue
+		}
+
+		for _, command := range status.Commands {
+			pid, started, uptime, exitCode := "-", "-", "-", "-"
+
+			if command.PID != 0 {
+				pid = fmt.Sprint(command.PID)
+				uptime = (time.Duration(command.Uptime) * time.Second).String()
+			}
+
+			if !command.StartedAt.IsZero() {
+				started = command.StartedAt.Format(time.DateTime)
+			}
+
+			if command.ExitCode != nil {
+				exitCode = fmt.Sprint(*command.ExitCode)
+			}
+
+			fmt.Fprintf(c.out, "%-20s %-20s %-10s %-8s %-20s %-10s %-8d %-6s %-6d\n",
+				command.Project,
+				command.Command,
+				command.State,
+				pid,
+				started,
+				uptime,
+				command.Restarts,
+				exitCode,
+				command.Port,
+			)
+		}
+	}
+}
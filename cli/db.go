@@ -0,0 +1,45 @@
+package cli
+
+import (
+	"os"
+
+	"github.com/iskandervdh/spinup/common"
+)
+
+// Handle the db command.
+func (c *CLI) handleDB() {
+	if len(os.Args) < 3 {
+		c.sendMsg(common.NewRegularMsg("Usage: %s db <backup|restore|reset> [args...]\n", common.ProgramName))
+		return
+	}
+
+	switch os.Args[2] {
+	case "backup":
+		path := ""
+
+		if len(os.Args) > 3 {
+			path = os.Args[3]
+		}
+
+		c.sendMsg(c.core.BackupDatabase(path))
+	case "restore":
+		if len(os.Args) < 4 {
+			c.sendMsg(common.NewRegularMsg("Usage: %s db restore <file>\n", common.ProgramName))
+			return
+		}
+
+		if !c.Confirm("Are you sure you want to replace all commands and projects with the ones in " + os.Args[3] + "?") {
+			return
+		}
+
+		c.sendMsg(c.core.RestoreDatabase(os.Args[3]))
+	case "reset":
+		if !c.Confirm("Are you sure you want to remove all commands and projects?") {
+			return
+		}
+
+		c.sendMsg(c.core.ResetDatabase())
+	default:
+		c.sendMsg(common.NewRegularMsg("Expected 'backup', 'restore' or 'reset'\n"))
+	}
+}
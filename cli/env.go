@@ -0,0 +1,64 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/iskandervdh/spinup/common"
+)
+
+// Print a list of all environment variables for a project to the output of the CLI.
+func (c *CLI) listEnvVariables(name string) error {
+	exists, project := c.core.ProjectExists(name)
+
+	if !exists {
+		return fmt.Errorf("project '%s' does not exist", name)
+	}
+
+	c.sendMsg(common.NewRegularMsg("%-20s %-30s\n", "Key", "Value"))
+
+	for _, envVariable := range project.EnvVariables {
+		c.sendMsg(common.NewRegularMsg("%-20s %-30s\n", envVariable.Name, envVariable.Value))
+	}
+
+	return nil
+}
+
+// Handle the env command.
+func (c *CLI) handleEnv() {
+	if len(os.Args) < 3 {
+		c.sendMsg(common.NewRegularMsg("Usage: %s env <add|remove|list> [args...]\n", common.ProgramName))
+		return
+	}
+
+	switch os.Args[2] {
+	case "list", "ls":
+		if len(os.Args) < 4 {
+			c.sendMsg(common.NewRegularMsg("Usage: %s env list|ls <project>\n", common.ProgramName))
+			return
+		}
+
+		err := c.listEnvVariables(os.Args[3])
+
+		if err != nil {
+			c.ErrorPrint("Error listing environment variables:", err)
+		}
+	case "add":
+		if len(os.Args) < 6 {
+			c.sendMsg(common.NewRegularMsg("Usage: %s env add <project> <key> <value>\n", common.ProgramName))
+			return
+		}
+
+		c.sendMsg(c.core.AddEnvVariable(os.Args[3], os.Args[4], os.Args[5]))
+	case "remove", "rm":
+		if len(os.Args) < 5 {
+			c.sendMsg(common.NewRegularMsg("Usage: %s env remove|rm <project> <key>\n", common.ProgramName))
+			return
+		}
+
+		c.sendMsg(c.core.RemoveEnvVariable(os.Args[3], os.Args[4]))
+	default:
+		c.sendMsg(common.NewErrMsg("Unknown subcommand '%s'", os.Args[2]))
+		c.sendMsg(common.NewRegularMsg("Expected 'add', 'remove|rm' or 'list|ls' subcommand\n"))
+	}
+}
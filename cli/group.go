@@ -0,0 +1,72 @@
+package cli
+
+import (
+	"os"
+	"strings"
+
+	"github.com/iskandervdh/spinup/common"
+)
+
+// Print a list of all groups and their projects to the output of the CLI.
+func (c *CLI) listGroups() {
+	groups, err := c.core.GetGroups()
+
+	if err != nil {
+		c.sendMsg(common.NewErrMsg("Error listing groups: %s\n", err))
+		return
+	}
+
+	if len(groups) == 0 {
+		c.sendMsg(common.NewRegularMsg("No groups found\n"))
+		return
+	}
+
+	c.sendMsg(common.NewRegularMsg("%-20s %-30s\n", "Name", "Projects"))
+
+	for _, group := range groups {
+		c.sendMsg(common.NewRegularMsg("%-20s %-30s\n", group.Name, strings.Join(group.Projects, ", ")))
+	}
+}
+
+// Handle the group command.
+func (c *CLI) handleGroup() {
+	if len(os.Args) < 3 {
+		c.sendMsg(common.NewRegularMsg("Usage: %s group|g <add|remove|list|add-project|remove-project> [args...]\n", common.ProgramName))
+		return
+	}
+
+	switch os.Args[2] {
+	case "list", "ls":
+		c.listGroups()
+	case "add":
+		if len(os.Args) < 4 {
+			c.sendMsg(common.NewRegularMsg("Usage: %s group|g add <name> [projects...]\n", common.ProgramName))
+			return
+		}
+
+		c.sendMsg(c.core.AddGroup(os.Args[3], os.Args[4:]))
+	case "remove", "rm":
+		if len(os.Args) < 4 {
+			c.sendMsg(common.NewRegularMsg("Usage: %s group|g remove|rm <name>\n", common.ProgramName))
+			return
+		}
+
+		c.sendMsg(c.core.RemoveGroup(os.Args[3]))
+	case "add-project", "ap":
+		if len(os.Args) < 5 {
+			c.sendMsg(common.NewRegularMsg("Usage: %s group|g add-project|ap <name> <project>\n", common.ProgramName))
+			return
+		}
+
+		c.sendMsg(c.core.AddProjectToGroup(os.Args[3], os.Args[4]))
+	case "remove-project", "rp":
+		if len(os.Args) < 5 {
+			c.sendMsg(common.NewRegularMsg("Usage: %s group|g remove-project|rp <name> <project>\n", common.ProgramName))
+			return
+		}
+
+		c.sendMsg(c.core.RemoveProjectFromGroup(os.Args[3], os.Args[4]))
+	default:
+		c.sendMsg(common.NewRegularMsg("Expected 'add', 'remove', 'list', 'add-project' or 'remove-project'\n"))
+	}
+}
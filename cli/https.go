@@ -0,0 +1,51 @@
+package cli
+
+import (
+	"os"
+
+	"github.com/iskandervdh/spinup/common"
+)
+
+// Handle the https command.
+func (c *CLI) handleHTTPS() {
+	if len(os.Args) < 3 {
+		c.sendMsg(common.NewRegularMsg("Usage: %s https <enable|disable|export-ca> [args...]\n", common.ProgramName))
+		return
+	}
+
+	switch os.Args[2] {
+	case "enable":
+		c.sendMsg(c.core.EnableHTTPS())
+	case "disable":
+		c.sendMsg(c.core.DisableHTTPS())
+	case "export-ca":
+		data, msg := c.core.ExportCA()
+
+		if msg != nil {
+			c.sendMsg(msg)
+			return
+		}
+
+		// Without a file the certificate is written to the output, so it can be piped into other tools
+		if len(os.Args) < 4 || os.Args[3] == "-" {
+			c.out.Write(data)
+			return
+		}
+
+		if _, err := os.Stat(os.Args[3]); err == nil {
+			c.sendMsg(common.NewErrMsg("File '%s' already exists", os.Args[3]))
+			return
+		}
+
+		err := os.WriteFile(os.Args[3], data, 0644)
+
+		if err != nil {
+			c.sendMsg(common.NewErrMsg("Error writing CA certificate: %s", err))
+			return
+		}
+
+		c.sendMsg(common.NewSuccessMsg("Exported CA certificate to %s", os.Args[3]))
+	default:
+		c.sendMsg(common.NewRegularMsg("Expected 'enable', 'disable' or 'export-ca'\n"))
+	}
+}
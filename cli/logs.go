@@ -0,0 +1,152 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/iskandervdh/spinup/common"
+	"github.com/iskandervdh/spinup/core"
+)
+
+// Parse the value of the --since flag, which is either a duration like "10m" or a time.
+func parseSince(value string) (time.Time, error) {
+	if duration, err := time.ParseDuration(value); err == nil {
+		return time.Now().Add(-duration), nil
+	}
+
+	for _, layout := range []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02T15:04:05", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("expected a duration like 10m or a time like 2006-01-02 15:04:05")
+}
+
+// Format of the time at the start of every printed log line.
+const logTimeFormat = "2006-01-02 15:04:05.000"
+
+// Print the given log line with the prefix of its command, colored the same way as when the project is run.
+func (c *CLI) printLogLine(line core.LogLine, prefixes map[string]string) {
+	prefix, ok := prefixes[line.Command]
+
+	if !ok {
+		prefix = common.PrefixText(fmt.Sprintf("[%s]", line.Command), len(prefixes))
+		prefixes[line.Command] = prefix
+	}
+
+	fmt.Fprintf(c.out, "%s %s %s\n", line.Time.Local().Format(logTimeFormat), prefix, line.Text)
+}
+
+func (c *CLI) sendLogsUsageMsg() {
+	c.sendMsg(common.NewRegularMsg("Usage: %s logs <project> [command] [--follow|-f] [--since <duration|time>] [--tail|-n <lines>]\n", common.ProgramName))
+}
+
+// Handle the logs subcommand, printing the logs of a project or one of its commands.
+func (c *CLI) handleLogs() {
+	args := []string{}
+	options := core.LogOptions{}
+	follow := false
+
+	for i := 2; i < len(os.Args); i++ {
+		arg := os.Args[i]
+
+		switch arg {
+		case "--follow", "-f":
+			follow = true
+		case "--since", "--tail", "-n":
+			if i+1 >= len(os.Args) {
+				c.sendLogsUsageMsg()
+				return
+			}
+
+			i++
+
+			if arg == "--since" {
+				since, err := parseSince(os.Args[i])
+
+				if err != nil {
+					c.sendMsg(common.NewErrMsg("Invalid value for --since: %s\n", err))
+					return
+				}
+
+				options.Since = since
+				continue
+			}
+
+			tail, err := strconv.Atoi(os.Args[i])
+
+			if err != nil || tail < 0 {
+				c.sendMsg(common.NewErrMsg("Invalid number of lines for --tail: %s\n", os.Args[i]))
+				return
+			}
+
+			options.Tail = tail
+		default:
+			args = append(args, arg)
+		}
+	}
+
+	if len(args) < 1 || len(args) > 2 {
+		c.sendLogsUsageMsg()
+		return
+	}
+
+	projectName := args[0]
+	commandName := ""
+
+	if len(args) == 2 {
+		commandName = args[1]
+	}
+
+	exists, project := c.core.ProjectExists(projectName)
+
+	if !exists {
+		c.sendMsg(common.NewErrMsg("Unknown project '%s'\n", projectName))
+		return
+	}
+
+	prefixes := map[string]string{}
+
+	for i, command := range project.Commands {
+		prefixes[command.Name] = common.PrefixText(fmt.Sprintf("[%s]", command.Name), i)
+	}
+
+	lines, offset, err := c.core.ReadLogs(projectName, commandName, options)
+
+	if err != nil {
+		c.sendMsg(common.NewErrMsg("Could not read logs: %s\n", err))
+		return
+	}
+
+	for _, line := range lines {
+		c.printLogLine(line, prefixes)
+	}
+
+	if !follow {
+		return
+	}
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sigChan)
+
+	stop := make(chan struct{})
+
+	go func() {
+		<-sigChan
+		close(stop)
+	}()
+
+	err = c.core.FollowLogs(projectName, commandName, offset, stop, func(line core.LogLine) {
+		c.printLogLine(line, prefixes)
+	})
+
+	if err != nil {
+		c.sendMsg(common.NewErrMsg("Could not follow logs: %s\n", err))
+	}
+}
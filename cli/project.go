@@ -37,7 +37,7 @@ func (c *CLI) listProjects() {
 		c.sendMsg(
 			common.NewRegularMsg("%-10s %-30s %-10d %-20s\n",
 				project.Name,
-				fmt.Sprintf("%s.%s", project.Name, common.TLD),
+				project.Domain,
 				project.Port,
 				commands,
 			),
@@ -181,7 +181,7 @@ func (c *CLI) editProjectInteractive() {
 // Handle the project subcommand.
 func (c *CLI) handleProject() {
 	if len(os.Args) < 3 {
-		c.sendMsg(common.NewRegularMsg("Usage: %s project <add|remove|edit|rename|add-command|remove-command|set-dir|get-dir|list> [args...]\n", common.ProgramName))
+		c.sendMsg(common.NewRegularMsg("Usage: %s project <add|remove|edit|rename|add-command|remove-command|set-dir|get-dir|env-files|list> [args...]\n", common.ProgramName))
 		return
 	}
 
@@ -278,8 +278,63 @@ func (c *CLI) handleProject() {
 		}
 
 		c.sendMsg(c.core.GetProjectDir(os.Args[3]))
+	case "env-files", "ef":
+		c.handleProjectEnvFiles()
 	default:
 		c.sendMsg(common.NewErrMsg("Unknown subcommand '%s'", os.Args[2]))
-		c.sendMsg(common.NewRegularMsg("Expected 'add', 'remove|rm', 'edit|e', 'rename|mv', 'add-command|ac', 'remove-command|rc', 'set-dir|sd', 'get-dir|gd' subcommand\n"))
+		c.sendMsg(common.NewRegularMsg("Expected 'add', 'remove|rm', 'edit|e', 'rename|mv', 'add-command|ac', 'remove-command|rc', 'set-dir|sd', 'get-dir|gd', 'env-files|ef' subcommand\n"))
+	}
+}
+
+// Print a list of all env files for a project to the output of the CLI.
+func (c *CLI) listEnvFiles(name string) error {
+	exists, project := c.core.ProjectExists(name)
+
+	if !exists {
+		return fmt.Errorf("project '%s' does not exist", name)
+	}
+
+	c.sendMsg(common.NewRegularMsg("%-40s\n", "Path"))
+
+	for _, envFile := range project.EnvFiles {
+		c.sendMsg(common.NewRegularMsg("%-40s\n", envFile.Path))
+	}
+
+	return nil
+}
+
+// Handle the env-files subcommand of the project subcommand.
+func (c *CLI) handleProjectEnvFiles() {
+	if len(os.Args) < 5 {
+		c.sendMsg(common.NewRegularMsg("Usage: %s project env-files|ef <project> <add|remove|list> [path]\n", common.ProgramName))
+		return
+	}
+
+	projectName := os.Args[3]
+
+	switch os.Args[4] {
+	case "list", "ls":
+		err := c.listEnvFiles(projectName)
+
+		if err != nil {
+			c.ErrorPrint("Error listing env files:", err)
+		}
+	case "add":
+		if len(os.Args) < 6 {
+			c.sendMsg(common.NewRegularMsg("Usage: %s project env-files|ef <project> add <path>\n", common.ProgramName))
+			return
+		}
+
+		c.sendMsg(c.core.AddEnvFile(projectName, os.Args[5]))
+	case "remove", "rm":
+		if len(os.Args) < 6 {
+			c.sendMsg(common.NewRegularMsg("Usage: %s project env-files|ef <project> remove|rm <path>\n", common.ProgramName))
+			return
+		}
+
+		c.sendMsg(c.core.RemoveEnvFile(projectName, os.Args[5]))
+	default:
+		c.sendMsg(common.NewErrMsg("Unknown subcommand '%s'", os.Args[4]))
+		c.sendMsg(common.NewRegularMsg("Expected 'add', 'remove|rm' or 'list|ls' subcommand\n"))
 	}
 }
@@ -0,0 +1,130 @@
+package cli
+
+import (
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/iskandervdh/spinup/common"
+	"github.com/iskandervdh/spinup/config"
+	"github.com/iskandervdh/spinup/core"
+	"github.com/iskandervdh/spinup/proxy"
+)
+
+// Handle the proxy command, which is also available as nginx.
+func (c *CLI) handleProxy() {
+	if len(os.Args) < 3 {
+		c.sendMsg(common.NewRegularMsg("Usage: %s proxy <sync|check|validate|use|serve> [args...]\n", common.ProgramName))
+		return
+	}
+
+	switch os.Args[2] {
+	case "sync":
+		removeOrphans := false
+
+		for _, arg := range os.Args[3:] {
+			switch arg {
+			case "--remove-orphans":
+				removeOrphans = true
+			default:
+				c.sendMsg(common.NewRegularMsg("Usage: %s proxy sync [--remove-orphans]\n", common.ProgramName))
+				return
+			}
+		}
+
+		c.sendMsg(c.core.SyncProxyConfigs(removeOrphans))
+	case "check":
+		c.proxyCheck()
+	case "validate":
+		c.sendMsg(c.core.ValidateProxyConfigs())
+	case "use":
+		if len(os.Args) < 4 {
+			c.sendMsg(common.NewRegularMsg("Usage: %s proxy use <%s>\n", common.ProgramName, strings.Join(config.ReverseProxyNames, "|")))
+			return
+		}
+
+		c.sendMsg(c.core.UseReverseProxy(os.Args[3]))
+	case "serve":
+		c.proxyServe()
+	default:
+		c.sendMsg(common.NewRegularMsg("Expected 'sync', 'check', 'validate', 'use' or 'serve'\n"))
+	}
+}
+
+// Show the differences between the reverse proxy configuration files on disk and the ones generated from the database.
+func (c *CLI) proxyCheck() {
+	checks, err := c.core.CheckProxyConfigs()
+
+	if err != nil {
+		c.sendMsg(common.NewErrMsg("Error checking reverse proxy configs: %s", err))
+		return
+	}
+
+	drift := 0
+
+	for _, check := range checks {
+		if check.State == core.ProxyConfigOK {
+			continue
+		}
+
+		drift++
+
+		c.sendMsg(common.NewRegularMsg("%s: %s\n", check.Name, check.State))
+
+		for _, line := range strings.Split(strings.TrimSuffix(check.Diff, "\n"), "\n") {
+			c.sendMsg(common.NewRegularMsg("  %s\n", line))
+		}
+	}
+
+	if drift > 0 {
+		c.sendMsg(common.NewErrMsg("Found %d reverse proxy configs that differ from the database, run '%s proxy sync' to update them", drift, common.ProgramName))
+		return
+	}
+
+	c.sendMsg(common.NewSuccessMsg("All reverse proxy configs are up to date"))
+}
+
+// Run the built-in reverse proxy in the foreground until SIGINT or SIGTERM is received.
+func (c *CLI) proxyServe() {
+	cfg := c.core.GetConfig()
+
+	if reverseProxy, err := cfg.GetReverseProxy(); err == nil && reverseProxy.Name() != config.ReverseProxyBuiltin {
+		c.sendMsg(common.NewWarnMsg("The selected reverse proxy is %s, run '%s proxy use %s' to select the built-in one", reverseProxy.Name(), common.ProgramName, config.ReverseProxyBuiltin))
+	}
+
+	port, err := cfg.GetProxyPort()
+
+	if err != nil {
+		c.sendMsg(common.NewErrMsg("%s", err))
+		return
+	}
+
+	server := proxy.NewServer(port, c.core.GetProxyRoutes)
+
+	err = server.Listen()
+
+	if err != nil {
+		c.sendMsg(common.NewErrMsg("Error starting reverse proxy: %s", err))
+		return
+	}
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+
+	go func() {
+		<-sigChan
+
+		server.Close()
+	}()
+
+	c.sendMsg(common.NewInfoMsg("Reverse proxy listening on %s", server.Addr()))
+
+	err = server.Serve(func(err error) {
+		c.sendMsg(common.NewErrMsg("Error reloading routes: %s", err))
+	})
+
+	if err != nil {
+		c.sendMsg(common.NewErrMsg("Error serving reverse proxy: %s", err))
+	}
+}
@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/iskandervdh/spinup/common"
+)
+
+// Print a list of all routes of a project to the output of the CLI, including the route of / to the port of
+// the project if the project does not have a route for / itself.
+func (c *CLI) listRoutes(name string) error {
+	exists, project := c.core.ProjectExists(name)
+
+	if !exists {
+		return fmt.Errorf("project '%s' does not exist", name)
+	}
+
+	c.sendMsg(common.NewRegularMsg("%-30s %-8s %s\n", "Path", "Port", "Strip prefix"))
+
+	hasRootRoute := false
+
+	for _, route := range project.Routes {
+		hasRootRoute = hasRootRoute || route.Path == "/"
+	}
+
+	if !hasRootRoute {
+		c.sendMsg(common.NewRegularMsg("%-30s %-8d %t\n", "/", project.Port, false))
+	}
+
+	for _, route := range project.Routes {
+		c.sendMsg(common.NewRegularMsg("%-30s %-8d %t\n", route.Path, route.Port, route.StripPrefix))
+	}
+
+	return nil
+}
+
+// Handle the route command.
+func (c *CLI) handleRoute() {
+	if len(os.Args) < 3 {
+		c.sendMsg(common.NewRegularMsg("Usage: %s route <add|remove|list> [args...]\n", common.ProgramName))
+		return
+	}
+
+	switch os.Args[2] {
+	case "list", "ls":
+		if len(os.Args) < 4 {
+			c.sendMsg(common.NewRegularMsg("Usage: %s route list|ls <project>\n", common.ProgramName))
+			return
+		}
+
+		err := c.listRoutes(os.Args[3])
+
+		if err != nil {
+			c.ErrorPrint("Error listing routes:", err)
+		}
+	case "add":
+		if len(os.Args) < 6 || len(os.Args) > 7 || (len(os.Args) == 7 && os.Args[6] != "--strip-prefix") {
+			c.sendMsg(common.NewRegularMsg("Usage: %s route add <project> <path> <port> [--strip-prefix]\n", common.ProgramName))
+			return
+		}
+
+		port, err := strconv.ParseInt(os.Args[5], 10, 64)
+
+		if err != nil {
+			c.ErrorPrint("Port must be an integer")
+			return
+		}
+
+		c.sendMsg(c.core.AddRoute(os.Args[3], os.Args[4], port, len(os.Args) == 7))
+	case "remove", "rm":
+		if len(os.Args) < 5 {
+			c.sendMsg(common.NewRegularMsg("Usage: %s route remove|rm <project> <path>\n", common.ProgramName))
+			return
+		}
+
+		c.sendMsg(c.core.RemoveRoute(os.Args[3], os.Args[4]))
+	default:
+		c.sendMsg(common.NewRegularMsg("Expected 'add', 'remove' or 'list'\n"))
+	}
+}
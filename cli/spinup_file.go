@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/iskandervdh/spinup/common"
+	"github.com/iskandervdh/spinup/core"
+)
+
+// Handle the export subcommand, writing the given projects (or all of them) as a spinup file to the output of the CLI.
+func (c *CLI) handleExport() {
+	cwd, err := os.Getwd()
+
+	if err != nil {
+		c.sendMsg(common.NewErrMsg("Error getting current working directory: %s\n", err))
+		return
+	}
+
+	data, msg := c.core.ExportProjects(os.Args[2:], cwd)
+
+	if msg != nil {
+		c.sendMsg(msg)
+		return
+	}
+
+	c.out.Write(data)
+}
+
+// Handle the import subcommand, importing the projects and commands of a spinup file or stdin when the file is "-".
+func (c *CLI) handleImport() {
+	path := ""
+	dryRun := false
+	onConflict := core.ImportConflictAbort
+
+	for _, arg := range os.Args[2:] {
+		switch arg {
+		case "--dry-run", "-n":
+			dryRun = true
+		case "--overwrite":
+			onConflict = core.ImportConflictOverwrite
+		case "--skip-existing":
+			onConflict = core.ImportConflictSkip
+		default:
+			path = arg
+		}
+	}
+
+	if path == "" {
+		c.sendMsg(common.NewRegularMsg("Usage: %s import <file|-> [--dry-run|-n] [--overwrite|--skip-existing]\n", common.ProgramName))
+		return
+	}
+
+	var data []byte
+	var err error
+
+	baseDir := filepath.Dir(path)
+
+	if path == "-" {
+		data, err = io.ReadAll(c.in)
+		baseDir = "."
+	} else {
+		data, err = os.ReadFile(path)
+	}
+
+	if err != nil {
+		c.sendMsg(common.NewErrMsg("Error reading spinup file: %s\n", err))
+		return
+	}
+
+	baseDir, err = filepath.Abs(baseDir)
+
+	if err != nil {
+		c.sendMsg(common.NewErrMsg("Error getting directory of spinup file: %s\n", err))
+		return
+	}
+
+	c.sendMsg(c.core.ImportProjects(data, baseDir, onConflict, dryRun))
+}
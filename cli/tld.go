@@ -0,0 +1,22 @@
+package cli
+
+import (
+	"os"
+
+	"github.com/iskandervdh/spinup/common"
+)
+
+// Handle the tld command, showing the TLD of the domains of projects or changing it.
+func (c *CLI) handleTLD() {
+	if len(os.Args) < 3 {
+		c.sendMsg(common.NewRegularMsg(".%s\n", c.core.GetTLD()))
+		return
+	}
+
+	if len(os.Args) > 3 {
+		c.sendMsg(common.NewRegularMsg("Usage: %s tld [tld]\n", common.ProgramName))
+		return
+	}
+
+	c.sendMsg(c.core.SetTLD(os.Args[2]))
+}
@@ -6,7 +6,8 @@ import (
 
 const ProgramName = "spinup"
 
-const TLD = "test"
+// Name of the background daemon that runs projects, the binary runs as the daemon when invoked with this name.
+const DaemonName = "spinupd"
 
 //go:embed .version
 var Version string
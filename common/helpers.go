@@ -11,7 +11,3 @@ func IsWindows() bool {
 func IsMacOS() bool {
 	return runtime.GOOS == "darwin"
 }
-
-func GetDomain(projectName string) string {
-	return projectName + "." + TLD
-}
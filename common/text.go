@@ -41,3 +41,13 @@ func ErrorText(text string) string {
 			Render("Error: " + text),
 	)
 }
+
+// Colors of the prefixes of the output of commands, so the output of every command can be told apart.
+var prefixColors = []string{"#5DADE2", "#F4D03F", "#58D68D", "#AF7AC5", "#EB984E", "#48C9B0", "#F1948A", "#A9CCE3"}
+
+// Add a lipgloss style to the given prefix of the output of a command, with a distinct color for every index.
+func PrefixText(text string, index int) string {
+	return lipgloss.NewStyle().
+		Foreground(lipgloss.Color(prefixColors[index%len(prefixColors)])).
+		Render(text)
+}
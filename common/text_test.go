@@ -36,3 +36,11 @@ func TestErrorText(t *testing.T) {
 		t.Errorf("Expected 'Error text', got '%s'", text)
 	}
 }
+
+func TestPrefixText(t *testing.T) {
+	text := PrefixText("[frontend]", 10)
+
+	if !strings.Contains(text, "[frontend]") {
+		t.Errorf("Expected '[frontend]', got '%s'", text)
+	}
+}
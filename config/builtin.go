@@ -0,0 +1,116 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/iskandervdh/spinup/common"
+)
+
+// Key of the setting that contains the port the built-in reverse proxy listens on.
+const ProxyPortSettingKey = "proxyPort"
+
+// Port the built-in reverse proxy listens on by default, which does not need root privileges.
+const DefaultProxyPort = 8480
+
+// Get the port the built-in reverse proxy listens on.
+func (c *Config) GetProxyPort() (int64, error) {
+	settings, err := c.GetSettings()
+
+	if err != nil {
+		return 0, err
+	}
+
+	value, exists := settings[ProxyPortSettingKey]
+
+	if !exists {
+		return DefaultProxyPort, nil
+	}
+
+	// Numbers in the JSON settings file are decoded as float64
+	port, ok := value.(float64)
+
+	if !ok || port != float64(int64(port)) || port < 1 || port > 65535 {
+		return 0, fmt.Errorf("setting %s should be a port number, got %v", ProxyPortSettingKey, value)
+	}
+
+	return int64(port), nil
+}
+
+// builtinProxy is the reverse proxy backend for the reverse proxy that is built into spinup.
+//
+// It routes requests using the projects in the database, so there are no configuration files to change.
+type builtinProxy struct {
+	config *Config
+}
+
+func (p *builtinProxy) Name() string {
+	return ReverseProxyBuiltin
+}
+
+func (p *builtinProxy) Init() error {
+	port, err := p.config.GetProxyPort()
+
+	if err != nil {
+		return err
+	}
+
+	if !p.config.IsTesting() {
+		fmt.Printf(
+			"\n!!! The built-in reverse proxy listens on port %d while %s is running or while running `%s proxy serve`\n",
+			port, common.DaemonName, common.ProgramName,
+		)
+	}
+
+	return nil
+}
+
+func (p *builtinProxy) AddSite(name string, port int64, domainAliases []string) error {
+	return nil
+}
+
+func (p *builtinProxy) RemoveSite(name string) error {
+	return nil
+}
+
+func (p *builtinProxy) RenameSite(oldName string, newName string) error {
+	return nil
+}
+
+func (p *builtinProxy) AddDomainAlias(name string, domainAlias string) error {
+	return nil
+}
+
+func (p *builtinProxy) RemoveDomainAlias(name string, domainAlias string) error {
+	return nil
+}
+
+// The built-in reverse proxy reloads the projects from the database by itself.
+func (p *builtinProxy) Reload() error {
+	return nil
+}
+
+func (p *builtinProxy) Validate() error {
+	_, err := p.config.GetProxyPort()
+
+	return err
+}
+
+func (p *builtinProxy) GetSitePath(name string) string {
+	return ""
+}
+
+func (p *builtinProxy) RenderSite(name string, port int64, domainAliases []string, routes []Route) string {
+	return ""
+}
+
+func (p *builtinProxy) WriteSite(name string, port int64, domainAliases []string, routes []Route) error {
+	return nil
+}
+
+func (p *builtinProxy) ReadSites() (map[string]string, error) {
+	return map[string]string{}, nil
+}
+
+func (p *builtinProxy) IsGeneratedSite(name string, content string) bool {
+	return false
+}
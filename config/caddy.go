@@ -0,0 +1,128 @@
+package config
+
+import (
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Regexes to match the site address, the upstream and the routes of a site in a Caddyfile snippet.
+var (
+	caddySiteAddressRegex  = regexp.MustCompile(`(?m)^([^\s#{][^{]*)\{\s*$`)
+	caddyReverseProxyRegex = regexp.MustCompile(`(?m)^\treverse_proxy\s+127\.0\.0\.1:(\d+)`)
+	caddyRouteRegex        = regexp.MustCompile(`(?m)^\thandle (/\S*)/\* \{\n(\t\turi strip_prefix \S+\n)?\t\treverse_proxy 127\.0\.0\.1:(\d+)\n\t\}`)
+)
+
+// Create the reverse proxy backend that writes a Caddyfile snippet for every project,
+// which are imported in the Caddyfile.
+func newCaddyProxy(c *Config) ReverseProxy {
+	return &fileProxy{
+		config:    c,
+		name:      ReverseProxyCaddy,
+		extension: ".caddy",
+		render:    renderCaddySite,
+		parse:     parseCaddySite,
+		reload: func() error {
+			return exec.Command("sudo", "systemctl", "reload", "caddy").Run()
+		},
+		check: func(path string, _ string) error {
+			output, err := exec.Command("caddy", "validate", "--adapter", "caddyfile", "--config", path).CombinedOutput()
+
+			if err != nil {
+				return fmt.Errorf("%s", strings.TrimSpace(string(output)))
+			}
+
+			return nil
+		},
+		instructions: func(dir string) string {
+			return fmt.Sprintf("\n!!! Please add the following import directive to your Caddyfile:\n\nimport %s/*.caddy\n", dir)
+		},
+	}
+}
+
+// Render the Caddyfile snippet of a site. Without a certificate the server names are served over plain HTTP,
+// so Caddy does not try to get certificates for them. With a certificate Caddy redirects HTTP requests to HTTPS.
+//
+// Every route gets a handle block, which Caddy sorts by the length of their paths and of which only one is used.
+// Requests to the path prefix without a trailing slash are redirected, like Nginx does.
+func renderCaddySite(_ string, port int64, serverNames []string, routes []Route, tls *siteTLS) string {
+	scheme := "http://"
+	directives := ""
+
+	if tls != nil {
+		scheme = "https://"
+		directives = fmt.Sprintf("\ttls %s %s\n", tls.certFile, tls.keyFile)
+	}
+
+	addresses := make([]string, len(serverNames))
+
+	for i, serverName := range serverNames {
+		addresses[i] = scheme + serverName
+	}
+
+	rootPort, prefixRoutes := splitRoutes(port, routes)
+	handles := ""
+
+	for _, route := range prefixRoutes {
+		stripPrefix := ""
+
+		if route.StripPrefix {
+			stripPrefix = fmt.Sprintf("\t\turi strip_prefix %s\n", route.Path)
+		}
+
+		handles += fmt.Sprintf(`
+	redir %[1]s %[1]s/ permanent
+	handle %[1]s/* {
+%[2]s		reverse_proxy 127.0.0.1:%[3]d
+	}
+`, route.Path, stripPrefix, route.Port)
+	}
+
+	return generatedHeader("#") + fmt.Sprintf(`%s {
+%s	reverse_proxy 127.0.0.1:%d
+%s}
+`, strings.Join(addresses, ", "), directives, rootPort, handles)
+}
+
+// Parse the server names, port and routes of a Caddyfile snippet of a site.
+func parseCaddySite(content string) (site, error) {
+	addressMatch := caddySiteAddressRegex.FindStringSubmatch(content)
+
+	if addressMatch == nil {
+		return site{}, fmt.Errorf("site address not found in config file")
+	}
+
+	portMatch := caddyReverseProxyRegex.FindStringSubmatch(content)
+
+	if portMatch == nil {
+		return site{}, fmt.Errorf("reverse_proxy not found in config file")
+	}
+
+	port, err := strconv.ParseInt(portMatch[1], 10, 64)
+
+	if err != nil {
+		return site{}, err
+	}
+
+	serverNames := []string{}
+
+	for _, address := range strings.FieldsFunc(addressMatch[1], func(r rune) bool { return r == ',' || r == ' ' || r == '\t' }) {
+		serverNames = append(serverNames, strings.TrimPrefix(strings.TrimPrefix(address, "http://"), "https://"))
+	}
+
+	routes := []Route{}
+
+	for _, match := range caddyRouteRegex.FindAllStringSubmatch(content, -1) {
+		routePort, err := strconv.ParseInt(match[3], 10, 64)
+
+		if err != nil {
+			return site{}, err
+		}
+
+		routes = append(routes, Route{Path: match[1], Port: routePort, StripPrefix: match[2] != ""})
+	}
+
+	return site{port, serverNames, routes}, nil
+}
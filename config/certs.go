@@ -0,0 +1,339 @@
+package config
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+	"path"
+	"slices"
+	"time"
+
+	"github.com/iskandervdh/spinup/common"
+)
+
+// Key of the setting that enables serving projects over HTTPS.
+const HTTPSSettingKey = "https"
+
+// How long the development CA is valid.
+const caValidity = 10 * 365 * 24 * time.Hour
+
+// How long the certificate of a project is valid, the maximum that browsers accept.
+const certificateValidity = 825 * 24 * time.Hour
+
+// Certificates that expire within this time are issued again.
+const certificateRenewBefore = 30 * 24 * time.Hour
+
+// Certificate and key files of a site that is served over HTTPS.
+type siteTLS struct {
+	certFile string
+	keyFile  string
+}
+
+// Returns whether projects are served over HTTPS.
+func (c *Config) IsHTTPSEnabled() bool {
+	value, err := c.GetSetting(HTTPSSettingKey)
+
+	if err != nil {
+		return false
+	}
+
+	enabled, ok := value.(bool)
+
+	return ok && enabled
+}
+
+// Get the certificate and key files of the site with the given name, or nil if HTTPS is disabled.
+func (c *Config) siteTLS(name string) *siteTLS {
+	if !c.IsHTTPSEnabled() {
+		return nil
+	}
+
+	certFile, keyFile := c.GetCertificatePaths(name)
+
+	return &siteTLS{certFile, keyFile}
+}
+
+// Returns the path to the directory containing the development CA and the certificates of the projects.
+func (c *Config) GetCertsDir() string {
+	return path.Join(c.configDir, "certs")
+}
+
+// Returns the paths of the certificate and key of the development CA.
+func (c *Config) GetCAPaths() (string, string) {
+	return path.Join(c.GetCertsDir(), "ca.pem"), path.Join(c.GetCertsDir(), "ca-key.pem")
+}
+
+// Returns the paths of the certificate and key of the project with the given name.
+//
+// Every project has its own directory, so the files of a project can never be the files of the CA
+// or of another project, whatever their names are.
+func (c *Config) GetCertificatePaths(name string) (string, string) {
+	projectCertsDir := path.Join(c.GetCertsDir(), "projects", name)
+
+	return path.Join(projectCertsDir, "cert.pem"), path.Join(projectCertsDir, "key.pem")
+}
+
+// Write the given PEM block to the file at the given path.
+func writePEM(filePath string, blockType string, bytes []byte, perm os.FileMode) error {
+	return os.WriteFile(filePath, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: bytes}), perm)
+}
+
+// Read the first PEM block of the given type from the file at the given path.
+func readPEM(filePath string, blockType string) ([]byte, error) {
+	data, err := os.ReadFile(filePath)
+
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(data)
+
+	if block == nil || block.Type != blockType {
+		return nil, fmt.Errorf("no %s found in %s", blockType, filePath)
+	}
+
+	return block.Bytes, nil
+}
+
+// Get a random serial number for a new certificate.
+func newSerialNumber() (*big.Int, error) {
+	return rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+}
+
+// Generate the development CA if it does not exist yet.
+//
+// The key of the CA never leaves the config directory, only its certificate has to be trusted.
+func (c *Config) EnsureCA() error {
+	caCertPath, caKeyPath := c.GetCAPaths()
+
+	if _, err := os.Stat(caCertPath); err == nil {
+		return nil
+	}
+
+	err := os.MkdirAll(c.GetCertsDir(), 0755)
+
+	if err != nil {
+		return err
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	if err != nil {
+		return err
+	}
+
+	serialNumber, err := newSerialNumber()
+
+	if err != nil {
+		return err
+	}
+
+	hostname, _ := os.Hostname()
+
+	template := &x509.Certificate{
+		SerialNumber: serialNumber,
+		Subject: pkix.Name{
+			Organization: []string{common.ProgramName},
+			CommonName:   fmt.Sprintf("%s development CA %s", common.ProgramName, hostname),
+		},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(caValidity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		MaxPathLenZero:        true,
+	}
+
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+
+	if err != nil {
+		return err
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+
+	if err != nil {
+		return err
+	}
+
+	err = writePEM(caKeyPath, "EC PRIVATE KEY", keyDER, 0600)
+
+	if err != nil {
+		return err
+	}
+
+	return writePEM(caCertPath, "CERTIFICATE", certDER, 0644)
+}
+
+// Read the certificate and key of the development CA.
+func (c *Config) readCA() (*x509.Certificate, *ecdsa.PrivateKey, error) {
+	caCertPath, caKeyPath := c.GetCAPaths()
+
+	certDER, err := readPEM(caCertPath, "CERTIFICATE")
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cert, err := x509.ParseCertificate(certDER)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	keyDER, err := readPEM(caKeyPath, "EC PRIVATE KEY")
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	key, err := x509.ParseECPrivateKey(keyDER)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return cert, key, nil
+}
+
+// Get the certificate of the development CA in PEM format, generating the CA if it does not exist yet.
+func (c *Config) ExportCA() ([]byte, error) {
+	err := c.EnsureCA()
+
+	if err != nil {
+		return nil, err
+	}
+
+	caCertPath, _ := c.GetCAPaths()
+
+	return os.ReadFile(caCertPath)
+}
+
+// Check if the certificate of the project with the given name is signed by the CA, covers exactly
+// the given server names and does not expire soon.
+func (c *Config) certificateCovers(name string, ca *x509.Certificate, serverNames []string) bool {
+	certPath, keyPath := c.GetCertificatePaths(name)
+
+	if _, err := os.Stat(keyPath); err != nil {
+		return false
+	}
+
+	certDER, err := readPEM(certPath, "CERTIFICATE")
+
+	if err != nil {
+		return false
+	}
+
+	cert, err := x509.ParseCertificate(certDER)
+
+	if err != nil || cert.IsCA || cert.CheckSignatureFrom(ca) != nil || time.Now().Add(certificateRenewBefore).After(cert.NotAfter) {
+		return false
+	}
+
+	dnsNames := slices.Sorted(slices.Values(cert.DNSNames))
+	expected := slices.Compact(slices.Sorted(slices.Values(serverNames)))
+
+	return slices.Equal(dnsNames, expected)
+}
+
+// Issue the certificate of the project with the given name for the given server names, unless
+// the existing certificate already covers them. The CA is generated if it does not exist yet.
+//
+// Returns whether a new certificate was issued, in which case the reverse proxy has to be reloaded.
+func (c *Config) EnsureCertificate(name string, serverNames []string) (bool, error) {
+	err := c.EnsureCA()
+
+	if err != nil {
+		return false, fmt.Errorf("error generating CA: %s", err)
+	}
+
+	ca, caKey, err := c.readCA()
+
+	if err != nil {
+		return false, fmt.Errorf("error reading CA: %s", err)
+	}
+
+	if c.certificateCovers(name, ca, serverNames) {
+		return false, nil
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	if err != nil {
+		return false, err
+	}
+
+	serialNumber, err := newSerialNumber()
+
+	if err != nil {
+		return false, err
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: serialNumber,
+		Subject: pkix.Name{
+			Organization: []string{common.ProgramName},
+			CommonName:   serverNames[0],
+		},
+		DNSNames:    slices.Compact(slices.Sorted(slices.Values(serverNames))),
+		NotBefore:   time.Now().Add(-time.Hour),
+		NotAfter:    time.Now().Add(certificateValidity),
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+
+	certDER, err := x509.CreateCertificate(rand.Reader, template, ca, &key.PublicKey, caKey)
+
+	if err != nil {
+		return false, err
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+
+	if err != nil {
+		return false, err
+	}
+
+	certPath, keyPath := c.GetCertificatePaths(name)
+
+	err = os.MkdirAll(path.Dir(certPath), 0755)
+
+	if err != nil {
+		return false, err
+	}
+
+	// The reverse proxy can run as another user, so it has to be able to read the key.
+	// The certificate is only trusted for the local domains of the project.
+	err = writePEM(keyPath, "EC PRIVATE KEY", keyDER, 0644)
+
+	if err != nil {
+		return false, err
+	}
+
+	err = writePEM(certPath, "CERTIFICATE", certDER, 0644)
+
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// Remove the certificate and key of the project with the given name, if they exist.
+func (c *Config) RemoveCertificate(name string) error {
+	certPath, keyPath := c.GetCertificatePaths(name)
+
+	for _, filePath := range []string{certPath, keyPath, path.Dir(certPath)} {
+		if err := os.Remove(filePath); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,103 @@
+package config
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"slices"
+	"testing"
+)
+
+func readTestingCertificate(t *testing.T, path string) *x509.Certificate {
+	data, err := os.ReadFile(path)
+
+	if err != nil {
+		t.Fatalf("Expected certificate to exist, got %s", err)
+	}
+
+	block, _ := pem.Decode(data)
+	cert, err := x509.ParseCertificate(block.Bytes)
+
+	if err != nil {
+		t.Fatalf("Expected valid certificate, got %s", err)
+	}
+
+	return cert
+}
+
+func TestEnsureCA(t *testing.T) {
+	c := TestingConfig("ensure_ca")
+
+	err := c.EnsureCA()
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+
+	caCertPath, caKeyPath := c.GetCAPaths()
+	ca := readTestingCertificate(t, caCertPath)
+
+	if !ca.IsCA {
+		t.Errorf("Expected CA certificate")
+	}
+
+	if info, err := os.Stat(caKeyPath); err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("Expected CA key to only be readable by the user, got %v %s", info, err)
+	}
+
+	c.EnsureCA()
+
+	if exported, _ := c.ExportCA(); !slices.Equal(readTestingCertificate(t, caCertPath).Raw, ca.Raw) || len(exported) == 0 {
+		t.Errorf("Expected existing CA to be kept and exported")
+	}
+}
+
+func TestEnsureCertificate(t *testing.T) {
+	c := TestingConfig("ensure_certificate")
+
+	issued, err := c.EnsureCertificate("test", []string{"test.test", "api.local"})
+
+	if err != nil || !issued {
+		t.Fatalf("Expected certificate to be issued, got %t %s", issued, err)
+	}
+
+	caCertPath, _ := c.GetCAPaths()
+	certPath, _ := c.GetCertificatePaths("test")
+
+	roots := x509.NewCertPool()
+	roots.AddCert(readTestingCertificate(t, caCertPath))
+
+	for _, dnsName := range []string{"test.test", "api.local"} {
+		_, err := readTestingCertificate(t, certPath).Verify(x509.VerifyOptions{DNSName: dnsName, Roots: roots})
+
+		if err != nil {
+			t.Errorf("Expected certificate to be valid for %s, got %s", dnsName, err)
+		}
+	}
+
+	if issued, _ := c.EnsureCertificate("test", []string{"api.local", "test.test"}); issued {
+		t.Errorf("Expected certificate not to be issued again for the same server names")
+	}
+
+	if issued, _ := c.EnsureCertificate("test", []string{"test.test"}); !issued {
+		t.Errorf("Expected certificate to be issued again when a server name is removed")
+	}
+
+	if slices.Contains(readTestingCertificate(t, certPath).DNSNames, "api.local") {
+		t.Errorf("Expected removed server name not to be in the certificate")
+	}
+
+	err = c.RemoveCertificate("test")
+
+	if err != nil {
+		t.Errorf("Expected no error, got %s", err)
+	}
+
+	if _, err := os.Stat(certPath); !os.IsNotExist(err) {
+		t.Errorf("Expected certificate to be removed")
+	}
+
+	if err := c.RemoveCertificate("test"); err != nil {
+		t.Errorf("Expected no error when removing a certificate that does not exist, got %s", err)
+	}
+}
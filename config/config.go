@@ -67,6 +67,36 @@ func (c *Config) GetDatabasePath() string {
 	return path.Join(c.configDir, common.ProgramName+".sqlite3")
 }
 
+// Returns the path to the directory containing the backups of the database.
+func (c *Config) GetBackupsDir() string {
+	return path.Join(c.configDir, "backups")
+}
+
+// Returns the path of the Unix socket the daemon listens on.
+func (c *Config) GetDaemonSocketPath() string {
+	return path.Join(c.configDir, common.DaemonName+".sock")
+}
+
+// Returns the path to the directory containing the logs of running projects.
+func (c *Config) GetLogsDir() string {
+	return path.Join(c.configDir, "logs")
+}
+
+// Returns the path to the directory containing the logs of the project with the given name.
+func (c *Config) GetProjectLogsDir(projectName string) string {
+	return path.Join(c.GetLogsDir(), projectName)
+}
+
+// Returns the path of the log file of the given command of a project,
+// or the path of the combined log of all commands if no command is given.
+func (c *Config) GetLogFilePath(projectName string, commandName string) string {
+	if commandName == "" {
+		return path.Join(c.GetProjectLogsDir(projectName), "combined.log")
+	}
+
+	return path.Join(c.GetProjectLogsDir(projectName), "commands", commandName+".log")
+}
+
 // Returns the path to the nginx configuration directory.
 func (c *Config) GetNginxConfigDir() string {
 	return c.nginxConfigDir
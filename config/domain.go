@@ -0,0 +1,206 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/iskandervdh/spinup/common"
+)
+
+// Key of the setting that contains the top-level domain of the domains of projects.
+const TLDSettingKey = "tld"
+
+// Key of the setting that contains the domain suffixes domain aliases can be under, besides the TLD.
+const DomainAliasSuffixesSettingKey = "domainAliasSuffixes"
+
+// Top-level domain of the domains of projects by default.
+const DefaultTLD = "test"
+
+// Domain suffix that is always allowed for domain aliases, since it resolves to localhost without dnsmasq.
+const localhostSuffix = "localhost"
+
+// Regex to match a domain of one or more labels, without leading or trailing dots.
+var domainRegex = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]*[a-z0-9])?(\.[a-z0-9]([a-z0-9-]*[a-z0-9])?)*$`)
+
+// Normalize the given top-level domain, removing the leading dot, and check that it is a valid domain.
+//
+// Multiple labels are allowed, like dev.internal.
+func NormalizeTLD(tld string) (string, error) {
+	normalized := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(tld), "."))
+
+	if !domainRegex.MatchString(normalized) {
+		return "", fmt.Errorf("invalid TLD '%s'", tld)
+	}
+
+	return normalized, nil
+}
+
+// Get the top-level domain of the domains of projects.
+func (c *Config) GetTLD() string {
+	value, err := c.GetSetting(TLDSettingKey)
+
+	if err != nil {
+		return DefaultTLD
+	}
+
+	tld, ok := value.(string)
+
+	if !ok {
+		return DefaultTLD
+	}
+
+	normalized, err := NormalizeTLD(tld)
+
+	if err != nil {
+		return DefaultTLD
+	}
+
+	return normalized
+}
+
+// Set the top-level domain of the domains of projects.
+func (c *Config) SetTLD(tld string) error {
+	normalized, err := NormalizeTLD(tld)
+
+	if err != nil {
+		return err
+	}
+
+	return c.SetSetting(TLDSettingKey, normalized)
+}
+
+// Get the domain of the project with the given name.
+//
+// All domains of projects are derived with this function, so they follow the TLD setting.
+func (c *Config) GetDomain(projectName string) string {
+	return projectName + "." + c.GetTLD()
+}
+
+// Get the domain suffixes domain aliases can be under: the TLD, localhost and the ones in the settings.
+func (c *Config) GetDomainAliasSuffixes() []string {
+	suffixes := []string{c.GetTLD(), localhostSuffix}
+
+	value, err := c.GetSetting(DomainAliasSuffixesSettingKey)
+
+	if err != nil {
+		return suffixes
+	}
+
+	values, ok := value.([]interface{})
+
+	if !ok {
+		return suffixes
+	}
+
+	for _, value := range values {
+		suffix, ok := value.(string)
+
+		if !ok {
+			continue
+		}
+
+		if normalized, err := NormalizeTLD(suffix); err == nil && !slices.Contains(suffixes, normalized) {
+			suffixes = append(suffixes, normalized)
+		}
+	}
+
+	return suffixes
+}
+
+// Check that the given domain alias is a valid domain under one of the allowed suffixes.
+func (c *Config) ValidateDomainAlias(domainAlias string) error {
+	if !domainRegex.MatchString(domainAlias) {
+		return fmt.Errorf("invalid domain '%s'", domainAlias)
+	}
+
+	suffixes := c.GetDomainAliasSuffixes()
+
+	for _, suffix := range suffixes {
+		if strings.HasSuffix(domainAlias, "."+suffix) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("domain alias '%s' should end with .%s", domainAlias, strings.Join(suffixes, " or ."))
+}
+
+// Returns the path of the dnsmasq configuration file that is shipped with spinup,
+// or an empty string on Windows, where dnsmasq is not used.
+func (c *Config) GetDnsmasqConfigPath() string {
+	if c.IsTesting() {
+		return path.Join(c.configDir, "config", "dnsmasq.conf")
+	}
+
+	if common.IsWindows() {
+		return ""
+	}
+
+	if common.IsMacOS() {
+		return "/usr/local/share/spinup/config/dnsmasq.conf"
+	}
+
+	return "/etc/spinup/config/dnsmasq.conf"
+}
+
+// Get the contents of the dnsmasq configuration file that forwards all domains with the given TLD to localhost.
+func RenderDnsmasqConfig(tld string) string {
+	return fmt.Sprintf(`#########################################
+# Spinup configuration file for dnsmasq #
+#########################################
+
+# Require requests to have an actual domain unless specified in /etc/hosts
+domain-needed
+
+# Prevent reverse lookups to private IP ranges from being forwarded upstream
+bogus-priv
+
+# Forward all requests with TLD '.%[1]s' to localhost
+address=/%[1]s/127.0.0.1
+`, tld)
+}
+
+// Rewrite the dnsmasq configuration file for the TLD in the settings and restart dnsmasq.
+//
+// The file is owned by root, so it is written with sudo when it cannot be written directly.
+func (c *Config) WriteDnsmasqConfig() error {
+	dnsmasqConfigPath := c.GetDnsmasqConfigPath()
+
+	if dnsmasqConfigPath == "" {
+		return nil
+	}
+
+	content := RenderDnsmasqConfig(c.GetTLD())
+
+	err := os.MkdirAll(path.Dir(dnsmasqConfigPath), 0755)
+
+	if err == nil {
+		err = os.WriteFile(dnsmasqConfigPath, []byte(content), 0644)
+	}
+
+	if os.IsPermission(err) && !c.IsTesting() {
+		cmd := exec.Command("sudo", "tee", dnsmasqConfigPath)
+		cmd.Stdin = strings.NewReader(content)
+		cmd.Stderr = os.Stderr
+
+		err = cmd.Run()
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if c.IsTesting() {
+		return nil
+	}
+
+	if common.IsMacOS() {
+		return exec.Command("sudo", "brew", "services", "restart", "dnsmasq").Run()
+	}
+
+	return exec.Command("sudo", "systemctl", "restart", "dnsmasq").Run()
+}
@@ -0,0 +1,114 @@
+package config
+
+import (
+	"os"
+	"testing"
+)
+
+func TestNormalizeTLD(t *testing.T) {
+	valid := map[string]string{
+		"test":          "test",
+		".localhost":    "localhost",
+		"Dev.Internal":  "dev.internal",
+		" .dev.local ":  "dev.local",
+		"my-domain.dev": "my-domain.dev",
+	}
+
+	for tld, expected := range valid {
+		normalized, err := NormalizeTLD(tld)
+
+		if err != nil || normalized != expected {
+			t.Errorf("Expected '%s' to normalize to '%s', got '%s' (%v)", tld, expected, normalized, err)
+		}
+	}
+
+	for _, tld := range []string{"", ".", "dev..internal", "dev.", "-dev", "dev_internal", "dev internal"} {
+		if _, err := NormalizeTLD(tld); err == nil {
+			t.Errorf("Expected '%s' to be invalid", tld)
+		}
+	}
+}
+
+func TestGetDomain(t *testing.T) {
+	c := TestingConfig("get_domain")
+
+	if c.GetTLD() != DefaultTLD || c.GetDomain("example") != "example.test" {
+		t.Errorf("Expected the default domain example.test, got %s", c.GetDomain("example"))
+	}
+
+	err := c.SetTLD(".Dev.Internal")
+
+	if err != nil {
+		t.Errorf("Expected no error, got %s", err)
+		return
+	}
+
+	if c.GetDomain("example") != "example.dev.internal" {
+		t.Errorf("Expected domain example.dev.internal, got %s", c.GetDomain("example"))
+	}
+
+	if c.SetTLD("dev..internal") == nil || c.GetTLD() != "dev.internal" {
+		t.Error("Expected an invalid TLD not to be saved")
+	}
+}
+
+func TestValidateDomainAlias(t *testing.T) {
+	c := TestingConfig("validate_domain_alias")
+
+	for _, domainAlias := range []string{"api.test", "api.example.test", "api.localhost"} {
+		if err := c.ValidateDomainAlias(domainAlias); err != nil {
+			t.Errorf("Expected domain alias '%s' to be valid, got %s", domainAlias, err)
+		}
+	}
+
+	for _, domainAlias := range []string{"test", "api.dev.internal", "api.example.com", "api.test.", "api_1.test"} {
+		if err := c.ValidateDomainAlias(domainAlias); err == nil {
+			t.Errorf("Expected domain alias '%s' to be invalid", domainAlias)
+		}
+	}
+
+	c.SetTLD("dev.internal")
+	c.SetSetting(DomainAliasSuffixesSettingKey, []string{"example.com", "invalid..suffix"})
+
+	for _, domainAlias := range []string{"api.dev.internal", "api.example.com", "api.localhost"} {
+		if err := c.ValidateDomainAlias(domainAlias); err != nil {
+			t.Errorf("Expected domain alias '%s' to be valid, got %s", domainAlias, err)
+		}
+	}
+
+	if err := c.ValidateDomainAlias("api.test"); err == nil {
+		t.Error("Expected domain alias under the previous TLD to be invalid")
+	}
+}
+
+func TestRenderDnsmasqConfig(t *testing.T) {
+	shipped, err := os.ReadFile("../packaging/unix/etc/spinup/config/dnsmasq.conf")
+
+	if err != nil {
+		t.Errorf("Expected no error, got %s", err)
+		return
+	}
+
+	if RenderDnsmasqConfig(DefaultTLD) != string(shipped) {
+		t.Errorf("Expected the rendered dnsmasq config to match the shipped one, got:\n%s", RenderDnsmasqConfig(DefaultTLD))
+	}
+}
+
+func TestWriteDnsmasqConfig(t *testing.T) {
+	c := TestingConfig("write_dnsmasq_config")
+
+	c.SetTLD("localhost")
+
+	err := c.WriteDnsmasqConfig()
+
+	if err != nil {
+		t.Errorf("Expected no error, got %s", err)
+		return
+	}
+
+	content, err := os.ReadFile(c.GetDnsmasqConfigPath())
+
+	if err != nil || string(content) != RenderDnsmasqConfig("localhost") {
+		t.Errorf("Expected the dnsmasq config for localhost, got %s (%v)", content, err)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/iskandervdh/spinup/common"
@@ -19,24 +20,106 @@ func (c *Config) reloadNginx() error {
 	return exec.Command("sudo", "systemctl", "reload", "nginx").Run()
 }
 
-// Add a new Nginx configuration file with the given name and port.
-func (c *Config) AddNginxConfig(name string, port int64) error {
-	config := fmt.Sprintf(`server {
-	listen 80;
+// Test the configuration of Nginx, including the configuration files of the projects.
+func (c *Config) ValidateNginx() error {
+	if c.IsTesting() {
+		return nil
+	}
 
-	server_name %s.test;
+	output, err := exec.Command("sudo", "nginx", "-t").CombinedOutput()
 
-	location / {
-		proxy_pass http://127.0.0.1:%d/;
+	if err != nil {
+		return fmt.Errorf("%s", strings.TrimSpace(string(output)))
+	}
+
+	return nil
+}
+
+// Header of the Nginx configuration files that are generated by spinup.
+var nginxConfigHeader = generatedHeader("#")
+
+// Get the path of the Nginx configuration file with the given name.
+func (c *Config) GetNginxConfigPath(name string) string {
+	return fmt.Sprintf("%s/%s.conf", c.nginxConfigDir, name)
+}
+
+// Get the contents of the Nginx configuration file of a project with the given name, port and domain aliases.
+func (c *Config) RenderNginxConfig(name string, port int64, domainAliases []string) string {
+	return renderNginxConfig(append([]string{c.GetDomain(name)}, domainAliases...), port, nil, nil)
+}
+
+// Get the location block that forwards the requests to the given path prefix to the given port.
+//
+// Prefixes other than / end with a slash, so they only match whole path segments. Nginx redirects requests
+// to the prefix without the slash. With a URI in proxy_pass the matched prefix is replaced by it, stripping it.
+func renderNginxLocation(path string, port int64, stripPrefix bool) string {
+	upstream := fmt.Sprintf("http://127.0.0.1:%d", port)
+
+	if path != "/" {
+		path += "/"
+	}
+
+	if path == "/" || stripPrefix {
+		upstream += "/"
+	}
+
+	return fmt.Sprintf(`	location %s {
+		proxy_pass %s;
 		proxy_set_header Host $host;
 		proxy_set_header X-Real-IP $remote_addr;
 		proxy_set_header X-Forwarded-For $proxy_add_x_forwarded_for;
 		proxy_set_header X-Forwarded-Proto $scheme;
 	}
+`, path, upstream)
+}
+
+// Get the contents of the Nginx configuration file of a project with the given server names, port and routes, served
+// over HTTPS with the given certificate if it is not nil, in which case HTTP requests are redirected to HTTPS.
+func renderNginxConfig(serverNameList []string, port int64, routes []Route, tls *siteTLS) string {
+	serverNames := strings.Join(serverNameList, " ")
+
+	rootPort, prefixRoutes := splitRoutes(port, routes)
+	locations := renderNginxLocation("/", rootPort, false)
+
+	for _, route := range prefixRoutes {
+		locations += "\n" + renderNginxLocation(route.Path, route.Port, route.StripPrefix)
+	}
+
+	if tls == nil {
+		return nginxConfigHeader + fmt.Sprintf(`server {
+	listen 80;
+
+	server_name %s;
+
+%s}
+`, serverNames, locations)
+	}
+
+	return nginxConfigHeader + fmt.Sprintf(`server {
+	listen 80;
+
+	server_name %[1]s;
+
+	return 301 https://$host$request_uri;
 }
-`, name, port)
 
-	nginxConfigFilePath := fmt.Sprintf("%s/%s.conf", c.nginxConfigDir, name)
+server {
+	listen 443 ssl;
+
+	server_name %[1]s;
+
+	ssl_certificate %[2]s;
+	ssl_certificate_key %[3]s;
+
+%[4]s}
+`, serverNames, tls.certFile, tls.keyFile, locations)
+}
+
+// Add a new Nginx configuration file with the given name and port.
+func (c *Config) AddNginxConfig(name string, port int64) error {
+	config := c.RenderNginxConfig(name, port, nil)
+
+	nginxConfigFilePath := c.GetNginxConfigPath(name)
 
 	if _, err := os.Stat(nginxConfigFilePath); err == nil {
 		return fmt.Errorf("config file %s already exists", nginxConfigFilePath)
@@ -59,7 +142,7 @@ func (c *Config) AddNginxConfig(name string, port int64) error {
 
 // Remove a Nginx configuration file with the given name.
 func (c *Config) RemoveNginxConfig(name string) error {
-	nginxConfigFilePath := fmt.Sprintf("%s/%s.conf", c.nginxConfigDir, name)
+	nginxConfigFilePath := c.GetNginxConfigPath(name)
 	err := os.Remove(nginxConfigFilePath)
 
 	if err != nil {
@@ -86,8 +169,8 @@ func (c *Config) UpdateNginxConfig(name string, port int64) error {
 
 // Rename a Nginx configuration file with the given old and new name.
 func (c *Config) RenameNginxConfig(oldName string, newName string) error {
-	oldNginxConfigFilePath := fmt.Sprintf("%s/%s.conf", c.nginxConfigDir, oldName)
-	newNginxConfigFilePath := fmt.Sprintf("%s/%s.conf", c.nginxConfigDir, newName)
+	oldNginxConfigFilePath := c.GetNginxConfigPath(oldName)
+	newNginxConfigFilePath := c.GetNginxConfigPath(newName)
 
 	err := os.Rename(oldNginxConfigFilePath, newNginxConfigFilePath)
 
@@ -102,30 +185,26 @@ func (c *Config) RenameNginxConfig(oldName string, newName string) error {
 	return nil
 }
 
-// Add a domain alias to a Nginx configuration file.
-func (c *Config) NginxAddDomainAlias(name string, domainAlias string) error {
-	nginxConfigFilePath := fmt.Sprintf("%s/%s.conf", c.nginxConfigDir, name)
+// Update the server names of a Nginx configuration file with the given function.
+//
+// The server names of every server block are replaced, since HTTPS configuration files have a server block
+// for redirecting HTTP requests as well.
+func (c *Config) updateNginxServerNames(name string, update func(serverNames []string) []string) error {
+	nginxConfigFilePath := c.GetNginxConfigPath(name)
 	content, err := os.ReadFile(nginxConfigFilePath)
 
 	if err != nil {
 		return err
 	}
 
-	lines := strings.Split(string(content), "\n")
-	serverName := ""
-
-	for _, line := range lines {
-		if serverNameRegex.MatchString(line) {
-			serverName = serverNameRegex.FindStringSubmatch(line)[1]
-		}
-	}
+	match := serverNameRegex.FindStringSubmatch(string(content))
 
-	if serverName == "" {
+	if match == nil {
 		return fmt.Errorf("server_name not found in config file")
 	}
 
-	newServerName := fmt.Sprintf("server_name %s %s;", serverName, domainAlias)
-	updatedConfig := strings.ReplaceAll(string(content), fmt.Sprintf("server_name %s;", serverName), newServerName)
+	newServerName := fmt.Sprintf("server_name %s;", strings.Join(update(strings.Fields(match[1])), " "))
+	updatedConfig := strings.ReplaceAll(string(content), match[0], newServerName)
 
 	err = c.writeToFile(nginxConfigFilePath, updatedConfig)
 
@@ -140,43 +219,84 @@ func (c *Config) NginxAddDomainAlias(name string, domainAlias string) error {
 	return nil
 }
 
+// Add a domain alias to a Nginx configuration file.
+func (c *Config) NginxAddDomainAlias(name string, domainAlias string) error {
+	return c.updateNginxServerNames(name, func(serverNames []string) []string {
+		if slices.Contains(serverNames, domainAlias) {
+			return serverNames
+		}
+
+		return append(serverNames, domainAlias)
+	})
+}
+
 // Remove a domain alias from a Nginx configuration file.
+//
+// Only the server name that is exactly the domain alias is removed, not the ones that contain it.
 func (c *Config) NginxRemoveDomainAlias(name string, domainAlias string) error {
-	nginxConfigFilePath := fmt.Sprintf("%s/%s.conf", c.nginxConfigDir, name)
-	content, err := os.ReadFile(nginxConfigFilePath)
+	return c.updateNginxServerNames(name, func(serverNames []string) []string {
+		return slices.DeleteFunc(serverNames, func(serverName string) bool { return serverName == domainAlias })
+	})
+}
+
+// Get the names of the Nginx configuration files in the configuration directory and their contents.
+func (c *Config) ReadNginxConfigs() (map[string]string, error) {
+	entries, err := os.ReadDir(c.nginxConfigDir)
 
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	lines := strings.Split(string(content), "\n")
-	serverName := ""
+	configs := map[string]string{}
+
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), ".conf")
 
-	for _, line := range lines {
-		if serverNameRegex.MatchString(line) {
-			serverName = serverNameRegex.FindStringSubmatch(line)[1]
+		if !ok || entry.IsDir() {
+			continue
 		}
+
+		content, err := os.ReadFile(filepath.Join(c.nginxConfigDir, entry.Name()))
+
+		if err != nil {
+			return nil, err
+		}
+
+		configs[name] = string(content)
 	}
 
-	if serverName == "" {
-		return fmt.Errorf("server_name not found in config file")
+	return configs, nil
+}
+
+// Check if the Nginx configuration file with the given name and contents was generated by spinup,
+// either with its header or, for files generated by older versions, with the domain of a project with that name.
+// Older versions always used the default TLD.
+//
+// Other configuration files can be in the same directory, for example on Windows.
+func IsGeneratedNginxConfig(name string, content string) bool {
+	if strings.HasPrefix(content, nginxConfigHeader) {
+		return true
 	}
 
-	updatedServerName := strings.Trim(strings.ReplaceAll(serverName, domainAlias, ""), " ")
-	newServerName := fmt.Sprintf("server_name %s;", updatedServerName)
-	updatedConfig := strings.ReplaceAll(string(content), fmt.Sprintf("server_name %s;", serverName), newServerName)
+	match := serverNameRegex.FindStringSubmatch(content)
 
-	err = c.writeToFile(nginxConfigFilePath, updatedConfig)
+	return match != nil && slices.Contains(strings.Fields(match[1]), name+"."+DefaultTLD)
+}
 
-	if err != nil {
-		return err
-	}
+// Write the Nginx configuration file with the given name, port and domain aliases, replacing the existing one.
+//
+// Nginx is not reloaded, so multiple configuration files can be written before calling ReloadNginx.
+func (c *Config) WriteNginxConfig(name string, port int64, domainAliases []string) error {
+	return c.writeToFile(c.GetNginxConfigPath(name), c.RenderNginxConfig(name, port, domainAliases))
+}
 
-	if !c.IsTesting() {
-		c.reloadNginx()
+// Reload Nginx to apply changes to its configuration files.
+func (c *Config) ReloadNginx() error {
+	if c.IsTesting() {
+		return nil
 	}
 
-	return nil
+	return c.reloadNginx()
 }
 
 // Initialize the Nginx configuration directory.
@@ -205,3 +325,105 @@ func (c *Config) InitNginx() error {
 
 	return nil
 }
+
+// nginxProxy is the reverse proxy backend that uses the Nginx configuration files of the Config.
+type nginxProxy struct {
+	config *Config
+}
+
+func (p *nginxProxy) Name() string {
+	return ReverseProxyNginx
+}
+
+func (p *nginxProxy) Init() error {
+	return p.config.InitNginx()
+}
+
+func (p *nginxProxy) AddSite(name string, port int64, domainAliases []string) error {
+	if len(domainAliases) == 0 && !p.config.IsHTTPSEnabled() {
+		return p.config.AddNginxConfig(name, port)
+	}
+
+	if _, err := os.Stat(p.GetSitePath(name)); err == nil {
+		return fmt.Errorf("config file %s already exists", p.GetSitePath(name))
+	}
+
+	err := p.WriteSite(name, port, domainAliases, nil)
+
+	if err != nil {
+		return err
+	}
+
+	return p.Reload()
+}
+
+func (p *nginxProxy) RemoveSite(name string) error {
+	return p.config.RemoveNginxConfig(name)
+}
+
+func (p *nginxProxy) RenameSite(oldName string, newName string) error {
+	err := p.config.RenameNginxConfig(oldName, newName)
+
+	if err != nil {
+		return err
+	}
+
+	// Use the certificate of the project with the new name
+	if tls := p.config.siteTLS(newName); tls != nil {
+		content, err := os.ReadFile(p.GetSitePath(newName))
+
+		if err != nil {
+			return err
+		}
+
+		oldCertFile, oldKeyFile := p.config.GetCertificatePaths(oldName)
+		updatedConfig := strings.NewReplacer(oldCertFile+";", tls.certFile+";", oldKeyFile+";", tls.keyFile+";").Replace(string(content))
+
+		err = p.config.writeToFile(p.GetSitePath(newName), updatedConfig)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	// Replace the domain of the project, which is the first server name
+	return p.config.updateNginxServerNames(newName, func(serverNames []string) []string {
+		return append([]string{p.config.GetDomain(newName)}, slices.DeleteFunc(serverNames, func(serverName string) bool { return serverName == p.config.GetDomain(oldName) })...)
+	})
+}
+
+func (p *nginxProxy) AddDomainAlias(name string, domainAlias string) error {
+	return p.config.NginxAddDomainAlias(name, domainAlias)
+}
+
+func (p *nginxProxy) RemoveDomainAlias(name string, domainAlias string) error {
+	return p.config.NginxRemoveDomainAlias(name, domainAlias)
+}
+
+func (p *nginxProxy) Reload() error {
+	return p.config.ReloadNginx()
+}
+
+func (p *nginxProxy) Validate() error {
+	return p.config.ValidateNginx()
+}
+
+func (p *nginxProxy) GetSitePath(name string) string {
+	return p.config.GetNginxConfigPath(name)
+}
+
+func (p *nginxProxy) RenderSite(name string, port int64, domainAliases []string, routes []Route) string {
+	return renderNginxConfig(append([]string{p.config.GetDomain(name)}, domainAliases...), port, routes, p.config.siteTLS(name))
+}
+
+func (p *nginxProxy) WriteSite(name string, port int64, domainAliases []string, routes []Route) error {
+	return p.config.writeToFile(p.GetSitePath(name), p.RenderSite(name, port, domainAliases, routes))
+}
+
+func (p *nginxProxy) ReadSites() (map[string]string, error) {
+	return p.config.ReadNginxConfigs()
+}
+
+func (p *nginxProxy) IsGeneratedSite(name string, content string) bool {
+	return IsGeneratedNginxConfig(name, content)
+}
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -89,3 +90,82 @@ func TestRemoveNginxConfigDoesNotExist(t *testing.T) {
 		t.Errorf("Expected error, got nil")
 	}
 }
+
+func TestNginxDomainAliases(t *testing.T) {
+	c := TestingConfig("nginx_domain_aliases")
+
+	c.InitNginx()
+	c.AddNginxConfig("test", 8080)
+
+	c.NginxAddDomainAlias("test", "myapi.local")
+	c.NginxAddDomainAlias("test", "api.local")
+	c.NginxAddDomainAlias("test", "api.local")
+
+	err := c.NginxRemoveDomainAlias("test", "api.local")
+
+	if err != nil {
+		t.Errorf("Expected no error, got %s", err)
+	}
+
+	configs, err := c.ReadNginxConfigs()
+
+	if err != nil {
+		t.Errorf("Expected no error, got %s", err)
+	}
+
+	if configs["test"] != c.RenderNginxConfig("test", 8080, []string{"myapi.local"}) {
+		t.Errorf("Expected only the removed domain alias to be removed, got %s", configs["test"])
+	}
+}
+
+func TestIsGeneratedNginxConfig(t *testing.T) {
+	if !IsGeneratedNginxConfig("test", TestingConfig("is_generated_nginx_config").RenderNginxConfig("test", 8080, nil)) {
+		t.Errorf("Expected rendered config to be generated by spinup")
+	}
+
+	if !IsGeneratedNginxConfig("test", "server {\n\tserver_name test.test alias.local;\n}\n") {
+		t.Errorf("Expected config with the domain of the project to be generated by spinup")
+	}
+
+	if IsGeneratedNginxConfig("default", "server {\n\tserver_name example.com;\n}\n") {
+		t.Errorf("Expected other config not to be generated by spinup")
+	}
+}
+
+func TestNginxHTTPS(t *testing.T) {
+	c := TestingConfig("nginx_https")
+
+	c.SetSetting(HTTPSSettingKey, true)
+
+	proxy, _ := c.NewReverseProxy(ReverseProxyNginx)
+	proxy.Init()
+	proxy.AddSite("test", 8080, nil)
+	proxy.AddDomainAlias("test", "api.local")
+
+	configs, _ := proxy.ReadSites()
+	certPath, keyPath := c.GetCertificatePaths("test")
+
+	for _, expected := range []string{
+		"return 301 https://$host$request_uri;",
+		"listen 443 ssl;",
+		"ssl_certificate " + certPath + ";",
+		"ssl_certificate_key " + keyPath + ";",
+	} {
+		if !strings.Contains(configs["test"], expected) {
+			t.Errorf("Expected config to contain %q, got %s", expected, configs["test"])
+		}
+	}
+
+	if strings.Count(configs["test"], "server_name test.test api.local;") != 2 {
+		t.Errorf("Expected domain alias to be added to both server blocks, got %s", configs["test"])
+	}
+
+	proxy.RenameSite("test", "renamed")
+
+	configs, _ = proxy.ReadSites()
+	certPath, _ = c.GetCertificatePaths("renamed")
+
+	if configs["renamed"] != proxy.RenderSite("renamed", 8080, []string{"api.local"}, nil) || !strings.Contains(configs["renamed"], certPath) {
+		t.Errorf("Expected renamed config to use the certificate of the new name, got %s", configs["renamed"])
+	}
+}
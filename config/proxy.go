@@ -0,0 +1,345 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/iskandervdh/spinup/common"
+)
+
+// Key of the setting that selects the reverse proxy backend.
+const ReverseProxySettingKey = "reverseProxy"
+
+// Names of the supported reverse proxy backends.
+const (
+	ReverseProxyNginx   = "nginx"
+	ReverseProxyCaddy   = "caddy"
+	ReverseProxyTraefik = "traefik"
+	ReverseProxyBuiltin = "builtin"
+)
+
+// Names of the supported reverse proxy backends, the first one is the default.
+var ReverseProxyNames = []string{ReverseProxyNginx, ReverseProxyCaddy, ReverseProxyTraefik, ReverseProxyBuiltin}
+
+// ReverseProxy routes the domain of a project and its domain aliases to the port of the project,
+// or to the port of the route of the longest matching path prefix.
+//
+// Every project has its own site in the configuration directory of the backend.
+// All methods that change a site reload the reverse proxy, except WriteSite.
+type ReverseProxy interface {
+	// Name of the backend, one of ReverseProxyNames.
+	Name() string
+
+	// Create the configuration directory and print how to include it in the configuration of the reverse proxy.
+	Init() error
+
+	// Add the site of a project without routes, returns an error if it already exists.
+	AddSite(name string, port int64, domainAliases []string) error
+	// Remove the site of a project.
+	RemoveSite(name string) error
+	// Rename the site of a project, including its domain, keeping its routes.
+	RenameSite(oldName string, newName string) error
+
+	// Add a domain alias to the site of a project.
+	AddDomainAlias(name string, domainAlias string) error
+	// Remove a domain alias from the site of a project, leaving domain aliases that contain it alone.
+	RemoveDomainAlias(name string, domainAlias string) error
+
+	// Apply changes to the sites.
+	Reload() error
+	// Check that the sites are valid configuration for the reverse proxy.
+	Validate() error
+
+	// Get the path of the configuration file of the site of a project.
+	GetSitePath(name string) string
+	// Get the contents of the configuration file of the site of a project.
+	RenderSite(name string, port int64, domainAliases []string, routes []Route) string
+	// Write the site of a project, replacing the existing one, without reloading the reverse proxy.
+	WriteSite(name string, port int64, domainAliases []string, routes []Route) error
+	// Get the names of the sites in the configuration directory and their contents.
+	ReadSites() (map[string]string, error)
+	// Check if the site with the given name and contents was generated by spinup.
+	IsGeneratedSite(name string, content string) bool
+}
+
+// Get the reverse proxy backend that is selected in the settings, nginx if none is selected.
+func (c *Config) GetReverseProxy() (ReverseProxy, error) {
+	settings, err := c.GetSettings()
+
+	if err != nil {
+		return nil, err
+	}
+
+	name, ok := settings[ReverseProxySettingKey].(string)
+
+	if !ok {
+		name = ReverseProxyNames[0]
+	}
+
+	return c.NewReverseProxy(name)
+}
+
+// Create the reverse proxy backend with the given name.
+func (c *Config) NewReverseProxy(name string) (ReverseProxy, error) {
+	switch name {
+	case ReverseProxyNginx:
+		return &nginxProxy{config: c}, nil
+	case ReverseProxyCaddy:
+		return newCaddyProxy(c), nil
+	case ReverseProxyTraefik:
+		return newTraefikProxy(c), nil
+	case ReverseProxyBuiltin:
+		return &builtinProxy{config: c}, nil
+	}
+
+	return nil, fmt.Errorf("unknown reverse proxy '%s', expected one of %s", name, strings.Join(ReverseProxyNames, ", "))
+}
+
+// Select the reverse proxy backend with the given name in the settings.
+func (c *Config) SetReverseProxy(name string) error {
+	if _, err := c.NewReverseProxy(name); err != nil {
+		return err
+	}
+
+	return c.SetSetting(ReverseProxySettingKey, name)
+}
+
+// Returns the path to the configuration directory of the reverse proxy backend with the given name.
+func (c *Config) getReverseProxyConfigDir(name string) string {
+	if name == ReverseProxyNginx {
+		return c.nginxConfigDir
+	}
+
+	return path.Join(c.configDir, name)
+}
+
+// Site of a project as it is stored in the configuration file of a reverse proxy.
+type site struct {
+	port        int64
+	serverNames []string
+	routes      []Route
+}
+
+// fileProxy is a reverse proxy backend that has a configuration file for every site in a directory,
+// which is rendered and parsed by the backend.
+//
+// Changes to a site are made by parsing its configuration file and rendering it again, so the parse function
+// has to return everything the render function needs, including the routes.
+type fileProxy struct {
+	config *Config
+
+	name      string
+	extension string
+
+	render func(name string, port int64, serverNames []string, routes []Route, tls *siteTLS) string
+	parse  func(content string) (site, error)
+	reload func() error
+	check  func(path string, content string) error
+
+	// Lines that explain how to include the configuration directory in the configuration of the reverse proxy.
+	instructions func(dir string) string
+}
+
+func (p *fileProxy) Name() string {
+	return p.name
+}
+
+func (p *fileProxy) dir() string {
+	return p.config.getReverseProxyConfigDir(p.name)
+}
+
+func (p *fileProxy) Init() error {
+	err := os.MkdirAll(p.dir(), 0755)
+
+	if err != nil {
+		return err
+	}
+
+	if !p.config.IsTesting() {
+		fmt.Print(p.instructions(p.dir()))
+	}
+
+	return nil
+}
+
+func (p *fileProxy) GetSitePath(name string) string {
+	return filepath.Join(p.dir(), name+p.extension)
+}
+
+func (p *fileProxy) RenderSite(name string, port int64, domainAliases []string, routes []Route) string {
+	return p.render(name, port, append([]string{p.config.GetDomain(name)}, domainAliases...), routes, p.config.siteTLS(name))
+}
+
+func (p *fileProxy) WriteSite(name string, port int64, domainAliases []string, routes []Route) error {
+	return p.config.writeToFile(p.GetSitePath(name), p.RenderSite(name, port, domainAliases, routes))
+}
+
+func (p *fileProxy) ReadSites() (map[string]string, error) {
+	entries, err := os.ReadDir(p.dir())
+
+	if err != nil {
+		return nil, err
+	}
+
+	sites := map[string]string{}
+
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), p.extension)
+
+		if !ok || entry.IsDir() {
+			continue
+		}
+
+		content, err := os.ReadFile(filepath.Join(p.dir(), entry.Name()))
+
+		if err != nil {
+			return nil, err
+		}
+
+		sites[name] = string(content)
+	}
+
+	return sites, nil
+}
+
+func (p *fileProxy) IsGeneratedSite(name string, content string) bool {
+	return strings.HasPrefix(content, generatedHeader("#"))
+}
+
+func (p *fileProxy) Reload() error {
+	if p.config.IsTesting() {
+		return nil
+	}
+
+	return p.reload()
+}
+
+func (p *fileProxy) AddSite(name string, port int64, domainAliases []string) error {
+	sitePath := p.GetSitePath(name)
+
+	if _, err := os.Stat(sitePath); err == nil {
+		return fmt.Errorf("config file %s already exists", sitePath)
+	} else if !os.IsNotExist(err) {
+		return fmt.Errorf("failed to check if config file exists: %v", err)
+	}
+
+	err := p.WriteSite(name, port, domainAliases, nil)
+
+	if err != nil {
+		return err
+	}
+
+	return p.Reload()
+}
+
+func (p *fileProxy) RemoveSite(name string) error {
+	err := os.Remove(p.GetSitePath(name))
+
+	if err != nil {
+		return err
+	}
+
+	return p.Reload()
+}
+
+// Read and parse the site of a project.
+func (p *fileProxy) readSite(name string) (site, error) {
+	content, err := os.ReadFile(p.GetSitePath(name))
+
+	if err != nil {
+		return site{}, err
+	}
+
+	return p.parse(string(content))
+}
+
+// Update the server names of the site of a project with the given function.
+func (p *fileProxy) updateServerNames(name string, update func(serverNames []string) []string) error {
+	s, err := p.readSite(name)
+
+	if err != nil {
+		return err
+	}
+
+	err = p.config.writeToFile(p.GetSitePath(name), p.render(name, s.port, update(s.serverNames), s.routes, p.config.siteTLS(name)))
+
+	if err != nil {
+		return err
+	}
+
+	return p.Reload()
+}
+
+func (p *fileProxy) RenameSite(oldName string, newName string) error {
+	s, err := p.readSite(oldName)
+
+	if err != nil {
+		return err
+	}
+
+	serverNames := slices.DeleteFunc(s.serverNames, func(serverName string) bool { return serverName == p.config.GetDomain(oldName) })
+	err = p.config.writeToFile(p.GetSitePath(newName), p.render(newName, s.port, append([]string{p.config.GetDomain(newName)}, serverNames...), s.routes, p.config.siteTLS(newName)))
+
+	if err != nil {
+		return err
+	}
+
+	return p.RemoveSite(oldName)
+}
+
+func (p *fileProxy) AddDomainAlias(name string, domainAlias string) error {
+	return p.updateServerNames(name, func(serverNames []string) []string {
+		if slices.Contains(serverNames, domainAlias) {
+			return serverNames
+		}
+
+		return append(serverNames, domainAlias)
+	})
+}
+
+func (p *fileProxy) RemoveDomainAlias(name string, domainAlias string) error {
+	return p.updateServerNames(name, func(serverNames []string) []string {
+		return slices.DeleteFunc(serverNames, func(serverName string) bool { return serverName == domainAlias })
+	})
+}
+
+func (p *fileProxy) Validate() error {
+	sites, err := p.ReadSites()
+
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(sites))
+
+	for name := range sites {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	for _, name := range names {
+		if _, err := p.parse(sites[name]); err != nil {
+			return fmt.Errorf("%s: %s", p.GetSitePath(name), err)
+		}
+
+		if p.check == nil || p.config.IsTesting() {
+			continue
+		}
+
+		if err := p.check(p.GetSitePath(name), sites[name]); err != nil {
+			return fmt.Errorf("%s: %s", p.GetSitePath(name), err)
+		}
+	}
+
+	return nil
+}
+
+// Header of the configuration files that are generated by spinup, as a comment with the given prefix.
+func generatedHeader(commentPrefix string) string {
+	return commentPrefix + " Generated by " + common.ProgramName + ", manual changes are overwritten by `" + common.ProgramName + " proxy sync`\n"
+}
@@ -0,0 +1,172 @@
+package config
+
+import (
+	"os"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestGetReverseProxy(t *testing.T) {
+	c := TestingConfig("get_reverse_proxy")
+
+	proxy, err := c.GetReverseProxy()
+
+	if err != nil || proxy.Name() != ReverseProxyNginx {
+		t.Errorf("Expected nginx to be the default reverse proxy, got %v %s", proxy, err)
+	}
+
+	err = c.SetReverseProxy(ReverseProxyCaddy)
+
+	if err != nil {
+		t.Errorf("Expected no error, got %s", err)
+	}
+
+	proxy, err = c.GetReverseProxy()
+
+	if err != nil || proxy.Name() != ReverseProxyCaddy {
+		t.Errorf("Expected caddy to be the selected reverse proxy, got %v %s", proxy, err)
+	}
+
+	if c.SetReverseProxy("apache") == nil {
+		t.Errorf("Expected error when selecting an unknown reverse proxy, got nil")
+	}
+}
+
+func TestReverseProxySites(t *testing.T) {
+	for _, name := range []string{ReverseProxyNginx, ReverseProxyCaddy, ReverseProxyTraefik} {
+		t.Run(name, func(t *testing.T) {
+			c := TestingConfig("reverse_proxy_sites_" + name)
+			proxy, _ := c.NewReverseProxy(name)
+
+			err := proxy.Init()
+
+			if err != nil {
+				t.Fatalf("Expected no error, got %s", err)
+			}
+
+			err = proxy.AddSite("test", 8080, nil)
+
+			if err != nil {
+				t.Fatalf("Expected no error, got %s", err)
+			}
+
+			if proxy.AddSite("test", 8080, nil) == nil {
+				t.Errorf("Expected error when adding a site that already exists, got nil")
+			}
+
+			// Routes have to be kept when changing the domain aliases and renaming the site
+			routes := []Route{{Path: "/api", Port: 8081, StripPrefix: true}, {Path: "/docs", Port: 8082}}
+
+			err = proxy.WriteSite("test", 8080, nil, routes)
+
+			if err != nil {
+				t.Fatalf("Expected no error, got %s", err)
+			}
+
+			proxy.AddDomainAlias("test", "myapi.local")
+			proxy.AddDomainAlias("test", "api.local")
+			proxy.AddDomainAlias("test", "api.local")
+			proxy.RemoveDomainAlias("test", "api.local")
+
+			err = proxy.RenameSite("test", "renamed")
+
+			if err != nil {
+				t.Fatalf("Expected no error, got %s", err)
+			}
+
+			sites, err := proxy.ReadSites()
+
+			if err != nil {
+				t.Fatalf("Expected no error, got %s", err)
+			}
+
+			if len(sites) != 1 || sites["renamed"] != proxy.RenderSite("renamed", 8080, []string{"myapi.local"}, routes) {
+				t.Errorf("Expected only the renamed site with its domain aliases and routes, got %v", sites)
+			}
+
+			if !proxy.IsGeneratedSite("renamed", sites["renamed"]) {
+				t.Errorf("Expected rendered site to be generated by spinup")
+			}
+
+			if err := proxy.Validate(); err != nil {
+				t.Errorf("Expected rendered site to be valid, got %s", err)
+			}
+
+			err = proxy.RemoveSite("renamed")
+
+			if err != nil {
+				t.Errorf("Expected no error, got %s", err)
+			}
+
+			if _, err := os.Stat(proxy.GetSitePath("renamed")); !os.IsNotExist(err) {
+				t.Errorf("Expected site to be removed")
+			}
+		})
+	}
+}
+
+func TestParseCaddySite(t *testing.T) {
+	s, err := parseCaddySite(renderCaddySite("test", 8080, []string{"test.test", "api.local"}, nil, nil))
+
+	if err != nil || s.port != 8080 || len(s.serverNames) != 2 || s.serverNames[1] != "api.local" {
+		t.Errorf("Expected port and server names of the rendered site, got %v %s", s, err)
+	}
+
+	routes := []Route{{Path: "/", Port: 8081}, {Path: "/api", Port: 8082, StripPrefix: true}, {Path: "/api/v2", Port: 8083}}
+	s, err = parseCaddySite(renderCaddySite("test", 8080, []string{"test.test"}, routes, nil))
+
+	if err != nil || s.port != 8081 || !slices.Equal(s.routes, routes[1:]) {
+		t.Errorf("Expected the route of / to replace the port and the other routes to be parsed, got %v %s", s, err)
+	}
+
+	if _, err := parseCaddySite("test.test {\n}\n"); err == nil {
+		t.Errorf("Expected error for a site without reverse_proxy, got nil")
+	}
+}
+
+func TestParseTraefikSite(t *testing.T) {
+	s, err := parseTraefikSite(renderTraefikSite("test", 8080, []string{"test.test", "api.local"}, nil, nil))
+
+	if err != nil || s.port != 8080 || len(s.serverNames) != 2 || s.serverNames[1] != "api.local" {
+		t.Errorf("Expected port and server names of the rendered site, got %v %s", s, err)
+	}
+
+	routes := []Route{{Path: "/", Port: 8081}, {Path: "/api", Port: 8082, StripPrefix: true}, {Path: "/api/v2", Port: 8083}}
+	content := renderTraefikSite("test", 8080, []string{"test.test", "api.local"}, routes, nil)
+	s, err = parseTraefikSite(content)
+
+	if err != nil || s.port != 8081 || len(s.serverNames) != 2 || !slices.Equal(s.routes, routes[1:]) {
+		t.Errorf("Expected the route of / to replace the port and the other routes to be parsed, got %v %s", s, err)
+	}
+
+	if !strings.Contains(content, "rule: \"(Host(`test.test`) || Host(`api.local`)) && (Path(`/api`) || PathPrefix(`/api/`))\"") {
+		t.Errorf("Expected the rule of a route to match its path prefix on the hosts of the site, got %s", content)
+	}
+
+	if _, err := parseTraefikSite("http:\n  routers: {}\n"); err == nil {
+		t.Errorf("Expected error for a site without a router, got nil")
+	}
+}
+
+func TestGetProxyPort(t *testing.T) {
+	c := TestingConfig("get_proxy_port")
+
+	port, err := c.GetProxyPort()
+
+	if err != nil || port != DefaultProxyPort {
+		t.Errorf("Expected default proxy port, got %d %s", port, err)
+	}
+
+	c.SetSetting(ProxyPortSettingKey, 9000)
+
+	if port, _ := c.GetProxyPort(); port != 9000 {
+		t.Errorf("Expected proxy port from the settings, got %d", port)
+	}
+
+	c.SetSetting(ProxyPortSettingKey, "http")
+
+	if _, err := c.GetProxyPort(); err == nil {
+		t.Errorf("Expected error for a proxy port that is not a number, got nil")
+	}
+}
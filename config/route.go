@@ -0,0 +1,64 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Route forwards the requests to a path prefix of the domains of a project to a port,
+// optionally stripping the prefix from the path.
+type Route struct {
+	Path        string
+	Port        int64
+	StripPrefix bool
+}
+
+// Regex to match a path prefix of a route without a trailing slash, consisting of segments
+// that can be used in the configuration files of every reverse proxy without escaping.
+var routePathRegex = regexp.MustCompile(`^(/[A-Za-z0-9._~-]+)+$`)
+
+// Normalize the given path prefix of a route, removing trailing slashes, and check that it is a valid path.
+//
+// The root path is returned as /.
+func NormalizeRoutePath(routePath string) (string, error) {
+	routePath = strings.TrimSpace(routePath)
+
+	if !strings.HasPrefix(routePath, "/") {
+		return "", fmt.Errorf("path '%s' should start with /", routePath)
+	}
+
+	normalized := strings.TrimRight(routePath, "/")
+
+	if normalized == "" {
+		return "/", nil
+	}
+
+	segments := strings.Split(normalized, "/")
+
+	if !routePathRegex.MatchString(normalized) || slices.Contains(segments, ".") || slices.Contains(segments, "..") {
+		return "", fmt.Errorf("invalid path '%s'", routePath)
+	}
+
+	return normalized, nil
+}
+
+// Split the routes of a site into the port of the root path, which is the port of the project
+// unless a route for / overrides it, and the routes of the other path prefixes sorted by path.
+func splitRoutes(port int64, routes []Route) (int64, []Route) {
+	prefixRoutes := []Route{}
+
+	for _, route := range routes {
+		if route.Path == "/" {
+			port = route.Port
+			continue
+		}
+
+		prefixRoutes = append(prefixRoutes, route)
+	}
+
+	slices.SortFunc(prefixRoutes, func(a Route, b Route) int { return strings.Compare(a.Path, b.Path) })
+
+	return port, prefixRoutes
+}
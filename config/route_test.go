@@ -0,0 +1,55 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNormalizeRoutePath(t *testing.T) {
+	valid := map[string]string{
+		"/":                    "/",
+		"//":                   "/",
+		"/api":                 "/api",
+		"/api/":                "/api",
+		" /api/v2":             "/api/v2",
+		"/.well-known/acme_1~": "/.well-known/acme_1~",
+	}
+
+	for routePath, expected := range valid {
+		normalized, err := NormalizeRoutePath(routePath)
+
+		if err != nil || normalized != expected {
+			t.Errorf("Expected '%s' to normalize to '%s', got '%s' (%v)", routePath, expected, normalized, err)
+		}
+	}
+
+	for _, routePath := range []string{"", "api", "/api//v2", "/api/../admin", "/./api", "/api;", "/api v2", "/api{", "/*"} {
+		if _, err := NormalizeRoutePath(routePath); err == nil {
+			t.Errorf("Expected '%s' to be invalid", routePath)
+		}
+	}
+}
+
+func TestRenderNginxConfigRoutes(t *testing.T) {
+	content := renderNginxConfig([]string{"test.test"}, 8080, []Route{
+		{Path: "/api", Port: 8081, StripPrefix: true},
+		{Path: "/docs", Port: 8082},
+		{Path: "/", Port: 8083},
+	}, nil)
+
+	expected := []string{
+		"\tlocation / {\n\t\tproxy_pass http://127.0.0.1:8083/;\n",
+		"\tlocation /api/ {\n\t\tproxy_pass http://127.0.0.1:8081/;\n",
+		"\tlocation /docs/ {\n\t\tproxy_pass http://127.0.0.1:8082;\n",
+	}
+
+	for _, location := range expected {
+		if !strings.Contains(content, location) {
+			t.Errorf("Expected config to contain %q, got:\n%s", location, content)
+		}
+	}
+
+	if strings.Count(content, "location") != 3 || strings.Contains(content, "8080") {
+		t.Errorf("Expected the route of / to replace the location of the project, got:\n%s", content)
+	}
+}
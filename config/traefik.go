@@ -0,0 +1,187 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Regexes to match the hosts and the path prefix in the rule of a Traefik router.
+var (
+	traefikHostRegex       = regexp.MustCompile("Host\\(`([^`]+)`\\)")
+	traefikPathPrefixRegex = regexp.MustCompile("PathPrefix\\(`([^`]+)/`\\)")
+)
+
+// Dynamic configuration of Traefik in a file of the file provider, limited to the parts spinup generates.
+type traefikDynamicConfig struct {
+	HTTP struct {
+		Routers map[string]struct {
+			Rule        string   `yaml:"rule"`
+			Service     string   `yaml:"service"`
+			Middlewares []string `yaml:"middlewares"`
+		} `yaml:"routers"`
+		Services map[string]struct {
+			LoadBalancer struct {
+				Servers []struct {
+					URL string `yaml:"url"`
+				} `yaml:"servers"`
+			} `yaml:"loadBalancer"`
+		} `yaml:"services"`
+	} `yaml:"http"`
+}
+
+// Create the reverse proxy backend that writes a dynamic configuration file for every project,
+// which are loaded by the file provider of Traefik.
+//
+// Traefik watches the directory for changes, so there is nothing to reload.
+func newTraefikProxy(c *Config) ReverseProxy {
+	return &fileProxy{
+		config:    c,
+		name:      ReverseProxyTraefik,
+		extension: ".yml",
+		render:    renderTraefikSite,
+		parse:     parseTraefikSite,
+		reload: func() error {
+			return nil
+		},
+		instructions: func(dir string) string {
+			return fmt.Sprintf("\n!!! Please add the following file provider to the static configuration of Traefik:\n\nproviders:\n  file:\n    directory: %s\n    watch: true\n", dir)
+		},
+	}
+}
+
+// Render the dynamic configuration of a site, with a router and a service named after the project.
+// Every route gets its own router and service, numbered in the order of their paths, and a middleware
+// that strips the path prefix if needed. Traefik prefers the routers with the longest rules, which are
+// the ones with the longest path prefixes.
+//
+// With a certificate the routers only accept HTTPS requests, redirecting HTTP requests
+// is done with the entry points in the static configuration of Traefik.
+func renderTraefikSite(name string, port int64, serverNames []string, routes []Route, tls *siteTLS) string {
+	hosts := make([]string, len(serverNames))
+
+	for i, serverName := range serverNames {
+		hosts[i] = fmt.Sprintf("Host(`%s`)", serverName)
+	}
+
+	rule := strings.Join(hosts, " || ")
+
+	routerTLS := ""
+	certificates := ""
+
+	if tls != nil {
+		routerTLS = "      tls: {}\n"
+		certificates = fmt.Sprintf("tls:\n  certificates:\n    - certFile: %q\n      keyFile: %q\n", tls.certFile, tls.keyFile)
+	}
+
+	rootPort, prefixRoutes := splitRoutes(port, routes)
+
+	routers := fmt.Sprintf("    %[1]s:\n      rule: \"%[2]s\"\n      service: %[1]s\n%[3]s", name, rule, routerTLS)
+	middlewares := ""
+	services := fmt.Sprintf("    %s:\n      loadBalancer:\n        servers:\n          - url: \"http://127.0.0.1:%d/\"\n", name, rootPort)
+
+	if len(prefixRoutes) > 0 && len(hosts) > 1 {
+		rule = "(" + rule + ")"
+	}
+
+	for i, route := range prefixRoutes {
+		routeName := fmt.Sprintf("%s-route-%d", name, i+1)
+		routeMiddlewares := ""
+
+		if route.StripPrefix {
+			routeMiddlewares = fmt.Sprintf("      middlewares:\n        - %s\n", routeName)
+			middlewares += fmt.Sprintf("    %s:\n      stripPrefix:\n        prefixes:\n          - \"%s\"\n", routeName, route.Path)
+		}
+
+		routers += fmt.Sprintf(
+			"    %[1]s:\n      rule: \"%[2]s && (Path(`%[3]s`) || PathPrefix(`%[3]s/`))\"\n      service: %[1]s\n%[4]s%[5]s",
+			routeName, rule, route.Path, routeMiddlewares, routerTLS,
+		)
+		services += fmt.Sprintf("    %s:\n      loadBalancer:\n        servers:\n          - url: \"http://127.0.0.1:%d/\"\n", routeName, route.Port)
+	}
+
+	if middlewares != "" {
+		middlewares = "  middlewares:\n" + middlewares
+	}
+
+	return generatedHeader("#") + "http:\n  routers:\n" + routers + middlewares + "  services:\n" + services + certificates
+}
+
+// Get the port in the URL of the first server of a service.
+func parseTraefikServicePort(config traefikDynamicConfig, serviceName string) (int64, error) {
+	service, ok := config.HTTP.Services[serviceName]
+
+	if !ok || len(service.LoadBalancer.Servers) == 0 {
+		return 0, fmt.Errorf("service '%s' of router not found", serviceName)
+	}
+
+	serverURL, err := url.Parse(service.LoadBalancer.Servers[0].URL)
+
+	if err != nil {
+		return 0, err
+	}
+
+	port, err := strconv.ParseInt(serverURL.Port(), 10, 64)
+
+	if err != nil {
+		return 0, fmt.Errorf("invalid port in server url '%s'", service.LoadBalancer.Servers[0].URL)
+	}
+
+	return port, nil
+}
+
+// Parse the server names, port and routes of the dynamic configuration of a site.
+//
+// The router without a path prefix is the router of the project, the others are the routers of its routes.
+func parseTraefikSite(content string) (site, error) {
+	var config traefikDynamicConfig
+
+	err := yaml.Unmarshal([]byte(content), &config)
+
+	if err != nil {
+		return site{}, err
+	}
+
+	s := site{port: -1, routes: []Route{}}
+
+	for _, router := range config.HTTP.Routers {
+		port, err := parseTraefikServicePort(config, router.Service)
+
+		if err != nil {
+			return site{}, err
+		}
+
+		if pathMatch := traefikPathPrefixRegex.FindStringSubmatch(router.Rule); pathMatch != nil {
+			s.routes = append(s.routes, Route{Path: pathMatch[1], Port: port, StripPrefix: len(router.Middlewares) > 0})
+			continue
+		}
+
+		if s.port != -1 {
+			return site{}, fmt.Errorf("expected 1 router without a path prefix, found multiple")
+		}
+
+		s.port = port
+		s.serverNames = []string{}
+
+		for _, match := range traefikHostRegex.FindAllStringSubmatch(router.Rule, -1) {
+			s.serverNames = append(s.serverNames, match[1])
+		}
+
+		if len(s.serverNames) == 0 {
+			return site{}, fmt.Errorf("no hosts found in router rule '%s'", router.Rule)
+		}
+	}
+
+	if s.port == -1 {
+		return site{}, fmt.Errorf("expected 1 router without a path prefix, found none")
+	}
+
+	slices.SortFunc(s.routes, func(a Route, b Route) int { return strings.Compare(a.Path, b.Path) })
+
+	return s, nil
+}
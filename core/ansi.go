@@ -0,0 +1,193 @@
+package core
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Matches ANSI escape sequences, of which only the ones that set the style of the text (SGR) are used.
+var ansiRegex = regexp.MustCompile(`\x1b\[([0-9;:?]*)([ -/]*[@-~])`)
+
+// Colors of the 16 basic ANSI colors, the last 8 being the bright variants.
+var ansiColors = []string{
+	"#000000", "#cd3131", "#0dbc79", "#e5e510", "#2472c8", "#bc3fbc", "#11a8cd", "#e5e5e5",
+	"#666666", "#f14c4c", "#23d18b", "#f5f543", "#3b8eea", "#d670d6", "#29b8db", "#ffffff",
+}
+
+// AnsiSegment is a part of a line of output with the same style, as set by ANSI escape sequences.
+//
+// Colors are CSS hex colors, or empty for the default color.
+type AnsiSegment struct {
+	Text       string `json:"text"`
+	Foreground string `json:"foreground"`
+	Background string `json:"background"`
+	Bold       bool   `json:"bold"`
+	Dim        bool   `json:"dim"`
+	Italic     bool   `json:"italic"`
+	Underline  bool   `json:"underline"`
+}
+
+// Style of the text that is set by ANSI escape sequences.
+type ansiStyle struct {
+	foreground string
+	background string
+	bold       bool
+	dim        bool
+	italic     bool
+	underline  bool
+	inverse    bool
+}
+
+// Remove ANSI escape sequences from the given text.
+func stripAnsi(text string) string {
+	return ansiRegex.ReplaceAllString(text, "")
+}
+
+// Get the color of the 256 color palette with the given index.
+func ansi256Color(index int) string {
+	switch {
+	case index < 0 || index > 255:
+		return ""
+	case index < 16:
+		return ansiColors[index]
+	case index < 232:
+		// 6x6x6 color cube
+		levels := []int{0, 95, 135, 175, 215, 255}
+		index -= 16
+
+		return fmt.Sprintf("#%02x%02x%02x", levels[index/36], levels[index/6%6], levels[index%6])
+	default:
+		gray := 8 + (index-232)*10
+
+		return fmt.Sprintf("#%02x%02x%02x", gray, gray, gray)
+	}
+}
+
+// Parse the color of an extended color code (38 or 48) at the start of the given parameters.
+//
+// Returns the color and the number of parameters that were used.
+func parseExtendedColor(params []int) (string, int) {
+	if len(params) >= 2 && params[0] == 5 {
+		return ansi256Color(params[1]), 2
+	}
+
+	if len(params) >= 4 && params[0] == 2 {
+		return fmt.Sprintf("#%02x%02x%02x", params[1]&0xff, params[2]&0xff, params[3]&0xff), 4
+	}
+
+	return "", len(params)
+}
+
+// Apply the parameters of an SGR escape sequence to the given style.
+func (s *ansiStyle) apply(params []int) {
+	if len(params) == 0 {
+		params = []int{0}
+	}
+
+	for i := 0; i < len(params); i++ {
+		switch code := params[i]; {
+		case code == 0:
+			*s = ansiStyle{}
+		case code == 1:
+			s.bold = true
+		case code == 2:
+			s.dim = true
+		case code == 3:
+			s.italic = true
+		case code == 4:
+			s.underline = true
+		case code == 7:
+			s.inverse = true
+		case code == 22:
+			s.bold = false
+			s.dim = false
+		case code == 23:
+			s.italic = false
+		case code == 24:
+			s.underline = false
+		case code == 27:
+			s.inverse = false
+		case code >= 30 && code <= 37:
+			s.foreground = ansiColors[code-30]
+		case code == 38:
+			color, used := parseExtendedColor(params[i+1:])
+			s.foreground = color
+			i += used
+		case code == 39:
+			s.foreground = ""
+		case code >= 40 && code <= 47:
+			s.background = ansiColors[code-40]
+		case code == 48:
+			color, used := parseExtendedColor(params[i+1:])
+			s.background = color
+			i += used
+		case code == 49:
+			s.background = ""
+		case code >= 90 && code <= 97:
+			s.foreground = ansiColors[code-90+8]
+		case code >= 100 && code <= 107:
+			s.background = ansiColors[code-100+8]
+		}
+	}
+}
+
+func (s ansiStyle) segment(text string) AnsiSegment {
+	segment := AnsiSegment{
+		Text:       text,
+		Foreground: s.foreground,
+		Background: s.background,
+		Bold:       s.bold,
+		Dim:        s.dim,
+		Italic:     s.italic,
+		Underline:  s.underline,
+	}
+
+	if s.inverse {
+		segment.Foreground, segment.Background = s.background, s.foreground
+	}
+
+	return segment
+}
+
+// Split the given text into segments with the same style, as set by the ANSI escape sequences in the text.
+//
+// The escape sequences themselves are not part of the segments, sequences that do not set the style are ignored.
+func ParseAnsi(text string) []AnsiSegment {
+	segments := []AnsiSegment{}
+	style := ansiStyle{}
+	start := 0
+
+	for _, match := range ansiRegex.FindAllStringSubmatchIndex(text, -1) {
+		if match[0] > start {
+			segments = append(segments, style.segment(text[start:match[0]]))
+		}
+
+		start = match[1]
+
+		if text[match[4]:match[5]] != "m" {
+			continue
+		}
+
+		params := []int{}
+
+		for _, param := range strings.FieldsFunc(text[match[2]:match[3]], func(r rune) bool { return r == ';' || r == ':' }) {
+			value, err := strconv.Atoi(param)
+
+			if err != nil {
+				value = -1
+			}
+
+			params = append(params, value)
+		}
+
+		style.apply(params)
+	}
+
+	if start < len(text) {
+		segments = append(segments, style.segment(text[start:]))
+	}
+
+	return segments
+}
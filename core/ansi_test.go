@@ -0,0 +1,53 @@
+package core
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseAnsi(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected []AnsiSegment
+	}{
+		{"plain text", []AnsiSegment{{Text: "plain text"}}},
+		{"", []AnsiSegment{}},
+		{
+			"\x1b[32mgreen\x1b[0m normal",
+			[]AnsiSegment{{Text: "green", Foreground: "#0dbc79"}, {Text: " normal"}},
+		},
+		{
+			"\x1b[1;4;91mbold\x1b[22m underlined\x1b[m",
+			[]AnsiSegment{{Text: "bold", Foreground: "#f14c4c", Bold: true, Underline: true}, {Text: " underlined", Foreground: "#f14c4c", Underline: true}},
+		},
+		{
+			"\x1b[38;5;196mred\x1b[39;48;2;1;2;3mbackground",
+			[]AnsiSegment{{Text: "red", Foreground: "#ff0000"}, {Text: "background", Background: "#010203"}},
+		},
+		{
+			"\x1b[38;5;244mgray\x1b[7minverse",
+			[]AnsiSegment{{Text: "gray", Foreground: "#808080"}, {Text: "inverse", Background: "#808080"}},
+		},
+		{
+			// Escape sequences that do not set the style are removed
+			"\x1b[2Kcleared\x1b[1A",
+			[]AnsiSegment{{Text: "cleared"}},
+		},
+	}
+
+	for _, test := range tests {
+		segments := ParseAnsi(test.text)
+
+		if !slices.Equal(segments, test.expected) {
+			t.Errorf("Expected segments of %q to be %+v, got %+v", test.text, test.expected, segments)
+		}
+	}
+}
+
+func TestStripAnsi(t *testing.T) {
+	text := stripAnsi("\x1b[1;32mready\x1b[0m in \x1b[38;5;208m120ms\x1b[0m")
+
+	if text != "ready in 120ms" {
+		t.Error("Expected escape sequences to be removed, got", text)
+	}
+}
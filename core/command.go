@@ -1,7 +1,12 @@
 package core
 
 import (
+	"database/sql"
 	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/iskandervdh/spinup/common"
 	"github.com/iskandervdh/spinup/database/sqlc"
@@ -11,6 +16,90 @@ type Command = sqlc.Command
 
 type Commands []Command
 
+// CommandSettings contains the optional settings of a command that determine how it is run.
+//
+// Dir is relative to the directory of the project the command is run for
+// and Env contains extra environment variables in the form KEY=VALUE.
+// Watch and WatchExclude contain glob patterns of files in the project directory
+// that cause the command to be restarted when they change.
+type CommandSettings struct {
+	Shell        bool
+	Dir          string
+	Env          []string
+	ForceColor   bool
+	Watch        []string
+	WatchExclude []string
+}
+
+// Get the default settings of a newly added command.
+func DefaultCommandSettings() CommandSettings {
+	return CommandSettings{
+		ForceColor: true,
+	}
+}
+
+// Get the settings of the given command.
+func GetCommandSettings(command Command) CommandSettings {
+	return CommandSettings{
+		Shell:        command.Shell,
+		Dir:          command.Dir.String,
+		Env:          commandEnv(command),
+		ForceColor:   command.ForceColor,
+		Watch:        splitLines(command.Watch),
+		WatchExclude: splitLines(command.WatchExclude),
+	}
+}
+
+// Split the given newline separated value into its non-empty lines.
+func splitLines(value string) []string {
+	var lines []string
+
+	for _, line := range strings.Split(value, "\n") {
+		if strings.TrimSpace(line) != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	return lines
+}
+
+// Get the extra environment variables of the given command.
+func commandEnv(command Command) []string {
+	return splitLines(command.Env)
+}
+
+// Check if the given command settings are valid.
+//
+// Callers that create or update a command together with its settings should call this first,
+// so that invalid settings are rejected before anything is written to the database.
+func ValidateCommandSettings(settings CommandSettings) error {
+	if settings.Dir != "" {
+		if filepath.IsAbs(settings.Dir) {
+			return fmt.Errorf("directory '%s' must be relative to the project directory", settings.Dir)
+		}
+
+		if strings.HasPrefix(filepath.Clean(settings.Dir), "..") {
+			return fmt.Errorf("directory '%s' must be inside the project directory", settings.Dir)
+		}
+	}
+
+	for _, variable := range settings.Env {
+		if !envAssignmentRegex.MatchString(variable) || strings.Contains(variable, "\n") {
+			return fmt.Errorf("environment variable '%s' must be in the form KEY=VALUE", variable)
+		}
+	}
+
+	for _, pattern := range slices.Concat(settings.Watch, settings.WatchExclude) {
+		err := validateGlob(pattern)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (c *Core) FetchCommands() error {
 	commands, err := c.dbQueries.GetCommands(c.dbContext)
 
@@ -154,3 +243,286 @@ func (c *Core) RenameCommand(oldName string, newName string) common.Msg {
 
 	return common.NewSuccessMsg("Renamed command '%s' to '%s'", oldName, newName)
 }
+
+// Set whether the command with the given name should be run through the shell of the system.
+func (c *Core) SetCommandShell(name string, shell bool) common.Msg {
+	exists, _ := c.CommandExists(name)
+
+	if !exists {
+		return common.NewErrMsg("Command '%s' does not exist", name)
+	}
+
+	err := c.dbQueries.SetCommandShell(c.dbContext, sqlc.SetCommandShellParams{
+		Shell: shell,
+		Name:  name,
+	})
+
+	if err != nil {
+		return common.NewErrMsg("Error updating command: %s", err)
+	}
+
+	if shell {
+		return common.NewSuccessMsg("Enabled shell mode for command '%s'", name)
+	}
+
+	return common.NewSuccessMsg("Disabled shell mode for command '%s'", name)
+}
+
+func (c *Core) updateCommandSettings(id int64, settings CommandSettings) error {
+	err := ValidateCommandSettings(settings)
+
+	if err != nil {
+		return err
+	}
+
+	return c.dbQueries.UpdateCommandSettings(c.dbContext, sqlc.UpdateCommandSettingsParams{
+		Shell: settings.Shell,
+		Dir: sql.NullString{
+			String: settings.Dir,
+			Valid:  settings.Dir != "",
+		},
+		Env:          strings.Join(settings.Env, "\n"),
+		ForceColor:   settings.ForceColor,
+		Watch:        strings.Join(settings.Watch, "\n"),
+		WatchExclude: strings.Join(settings.WatchExclude, "\n"),
+		ID:           id,
+	})
+}
+
+// Set the settings of the command with the given name.
+func (c *Core) SetCommandSettings(name string, settings CommandSettings) common.Msg {
+	exists, command := c.CommandExists(name)
+
+	if !exists {
+		return common.NewErrMsg("Command '%s' does not exist", name)
+	}
+
+	err := c.updateCommandSettings(command.ID, settings)
+
+	if err != nil {
+		return common.NewErrMsg("Error updating command settings: %s", err)
+	}
+
+	return common.NewSuccessMsg("Updated settings of command '%s'", name)
+}
+
+func (c *Core) SetCommandSettingsById(id int64, settings CommandSettings) common.Msg {
+	err := c.updateCommandSettings(id, settings)
+
+	if err != nil {
+		return common.NewErrMsg("Error updating command settings: %s", err)
+	}
+
+	return common.NewSuccessMsg("Updated command settings")
+}
+
+// Set the readiness check of the command with the given name.
+//
+// Commands that depend on this command will wait until the check succeeds before they are started.
+func (c *Core) SetCommandReadiness(name string, check string, value string) common.Msg {
+	exists, _ := c.CommandExists(name)
+
+	if !exists {
+		return common.NewErrMsg("Command '%s' does not exist", name)
+	}
+
+	err := validateReadiness(check, value)
+
+	if err != nil {
+		return common.NewErrMsg("Invalid readiness check: %s", err)
+	}
+
+	err = c.dbQueries.SetCommandReadiness(c.dbContext, sqlc.SetCommandReadinessParams{
+		ReadyCheck: check,
+		ReadyValue: value,
+		Name:       name,
+	})
+
+	if err != nil {
+		return common.NewErrMsg("Error updating command: %s", err)
+	}
+
+	if check == ReadyCheckNone {
+		return common.NewSuccessMsg("Removed readiness check of command '%s'", name)
+	}
+
+	return common.NewSuccessMsg("Set readiness check of command '%s' to %s '%s'", name, check, value)
+}
+
+// Set the restart policy of the command with the given name.
+//
+// A maximum of 0 retries means the command is restarted without limit.
+func (c *Core) SetCommandRestartPolicy(name string, policy string, maxRetries int64) common.Msg {
+	exists, _ := c.CommandExists(name)
+
+	if !exists {
+		return common.NewErrMsg("Command '%s' does not exist", name)
+	}
+
+	if !isValidRestartPolicy(policy) {
+		return common.NewErrMsg("Invalid restart policy '%s', expected '%s', '%s' or '%s'", policy, RestartNever, RestartOnFailure, RestartAlways)
+	}
+
+	if maxRetries < 0 {
+		return common.NewErrMsg("Maximum number of retries can not be negative")
+	}
+
+	err := c.dbQueries.SetCommandRestartPolicy(c.dbContext, sqlc.SetCommandRestartPolicyParams{
+		RestartPolicy: policy,
+		MaxRetries:    maxRetries,
+		Name:          name,
+	})
+
+	if err != nil {
+		return common.NewErrMsg("Error updating command: %s", err)
+	}
+
+	return common.NewSuccessMsg("Set restart policy of command '%s' to '%s'", name, policy)
+}
+
+// Set the signal that is used to stop the command with the given name and how many seconds
+// to wait for the command to stop before it is killed.
+func (c *Core) SetCommandStopSignal(name string, signal string, timeout int64) common.Msg {
+	exists, _ := c.CommandExists(name)
+
+	if !exists {
+		return common.NewErrMsg("Command '%s' does not exist", name)
+	}
+
+	signal = normalizeStopSignal(signal)
+
+	if !isValidStopSignal(signal) {
+		return common.NewErrMsg("Invalid stop signal '%s', expected one of %s", signal, strings.Join(stopSignalNames(), ", "))
+	}
+
+	if timeout <= 0 {
+		return common.NewErrMsg("Grace period must be at least 1 second")
+	}
+
+	err := c.dbQueries.SetCommandStopSignal(c.dbContext, sqlc.SetCommandStopSignalParams{
+		StopSignal:  signal,
+		StopTimeout: timeout,
+		Name:        name,
+	})
+
+	if err != nil {
+		return common.NewErrMsg("Error updating command: %s", err)
+	}
+
+	return common.NewSuccessMsg("Set stop signal of command '%s' to %s with a grace period of %s", name, signal, time.Duration(timeout)*time.Second)
+}
+
+// Get the names of the commands the command with the given name depends on.
+func (c *Core) GetCommandDependencies(name string) ([]string, error) {
+	exists, command := c.CommandExists(name)
+
+	if !exists {
+		return nil, fmt.Errorf("command '%s' does not exist", name)
+	}
+
+	return c.dbQueries.GetCommandDependencies(c.dbContext, command.ID)
+}
+
+// Check if adding the given dependencies to the command with the given id would result in a dependency cycle.
+func (c *Core) hasDependencyCycle(commandID int64, dependsOnIDs []int64) (bool, error) {
+	dependencies, err := c.dbQueries.GetAllCommandDependencies(c.dbContext)
+
+	if err != nil {
+		return false, err
+	}
+
+	graph := map[int64][]int64{}
+
+	for _, dependency := range dependencies {
+		if dependency.CommandID != commandID {
+			graph[dependency.CommandID] = append(graph[dependency.CommandID], dependency.DependsOnID)
+		}
+	}
+
+	graph[commandID] = dependsOnIDs
+
+	// Walk the dependencies from the command and check if we end up at the command again
+	visited := map[int64]bool{}
+	stack := slices.Clone(dependsOnIDs)
+
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if current == commandID {
+			return true, nil
+		}
+
+		if visited[current] {
+			continue
+		}
+
+		visited[current] = true
+		stack = append(stack, graph[current]...)
+	}
+
+	return false, nil
+}
+
+// Set the commands the command with the given name depends on, replacing the existing dependencies.
+//
+// When a project is run, the command is only started once all of its dependencies
+// that are part of the same project are ready.
+func (c *Core) SetCommandDependencies(name string, dependsOn []string) common.Msg {
+	exists, command := c.CommandExists(name)
+
+	if !exists {
+		return common.NewErrMsg("Command '%s' does not exist", name)
+	}
+
+	dependsOnIDs := make([]int64, 0, len(dependsOn))
+
+	for _, dependencyName := range dependsOn {
+		if dependencyName == name {
+			return common.NewErrMsg("Command '%s' can not depend on itself", name)
+		}
+
+		exists, dependency := c.CommandExists(dependencyName)
+
+		if !exists {
+			return common.NewErrMsg("Command '%s' does not exist", dependencyName)
+		}
+
+		if !slices.Contains(dependsOnIDs, dependency.ID) {
+			dependsOnIDs = append(dependsOnIDs, dependency.ID)
+		}
+	}
+
+	hasCycle, err := c.hasDependencyCycle(command.ID, dependsOnIDs)
+
+	if err != nil {
+		return common.NewErrMsg("Error checking command dependencies: %s", err)
+	}
+
+	if hasCycle {
+		return common.NewErrMsg("Dependencies of command '%s' would result in a dependency cycle", name)
+	}
+
+	err = c.dbQueries.DeleteCommandDependencies(c.dbContext, command.ID)
+
+	if err != nil {
+		return common.NewErrMsg("Error removing command dependencies: %s", err)
+	}
+
+	for _, dependsOnID := range dependsOnIDs {
+		err = c.dbQueries.CreateCommandDependency(c.dbContext, sqlc.CreateCommandDependencyParams{
+			CommandID:   command.ID,
+			DependsOnID: dependsOnID,
+		})
+
+		if err != nil {
+			return common.NewErrMsg("Error adding command dependency: %s", err)
+		}
+	}
+
+	if len(dependsOn) == 0 {
+		return common.NewSuccessMsg("Removed dependencies of command '%s'", name)
+	}
+
+	return common.NewSuccessMsg("Command '%s' now depends on %s", name, strings.Join(dependsOn, ", "))
+}
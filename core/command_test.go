@@ -1,8 +1,11 @@
 package core
 
 import (
+	"slices"
 	"sort"
 	"testing"
+
+	"github.com/iskandervdh/spinup/common"
 )
 
 func TestGetCommandNames(t *testing.T) {
@@ -221,3 +224,313 @@ func TestRenameCommand(t *testing.T) {
 		return
 	}
 }
+
+func TestSetCommandShell(t *testing.T) {
+	c := TestingCore("set_command_shell")
+
+	c.FetchCommands()
+
+	c.AddCommand("test", "ls | wc -l")
+
+	// "Refetch" the commands config
+	c.FetchCommands()
+
+	c.SetCommandShell("test", true)
+
+	exists, command := c.CommandExists("test")
+
+	if !exists {
+		t.Error("Expected command to exist, got", exists)
+		return
+	}
+
+	if !command.Shell {
+		t.Error("Expected command to have shell mode enabled, got", command.Shell)
+		return
+	}
+
+	msg := c.SetCommandShell("does_not_exist", true)
+
+	if _, ok := msg.(*common.ErrMsg); !ok {
+		t.Error("Expected error message for unknown command, got", msg)
+	}
+}
+
+func TestSetCommandSettings(t *testing.T) {
+	c := TestingCore("set_command_settings")
+
+	c.FetchCommands()
+
+	c.AddCommand("test", "npm run dev")
+
+	// "Refetch" the commands config
+	c.FetchCommands()
+
+	msg := c.SetCommandSettings("test", CommandSettings{
+		Shell:      true,
+		Dir:        "frontend",
+		Env:        []string{"NODE_ENV=development", "PORT={{port}}"},
+		ForceColor: false,
+	})
+
+	if _, ok := msg.(*common.SuccessMsg); !ok {
+		t.Error("Expected success message, got", msg.GetText())
+		return
+	}
+
+	_, command := c.CommandExists("test")
+	settings := GetCommandSettings(command)
+
+	if !settings.Shell || settings.Dir != "frontend" || settings.ForceColor {
+		t.Error("Expected settings to be updated, got", settings)
+	}
+
+	if len(settings.Env) != 2 || settings.Env[1] != "PORT={{port}}" {
+		t.Error("Expected 2 environment variables, got", settings.Env)
+	}
+}
+
+func TestSetCommandSettingsInvalid(t *testing.T) {
+	c := TestingCore("set_command_settings_invalid")
+
+	c.FetchCommands()
+
+	c.AddCommand("test", "npm run dev")
+
+	invalidSettings := []CommandSettings{
+		{Dir: "/absolute/path"},
+		{Dir: "../outside"},
+		{Env: []string{"NOT A VARIABLE"}},
+	}
+
+	for _, settings := range invalidSettings {
+		msg := c.SetCommandSettings("test", settings)
+
+		if _, ok := msg.(*common.ErrMsg); !ok {
+			t.Error("Expected error message for settings", settings, "got", msg.GetText())
+		}
+	}
+}
+
+func TestValidateCommandSettings(t *testing.T) {
+	err := ValidateCommandSettings(CommandSettings{Dir: "web", Env: []string{"PORT=3000"}})
+
+	if err != nil {
+		t.Error("Expected valid settings, got", err)
+	}
+
+	err = ValidateCommandSettings(CommandSettings{Dir: "../outside"})
+
+	if err == nil {
+		t.Error("Expected error for directory outside of the project")
+	}
+}
+
+func TestDefaultCommandSettings(t *testing.T) {
+	c := TestingCore("default_command_settings")
+
+	c.FetchCommands()
+
+	c.AddCommand("test", "npm run dev")
+
+	_, command := c.CommandExists("test")
+	settings := GetCommandSettings(command)
+	defaultSettings := DefaultCommandSettings()
+
+	if settings.Shell != defaultSettings.Shell || settings.Dir != defaultSettings.Dir || settings.ForceColor != defaultSettings.ForceColor {
+		t.Error("Expected new command to have the default settings, got", settings)
+	}
+}
+
+func TestSetCommandDependencies(t *testing.T) {
+	c := TestingCore("set_command_dependencies")
+
+	c.FetchCommands()
+
+	c.AddCommand("database", "docker compose up")
+	c.AddCommand("backend", "npm run start")
+	c.AddCommand("frontend", "npm run dev")
+
+	// "Refetch" the commands config
+	c.FetchCommands()
+
+	msg := c.SetCommandDependencies("backend", []string{"database"})
+
+	if _, ok := msg.(*common.SuccessMsg); !ok {
+		t.Error("Expected success message, got", msg.GetText())
+		return
+	}
+
+	c.SetCommandDependencies("frontend", []string{"backend"})
+
+	dependencies, err := c.GetCommandDependencies("frontend")
+
+	if err != nil || !slices.Equal(dependencies, []string{"backend"}) {
+		t.Error("Expected frontend to depend on backend, got", dependencies, err)
+	}
+
+	msg = c.SetCommandDependencies("database", []string{"frontend"})
+
+	if _, ok := msg.(*common.ErrMsg); !ok {
+		t.Error("Expected error message for dependency cycle, got", msg.GetText())
+	}
+
+	msg = c.SetCommandDependencies("database", []string{"database"})
+
+	if _, ok := msg.(*common.ErrMsg); !ok {
+		t.Error("Expected error message for depending on itself, got", msg.GetText())
+	}
+
+	msg = c.SetCommandDependencies("database", []string{"does_not_exist"})
+
+	if _, ok := msg.(*common.ErrMsg); !ok {
+		t.Error("Expected error message for unknown dependency, got", msg.GetText())
+	}
+
+	// Removing the dependencies of the backend breaks the chain
+	c.SetCommandDependencies("backend", nil)
+
+	dependencies, _ = c.GetCommandDependencies("backend")
+
+	if len(dependencies) != 0 {
+		t.Error("Expected backend to have no dependencies, got", dependencies)
+	}
+
+	msg = c.SetCommandDependencies("database", []string{"frontend"})
+
+	if _, ok := msg.(*common.SuccessMsg); !ok {
+		t.Error("Expected success message, got", msg.GetText())
+	}
+}
+
+func TestSetCommandReadiness(t *testing.T) {
+	c := TestingCore("set_command_readiness")
+
+	c.FetchCommands()
+
+	c.AddCommand("test", "npm run dev")
+
+	// "Refetch" the commands config
+	c.FetchCommands()
+
+	msg := c.SetCommandReadiness("test", ReadyCheckHTTP, "/health")
+
+	if _, ok := msg.(*common.SuccessMsg); !ok {
+		t.Error("Expected success message, got", msg.GetText())
+		return
+	}
+
+	c.FetchCommands()
+	_, command := c.CommandExists("test")
+
+	if command.ReadyCheck != ReadyCheckHTTP || command.ReadyValue != "/health" {
+		t.Error("Expected readiness check to be updated, got", command.ReadyCheck, command.ReadyValue)
+	}
+
+	invalid := [][2]string{
+		{"unknown", ""},
+		{ReadyCheckLog, ""},
+		{ReadyCheckLog, "("},
+		{ReadyCheckDelay, "soon"},
+	}
+
+	for _, readiness := range invalid {
+		msg := c.SetCommandReadiness("test", readiness[0], readiness[1])
+
+		if _, ok := msg.(*common.ErrMsg); !ok {
+			t.Error("Expected error message for readiness check", readiness, "got", msg.GetText())
+		}
+	}
+}
+
+func TestSetCommandRestartPolicy(t *testing.T) {
+	c := TestingCore("set_command_restart_policy")
+
+	c.FetchCommands()
+
+	c.AddCommand("test", "npm run dev")
+
+	// "Refetch" the commands config
+	c.FetchCommands()
+
+	_, command := c.CommandExists("test")
+
+	if command.RestartPolicy != RestartNever {
+		t.Error("Expected default restart policy to be 'never', got", command.RestartPolicy)
+	}
+
+	msg := c.SetCommandRestartPolicy("test", RestartOnFailure, 3)
+
+	if _, ok := msg.(*common.SuccessMsg); !ok {
+		t.Error("Expected success message, got", msg.GetText())
+		return
+	}
+
+	c.FetchCommands()
+	_, command = c.CommandExists("test")
+
+	if command.RestartPolicy != RestartOnFailure || command.MaxRetries != 3 {
+		t.Error("Expected restart policy to be updated, got", command.RestartPolicy, command.MaxRetries)
+	}
+
+	msg = c.SetCommandRestartPolicy("test", "sometimes", 3)
+
+	if _, ok := msg.(*common.ErrMsg); !ok {
+		t.Error("Expected error message for invalid restart policy, got", msg.GetText())
+	}
+
+	msg = c.SetCommandRestartPolicy("test", RestartAlways, -1)
+
+	if _, ok := msg.(*common.ErrMsg); !ok {
+		t.Error("Expected error message for negative retries, got", msg.GetText())
+	}
+}
+
+func TestSetCommandStopSignal(t *testing.T) {
+	c := TestingCore("set_command_stop_signal")
+
+	c.FetchCommands()
+
+	c.AddCommand("test", "npm run dev")
+
+	// "Refetch" the commands config
+	c.FetchCommands()
+
+	_, command := c.CommandExists("test")
+
+	if command.StopSignal != "SIGTERM" || command.StopTimeout != 10 {
+		t.Error("Expected default stop signal to be SIGTERM with a grace period of 10 seconds, got", command.StopSignal, command.StopTimeout)
+	}
+
+	msg := c.SetCommandStopSignal("test", "int", 5)
+
+	if _, ok := msg.(*common.SuccessMsg); !ok {
+		t.Error("Expected success message, got", msg.GetText())
+		return
+	}
+
+	c.FetchCommands()
+	_, command = c.CommandExists("test")
+
+	if command.StopSignal != "SIGINT" || command.StopTimeout != 5 {
+		t.Error("Expected stop signal to be updated, got", command.StopSignal, command.StopTimeout)
+	}
+
+	msg = c.SetCommandStopSignal("test", "SIGUSR1", 5)
+
+	if _, ok := msg.(*common.ErrMsg); !ok {
+		t.Error("Expected error message for invalid stop signal, got", msg.GetText())
+	}
+
+	msg = c.SetCommandStopSignal("test", "SIGTERM", 0)
+
+	if _, ok := msg.(*common.ErrMsg); !ok {
+		t.Error("Expected error message for grace period of 0 seconds, got", msg.GetText())
+	}
+
+	msg = c.SetCommandStopSignal("unknown", "SIGTERM", 5)
+
+	if _, ok := msg.(*common.ErrMsg); !ok {
+		t.Error("Expected error message for unknown command, got", msg.GetText())
+	}
+}
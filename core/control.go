@@ -0,0 +1,192 @@
+package core
+
+import (
+	"github.com/iskandervdh/spinup/common"
+)
+
+// Mark the given command as done once runCommand returned for it.
+func (c *Core) finishCommand(run *projectRun, command *runningCommand) {
+	c.runMu.Lock()
+	defer c.runMu.Unlock()
+
+	command.done = true
+	run.active--
+	run.wg.Done()
+}
+
+// Wait until the given command, that was stopped by the user, is started again.
+//
+// Returns false if the project is being stopped.
+func (c *Core) waitForStart(command *runningCommand, stopping <-chan struct{}) bool {
+	select {
+	case <-command.startRequests:
+	case <-stopping:
+		return false
+	}
+
+	// Restarts requested while the command was stopped are not needed anymore, since it is started right away
+	select {
+	case <-command.restartRequests:
+	default:
+	}
+
+	return true
+}
+
+// Get the running command with the given name of the project that is being run.
+func (c *Core) getRunningCommand(name string) (*projectRun, *runningCommand, common.Msg) {
+	c.runMu.Lock()
+	defer c.runMu.Unlock()
+
+	if c.currentRun == nil {
+		return nil, nil, common.NewErrMsg("No project is running")
+	}
+
+	for _, command := range c.currentRun.commands {
+		if command.name == name {
+			return c.currentRun, command, nil
+		}
+	}
+
+	return nil, nil, common.NewErrMsg("Command '%s' is not part of project '%s'", name, c.currentRun.project.Name)
+}
+
+// Run a command again after runCommand returned for it, while the rest of the project is still running.
+func (c *Core) rerunCommand(run *projectRun, command *runningCommand) common.Msg {
+	c.runMu.Lock()
+	defer c.runMu.Unlock()
+
+	// The project is stopped once no commands are running, so it can not be run again
+	if run.active == 0 || c.currentRun != run {
+		return common.NewErrMsg("Project '%s' is stopping", run.project.Name)
+	}
+
+	if !command.done {
+		return nil
+	}
+
+	command.done = false
+	run.active++
+	run.wg.Add(1)
+
+	command.mu.Lock()
+	command.stopped = false
+	command.mu.Unlock()
+
+	go c.runCommand(run, command)
+
+	return common.NewSuccessMsg("Started command '%s'", command.name)
+}
+
+// Stop the command with the given name of the project that is being run, without stopping the other commands.
+//
+// The command is not restarted until it is started again with StartCommand or RestartCommand.
+func (c *Core) StopCommand(name string) common.Msg {
+	run, command, msg := c.getRunningCommand(name)
+
+	if msg != nil {
+		return msg
+	}
+
+	c.runMu.Lock()
+	done := command.done
+	c.runMu.Unlock()
+
+	command.mu.Lock()
+	defer command.mu.Unlock()
+
+	if done || command.stopped {
+		return common.NewErrMsg("Command '%s' of project '%s' is not running", name, run.project.Name)
+	}
+
+	command.stopped = true
+
+	// Start requests from before the command was stopped should not start it again
+	select {
+	case <-command.startRequests:
+	default:
+	}
+
+	if command.running {
+		c.stopProcess(command)
+
+		return common.NewSuccessMsg("Stopping command '%s'", name)
+	}
+
+	// Wake up the command if it is waiting to be restarted, so it waits until it is started again instead
+	select {
+	case command.restartRequests <- struct{}{}:
+	default:
+	}
+
+	command.state = CommandStopped
+
+	return common.NewSuccessMsg("Stopped command '%s'", name)
+}
+
+// Start the command with the given name of the project that is being run,
+// after it was stopped with StopCommand or after it exited.
+func (c *Core) StartCommand(name string) common.Msg {
+	run, command, msg := c.getRunningCommand(name)
+
+	if msg != nil {
+		return msg
+	}
+
+	if msg := c.rerunCommand(run, command); msg != nil {
+		return msg
+	}
+
+	command.mu.Lock()
+	defer command.mu.Unlock()
+
+	if command.stopped {
+		command.stopped = false
+
+		select {
+		case command.startRequests <- struct{}{}:
+		default:
+		}
+
+		return common.NewSuccessMsg("Started command '%s'", name)
+	}
+
+	if command.running {
+		return common.NewErrMsg("Command '%s' of project '%s' is already running", name, run.project.Name)
+	}
+
+	if command.state == CommandWaiting {
+		return common.NewErrMsg("Command '%s' of project '%s' is waiting for its dependencies", name, run.project.Name)
+	}
+
+	// The command exited and is waiting for a restart, either because of its restart policy or watched files
+	c.restartCommand(command)
+
+	return common.NewSuccessMsg("Started command '%s'", name)
+}
+
+// Restart the command with the given name of the project that is being run, without restarting the other commands.
+//
+// Commands that are not running are started.
+func (c *Core) RestartCommand(name string) common.Msg {
+	_, command, msg := c.getRunningCommand(name)
+
+	if msg != nil {
+		return msg
+	}
+
+	command.mu.Lock()
+	running := command.running && !command.stopped
+	command.mu.Unlock()
+
+	if !running {
+		return c.StartCommand(name)
+	}
+
+	command.mu.Lock()
+	defer command.mu.Unlock()
+
+	c.restartCommand(command)
+
+	return common.NewSuccessMsg("Restarting command '%s'", name)
+}
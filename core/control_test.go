@@ -0,0 +1,161 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/iskandervdh/spinup/common"
+)
+
+// Get the status of the command with the given name of the project that is being run.
+func testingCommandStatus(c *Core, name string) CommandStatus {
+	status, _ := c.GetStatus()
+
+	for _, command := range status.Commands {
+		if command.Command == name {
+			return command
+		}
+	}
+
+	return CommandStatus{}
+}
+
+func TestStopStartRestartCommand(t *testing.T) {
+	c := TestingCore("stop_start_restart_command")
+
+	c.FetchCommands()
+	c.FetchProjects()
+
+	c.AddCommand("frontend", "sleep 10")
+	c.AddCommand("backend", "sleep 10")
+
+	c.AddProject("test", 1234, []string{"frontend", "backend"})
+
+	// "Refetch" the projects from the config file
+	c.FetchProjects()
+
+	if _, ok := c.StopCommand("frontend").(*common.ErrMsg); !ok {
+		t.Error("Expected error when stopping a command while no project is running")
+	}
+
+	// Create the signal channel before running, so the test can stop the project
+	sigChan := make(chan os.Signal, 1)
+	c.sigChan = &sigChan
+
+	done := make(chan struct{})
+
+	go func() {
+		c.TryToRun("test")
+		close(done)
+	}()
+
+	time.Sleep(500 * time.Millisecond)
+
+	backendPID := testingCommandStatus(c, "backend").PID
+
+	if msg, ok := c.StopCommand("frontend").(*common.ErrMsg); ok {
+		t.Error("Expected command to stop, got", msg.GetText())
+	}
+
+	time.Sleep(500 * time.Millisecond)
+
+	if status := testingCommandStatus(c, "frontend"); status.State != CommandStopped {
+		t.Error("Expected command 'frontend' to be stopped, got", status.State)
+	}
+
+	if status := testingCommandStatus(c, "backend"); status.State != CommandRunning || status.PID != backendPID {
+		t.Error("Expected command 'backend' to keep running, got", status)
+	}
+
+	if _, ok := c.StopCommand("frontend").(*common.ErrMsg); !ok {
+		t.Error("Expected error when stopping a command that is already stopped")
+	}
+
+	if _, ok := c.StopCommand("unknown").(*common.ErrMsg); !ok {
+		t.Error("Expected error when stopping a command that is not part of the project")
+	}
+
+	if msg, ok := c.StartCommand("frontend").(*common.ErrMsg); ok {
+		t.Error("Expected command to start, got", msg.GetText())
+	}
+
+	time.Sleep(500 * time.Millisecond)
+
+	if status := testingCommandStatus(c, "frontend"); status.State != CommandRunning || status.Restarts != 1 {
+		t.Error("Expected command 'frontend' to be running again, got", status)
+	}
+
+	if _, ok := c.StartCommand("frontend").(*common.ErrMsg); !ok {
+		t.Error("Expected error when starting a command that is already running")
+	}
+
+	if msg, ok := c.RestartCommand("backend").(*common.ErrMsg); ok {
+		t.Error("Expected command to restart, got", msg.GetText())
+	}
+
+	time.Sleep(500 * time.Millisecond)
+
+	if status := testingCommandStatus(c, "backend"); status.State != CommandRunning || status.PID == backendPID {
+		t.Error("Expected command 'backend' to be restarted, got", status)
+	}
+
+	sigChan <- os.Interrupt
+	<-done
+}
+
+func TestStartExitedCommand(t *testing.T) {
+	c := TestingCore("start_exited_command")
+
+	c.FetchCommands()
+	c.FetchProjects()
+
+	output := filepath.Join(TestingConfigDir("start_exited_command"), "output.txt")
+
+	c.AddCommand("once", "echo started >> "+output)
+	c.SetCommandShell("once", true)
+	c.AddCommand("server", "sleep 10")
+
+	c.AddProject("test", 1234, []string{"once", "server"})
+
+	// "Refetch" the projects from the config file
+	c.FetchProjects()
+
+	// Create the signal channel before running, so the test can stop the project
+	sigChan := make(chan os.Signal, 1)
+	c.sigChan = &sigChan
+
+	done := make(chan struct{})
+
+	go func() {
+		c.TryToRun("test")
+		close(done)
+	}()
+
+	time.Sleep(500 * time.Millisecond)
+
+	if status := testingCommandStatus(c, "once"); status.State != CommandExited {
+		t.Error("Expected command 'once' to have exited, got", status.State)
+	}
+
+	if msg, ok := c.StartCommand("once").(*common.ErrMsg); ok {
+		t.Error("Expected exited command to start, got", msg.GetText())
+	}
+
+	time.Sleep(500 * time.Millisecond)
+
+	sigChan <- os.Interrupt
+	<-done
+
+	contents, err := os.ReadFile(output)
+
+	if err != nil {
+		t.Error("Expected output file to exist, got", err)
+		return
+	}
+
+	if string(contents) != "started\nstarted\n" {
+		t.Errorf("Expected command to be started again, got %q", contents)
+	}
+}
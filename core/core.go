@@ -7,6 +7,8 @@ import (
 	"io"
 	"os"
 	"slices"
+	"sync"
+	"sync/atomic"
 
 	_ "github.com/golang-migrate/migrate/v4/source/file"
 	"github.com/iskandervdh/spinup/common"
@@ -29,11 +31,24 @@ type Core struct {
 	out io.Writer
 	err io.Writer
 
+	db        *sql.DB
 	dbQueries *sqlc.Queries
 	dbContext context.Context
 
 	commands Commands
 	projects Projects
+
+	// The project that is being run, nil if no project is running
+	runMu      sync.Mutex
+	currentRun *projectRun
+
+	// Log files of the project that is being run, nil if no project is running
+	logs atomic.Pointer[projectLogs]
+
+	// Whether the output of the commands is prefixed with the name of their project and the index of
+	// the color of the first command, used when running multiple projects at once
+	prefixProject     bool
+	prefixColorOffset int
 }
 
 func (c *Core) connectToDB() (*sql.DB, error) {
@@ -94,6 +109,7 @@ func New(options ...func(*Core)) *Core {
 		os.Exit(1)
 	}
 
+	c.db = db
 	c.dbQueries = sqlc.New(db)
 	err = database.MigrateDatabase(db)
 
@@ -119,6 +135,15 @@ func WithMsgChan(msgChan *chan common.Msg) func(*Core) {
 	}
 }
 
+// Optional function to set the signal channel of the Core when creating a new instance.
+//
+// Sending a signal to the channel gracefully stops the project that is being run.
+func WithSigChan(sigChan *chan os.Signal) func(*Core) {
+	return func(c *Core) {
+		c.sigChan = sigChan
+	}
+}
+
 func (c *Core) SetOut(out io.Writer) {
 	c.out = out
 }
@@ -188,5 +213,7 @@ func (c *Core) getCommandsForProject(projectName string) []Command {
 
 // Send a message to the message channel.
 func (c *Core) sendMsg(msg common.Msg) {
+	c.logs.Load().writeMsg(msg)
+
 	*c.msgChan <- msg
 }
@@ -14,6 +14,11 @@ func TestingConfigDir(testName string) string {
 }
 
 func TestingCore(testName string) *Core {
+	return testingCoreWithMsgHandler(testName, func(common.Msg) {})
+}
+
+// Create a core for testing that calls the given function with every message it sends.
+func testingCoreWithMsgHandler(testName string, handleMsg func(common.Msg)) *Core {
 	// Remove old tmp config dir
 	testingConfigDir := TestingConfigDir(testName)
 	err := os.RemoveAll(testingConfigDir)
@@ -31,7 +36,7 @@ func TestingCore(testName string) *Core {
 
 	go func() {
 		for {
-			<-(*c.msgChan)
+			handleMsg(<-(*c.msgChan))
 		}
 	}()
 
@@ -0,0 +1,272 @@
+package core
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/iskandervdh/spinup/common"
+	"github.com/iskandervdh/spinup/database"
+	"github.com/mattn/go-sqlite3"
+)
+
+// Time format used in the names of backup files, so they sort by the time they were made.
+const backupTimeFormat = "20060102-150405.000"
+
+// Maximum time to wait for other connections to release their locks on a database while copying it.
+const backupTimeout = 10 * time.Second
+
+// Delay before retrying a step of copying a database when it is locked.
+const backupRetryInterval = 50 * time.Millisecond
+
+// Get the path of a new backup file in the backups directory.
+func (c *Core) newBackupPath() string {
+	return filepath.Join(c.config.GetBackupsDir(), fmt.Sprintf("%s-%s.sqlite3", common.ProgramName, time.Now().Format(backupTimeFormat)))
+}
+
+// Open a new in-memory database. It is limited to a single connection, since every connection
+// to an in-memory database has its own database.
+func openMemoryDatabase() (*sql.DB, error) {
+	db, err := sql.Open("sqlite3", ":memory:")
+
+	if err != nil {
+		return nil, err
+	}
+
+	db.SetMaxOpenConns(1)
+
+	return db, nil
+}
+
+// Copy the contents of the source database into the destination database, replacing its contents.
+//
+// The online backup API of SQLite is used, so the databases can safely be used by other connections
+// and processes, like the app or the daemon, while they are copied.
+func (c *Core) copyDatabase(dst *sql.DB, src *sql.DB) error {
+	dstConn, err := dst.Conn(c.dbContext)
+
+	if err != nil {
+		return err
+	}
+
+	defer dstConn.Close()
+
+	srcConn, err := src.Conn(c.dbContext)
+
+	if err != nil {
+		return err
+	}
+
+	defer srcConn.Close()
+
+	return dstConn.Raw(func(dstDriverConn any) error {
+		return srcConn.Raw(func(srcDriverConn any) error {
+			dstSQLiteConn, ok := dstDriverConn.(*sqlite3.SQLiteConn)
+
+			if !ok {
+				return fmt.Errorf("destination is not a sqlite3 database")
+			}
+
+			srcSQLiteConn, ok := srcDriverConn.(*sqlite3.SQLiteConn)
+
+			if !ok {
+				return fmt.Errorf("source is not a sqlite3 database")
+			}
+
+			backup, err := dstSQLiteConn.Backup("main", srcSQLiteConn, "main")
+
+			if err != nil {
+				return err
+			}
+
+			deadline := time.Now().Add(backupTimeout)
+
+			for {
+				done, err := backup.Step(-1)
+
+				if err != nil {
+					backup.Close()
+					return err
+				}
+
+				if done {
+					return backup.Finish()
+				}
+
+				// The database is locked by another connection, try again until the lock is released
+				if time.Now().After(deadline) {
+					backup.Close()
+					return fmt.Errorf("database is locked")
+				}
+
+				time.Sleep(backupRetryInterval)
+			}
+		})
+	})
+}
+
+// Read the database file at the given path into a migrated in-memory database,
+// checking that it is an intact spinup database.
+func (c *Core) readDatabaseFile(path string) (*sql.DB, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, err
+	}
+
+	file, err := sql.Open("sqlite3", "file:"+path+"?mode=ro")
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+
+	var integrity string
+
+	err = file.QueryRowContext(c.dbContext, "PRAGMA integrity_check").Scan(&integrity)
+
+	if err != nil {
+		return nil, fmt.Errorf("not a valid database: %s", err)
+	}
+
+	if integrity != "ok" {
+		return nil, fmt.Errorf("database is corrupted: %s", integrity)
+	}
+
+	var tables int
+
+	err = file.QueryRowContext(c.dbContext, "SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name IN ('commands', 'projects')").Scan(&tables)
+
+	if err != nil || tables != 2 {
+		return nil, fmt.Errorf("not a %s database", common.ProgramName)
+	}
+
+	db, err := openMemoryDatabase()
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.copyDatabase(db, file)
+
+	if err == nil {
+		// Bring backups made by older versions up to date
+		err = database.MigrateDatabase(db)
+	}
+
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// Replace the contents of the database with the given database and regenerate the reverse proxy configuration files.
+//
+// A backup of the current database is made first, its path is returned.
+func (c *Core) replaceDatabase(db *sql.DB) (string, error) {
+	backupPath := c.newBackupPath()
+
+	if msg, ok := c.BackupDatabase(backupPath).(*common.ErrMsg); ok {
+		return "", fmt.Errorf("%s", msg.GetText())
+	}
+
+	err := c.copyDatabase(c.db, db)
+
+	if err != nil {
+		return backupPath, err
+	}
+
+	err = c.FetchCommands()
+
+	if err != nil {
+		return backupPath, err
+	}
+
+	// The generated configuration files of the previous projects that no longer exist are removed as orphans
+	_, err = c.syncProxyConfigs(true)
+
+	return backupPath, err
+}
+
+// Back up the database to the file at the given path, or to a new file in the backups directory if no path is given.
+func (c *Core) BackupDatabase(path string) common.Msg {
+	if path == "" {
+		path = c.newBackupPath()
+	}
+
+	if _, err := os.Stat(path); err == nil {
+		return common.NewErrMsg("File '%s' already exists", path)
+	}
+
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+
+	if err != nil {
+		return common.NewErrMsg("Error creating backup directory: %s", err)
+	}
+
+	backup, err := sql.Open("sqlite3", path)
+
+	if err != nil {
+		return common.NewErrMsg("Error creating backup file: %s", err)
+	}
+
+	defer backup.Close()
+
+	err = c.copyDatabase(backup, c.db)
+
+	if err != nil {
+		os.Remove(path)
+		return common.NewErrMsg("Error backing up database: %s", err)
+	}
+
+	return common.NewSuccessMsg("Backed up database to %s", path)
+}
+
+// Restore the database from the backup file at the given path and regenerate the reverse proxy configuration files.
+//
+// The current database is backed up first, so the restore can be undone.
+func (c *Core) RestoreDatabase(path string) common.Msg {
+	db, err := c.readDatabaseFile(path)
+
+	if err != nil {
+		return common.NewErrMsg("Error reading backup '%s': %s", path, err)
+	}
+
+	defer db.Close()
+
+	backupPath, err := c.replaceDatabase(db)
+
+	if err != nil {
+		return common.NewErrMsg("Error restoring database: %s", err)
+	}
+
+	return common.NewSuccessMsg("Restored database from %s, the previous database was backed up to %s", path, backupPath)
+}
+
+// Remove all commands, projects and groups from the database and remove their reverse proxy configuration files.
+//
+// The current database is backed up first, so the reset can be undone.
+func (c *Core) ResetDatabase() common.Msg {
+	db, err := openMemoryDatabase()
+
+	if err == nil {
+		err = database.MigrateDatabase(db)
+	}
+
+	if err != nil {
+		return common.NewErrMsg("Error creating empty database: %s", err)
+	}
+
+	defer db.Close()
+
+	backupPath, err := c.replaceDatabase(db)
+
+	if err != nil {
+		return common.NewErrMsg("Error resetting database: %s", err)
+	}
+
+	return common.NewSuccessMsg("Reset database, the previous database was backed up to %s", backupPath)
+}
@@ -0,0 +1,133 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/iskandervdh/spinup/common"
+)
+
+func TestBackupDatabase(t *testing.T) {
+	c := TestingCore("backup_database")
+
+	c.FetchCommands()
+	c.FetchProjects()
+
+	c.AddProject("example", 1234, []string{})
+
+	path := filepath.Join(t.TempDir(), "backup.sqlite3")
+	msg := c.BackupDatabase(path)
+
+	if _, ok := msg.(*common.SuccessMsg); !ok {
+		t.Error("Expected database to be backed up, got", msg.GetText())
+		return
+	}
+
+	if _, err := os.Stat(path); err != nil {
+		t.Error("Expected backup file to exist, got", err)
+	}
+
+	if msg := c.BackupDatabase(path); msg.GetText() != "File '"+path+"' already exists" {
+		t.Error("Expected error when backing up to an existing file, got", msg.GetText())
+	}
+
+	msg = c.BackupDatabase("")
+
+	if _, ok := msg.(*common.SuccessMsg); !ok || !strings.Contains(msg.GetText(), c.config.GetBackupsDir()) {
+		t.Error("Expected database to be backed up to the backups directory, got", msg.GetText())
+	}
+}
+
+func TestRestoreDatabase(t *testing.T) {
+	c := TestingCore("restore_database")
+
+	c.FetchCommands()
+	c.FetchProjects()
+
+	c.AddProject("example", 1234, []string{})
+	c.FetchProjects()
+	c.AddDomainAlias("example", "example.localhost")
+
+	path := filepath.Join(t.TempDir(), "backup.sqlite3")
+	c.BackupDatabase(path)
+
+	c.RemoveProject("example")
+	c.AddProject("other", 1235, []string{})
+
+	msg := c.RestoreDatabase(path)
+
+	if _, ok := msg.(*common.SuccessMsg); !ok {
+		t.Error("Expected database to be restored, got", msg.GetText())
+		return
+	}
+
+	if exists, _ := c.ProjectExists("example"); !exists {
+		t.Error("Expected project of the backup to exist after restoring")
+	}
+
+	if exists, _ := c.ProjectExists("other"); exists {
+		t.Error("Expected project that was added after the backup not to exist after restoring")
+	}
+
+	nginxConfig, err := os.ReadFile(filepath.Join(c.config.GetNginxConfigDir(), "example.conf"))
+
+	if err != nil || !strings.Contains(string(nginxConfig), "server_name example.test example.localhost;") {
+		t.Error("Expected nginx config of the restored project to be regenerated, got", string(nginxConfig), err)
+	}
+
+	if _, err := os.Stat(filepath.Join(c.config.GetNginxConfigDir(), "other.conf")); !os.IsNotExist(err) {
+		t.Error("Expected nginx config of the project that no longer exists to be removed")
+	}
+
+	invalidPath := filepath.Join(t.TempDir(), "invalid.sqlite3")
+	os.WriteFile(invalidPath, []byte("not a database"), 0644)
+
+	if _, ok := c.RestoreDatabase(invalidPath).(*common.ErrMsg); !ok {
+		t.Error("Expected error when restoring from a file that is not a database")
+	}
+
+	if exists, _ := c.ProjectExists("example"); !exists {
+		t.Error("Expected database to be unchanged after a failed restore")
+	}
+}
+
+func TestResetDatabase(t *testing.T) {
+	c := TestingCore("reset_database")
+
+	c.FetchCommands()
+	c.FetchProjects()
+
+	c.AddCommand("server", "sleep 10")
+	c.AddProject("example", 1234, []string{"server"})
+
+	msg := c.ResetDatabase()
+
+	if _, ok := msg.(*common.SuccessMsg); !ok {
+		t.Error("Expected database to be reset, got", msg.GetText())
+		return
+	}
+
+	if len(c.GetProjectNames()) != 0 || len(c.GetCommandNames()) != 0 {
+		t.Error("Expected no projects and commands after resetting")
+	}
+
+	if _, err := os.Stat(filepath.Join(c.config.GetNginxConfigDir(), "example.conf")); !os.IsNotExist(err) {
+		t.Error("Expected nginx config of the removed project to be removed")
+	}
+
+	backups, _ := os.ReadDir(c.config.GetBackupsDir())
+
+	if len(backups) != 1 {
+		t.Error("Expected a backup to be made before resetting, got", len(backups))
+		return
+	}
+
+	// The reset can be undone by restoring the backup
+	c.RestoreDatabase(filepath.Join(c.config.GetBackupsDir(), backups[0].Name()))
+
+	if exists, _ := c.ProjectExists("example"); !exists {
+		t.Error("Expected project to exist after restoring the backup made before resetting")
+	}
+}
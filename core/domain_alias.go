@@ -2,6 +2,7 @@ package core
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/iskandervdh/spinup/common"
 	"github.com/iskandervdh/spinup/database/sqlc"
@@ -21,14 +22,22 @@ func (c *Core) AddDomainAlias(projectName string, domainAlias string) common.Msg
 		return common.NewErrMsg("Project '%s' does not exist", projectName)
 	}
 
+	domainAlias = strings.ToLower(domainAlias)
+
+	err := c.config.ValidateDomainAlias(domainAlias)
+
+	if err != nil {
+		return common.NewErrMsg("Invalid domain alias: %s", err)
+	}
+
 	// Check if the domain alias is already defined as the domain of the project
-	if common.GetDomain(project.Name) == domainAlias {
+	if c.config.GetDomain(project.Name) == domainAlias {
 		return common.NewErrMsg("Domain alias '%s' is already the domain of project '%s'", domainAlias, projectName)
 	}
 
 	for projectName, project := range c.projects {
 		// Check if the domain alias is the domain of another project
-		if common.GetDomain(project.Name) == domainAlias {
+		if c.config.GetDomain(project.Name) == domainAlias {
 			return common.NewErrMsg("Domain alias '%s' is already the domain of project '%s'", domainAlias, projectName)
 		}
 
@@ -40,10 +49,16 @@ func (c *Core) AddDomainAlias(projectName string, domainAlias string) common.Msg
 		}
 	}
 
-	err := c.config.NginxAddDomainAlias(projectName, domainAlias)
+	proxy, err := c.reverseProxy()
 
 	if err != nil {
-		return common.NewErrMsg(fmt.Sprintln("Error trying to add domain alias to nginx config file", err))
+		return common.NewErrMsg("%s", err)
+	}
+
+	err = proxy.AddDomainAlias(projectName, domainAlias)
+
+	if err != nil {
+		return common.NewErrMsg(fmt.Sprintln("Error trying to add domain alias to "+proxy.Name()+" config file", err))
 	}
 
 	err = c.dbQueries.CreateDomainAlias(c.dbContext, sqlc.CreateDomainAliasParams{
@@ -55,6 +70,12 @@ func (c *Core) AddDomainAlias(projectName string, domainAlias string) common.Msg
 		return common.NewErrMsg("Error adding domain alias to database: %s", err)
 	}
 
+	err = c.updateCertificate(projectName)
+
+	if err != nil {
+		return common.NewErrMsg("Error updating certificate: %s", err)
+	}
+
 	return common.NewSuccessMsg("Added domain alias '%s' to project '%s'", domainAlias, projectName)
 }
 
@@ -70,10 +91,16 @@ func (c *Core) RemoveDomainAlias(projectName string, domainAlias string) common.
 		return common.NewErrMsg("Project '%s' does not exist", projectName)
 	}
 
-	err := c.config.NginxRemoveDomainAlias(projectName, domainAlias)
+	proxy, err := c.reverseProxy()
+
+	if err != nil {
+		return common.NewErrMsg("%s", err)
+	}
+
+	err = proxy.RemoveDomainAlias(projectName, domainAlias)
 
 	if err != nil {
-		return common.NewErrMsg(fmt.Sprintln("Error trying to remove domain alias from nginx config file", err))
+		return common.NewErrMsg(fmt.Sprintln("Error trying to remove domain alias from "+proxy.Name()+" config file", err))
 	}
 
 	for i, alias := range project.DomainAliases {
@@ -90,6 +117,12 @@ func (c *Core) RemoveDomainAlias(projectName string, domainAlias string) common.
 				return common.NewErrMsg("Error removing domain alias from database: %s", err)
 			}
 
+			err = c.updateCertificate(projectName)
+
+			if err != nil {
+				return common.NewErrMsg("Error updating certificate: %s", err)
+			}
+
 			return common.NewSuccessMsg("Removed domain alias '%s' from project '%s'", domainAlias, projectName)
 		}
 	}
@@ -99,3 +99,35 @@ func TestRemoveDomainAlias(t *testing.T) {
 		t.Error("Expected domain alias to be 'tst.test.test', got", project.DomainAliases[0])
 	}
 }
+
+func TestAddDomainAliasInvalid(t *testing.T) {
+	c := TestingCore("add_domain_alias_invalid")
+
+	c.FetchCommands()
+	c.FetchProjects()
+
+	c.AddProject("test", 1234, []string{})
+	c.FetchProjects()
+
+	for _, domainAlias := range []string{"api.example.com", "api.local", "api..test", "-api.test"} {
+		msg := c.AddDomainAlias("test", domainAlias)
+
+		if _, ok := msg.(*common.ErrMsg); !ok {
+			t.Errorf("Expected adding domain alias '%s' to fail, got: %s", domainAlias, msg.GetText())
+		}
+	}
+
+	msg := c.AddDomainAlias("test", "API.localhost")
+
+	if _, ok := msg.(*common.ErrMsg); ok {
+		t.Error("Expected to add domain alias under localhost, got:", msg.GetText())
+		return
+	}
+
+	c.FetchProjects()
+	_, project := c.ProjectExists("test")
+
+	if len(project.DomainAliases) != 1 || project.DomainAliases[0].Value != "api.localhost" {
+		t.Error("Expected only the lowercased domain alias 'api.localhost', got", project.DomainAliases)
+	}
+}
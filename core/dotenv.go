@@ -0,0 +1,269 @@
+package core
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Parse the dotenv formatted contents of the given reader into a list of KEY=VALUE environment variables.
+//
+// Lines can optionally start with `export`, comments start with `#` and values can be
+// unquoted, single quoted (taken literally) or double quoted (supporting escape sequences).
+// `${VAR}`, `${VAR:-default}` and `$VAR` references in unquoted and double quoted values
+// are interpolated using the variables defined earlier and the given lookup function.
+func parseDotenv(reader io.Reader, lookup func(string) (string, bool)) ([]string, error) {
+	var env []string
+	defined := map[string]string{}
+
+	resolve := func(name string) (string, bool) {
+		if value, ok := defined[name]; ok {
+			return value, true
+		}
+
+		return lookup(name)
+	}
+
+	scanner := bufio.NewScanner(reader)
+	lineNumber := 0
+
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		line = strings.TrimPrefix(line, "export ")
+
+		key, rawValue, found := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+
+		if !found || !envVariableNameRegex.MatchString(key) {
+			return nil, fmt.Errorf("line %d: expected KEY=VALUE, got '%s'", lineNumber, line)
+		}
+
+		rawValue = strings.TrimSpace(rawValue)
+
+		// Quoted values can span multiple lines
+		for len(rawValue) > 0 && (rawValue[0] == '"' || rawValue[0] == '\'') && !hasClosingQuote(rawValue) {
+			if !scanner.Scan() {
+				return nil, fmt.Errorf("line %d: unterminated quoted value for '%s'", lineNumber, key)
+			}
+
+			lineNumber++
+			rawValue += "\n" + scanner.Text()
+		}
+
+		value, err := parseDotenvValue(rawValue, resolve)
+
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %s", lineNumber, err)
+		}
+
+		defined[key] = value
+		env = append(env, key+"="+value)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading dotenv file: %s", err)
+	}
+
+	return env, nil
+}
+
+// Check if the given quoted value contains its closing quote.
+func hasClosingQuote(value string) bool {
+	quote := value[0]
+
+	for i := 1; i < len(value); i++ {
+		if quote == '"' && value[i] == '\\' {
+			i++
+			continue
+		}
+
+		if value[i] == quote {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Parse a single (possibly quoted) dotenv value.
+func parseDotenvValue(rawValue string, resolve func(string) (string, bool)) (string, error) {
+	if rawValue == "" {
+		return "", nil
+	}
+
+	switch rawValue[0] {
+	case '\'':
+		end := strings.IndexByte(rawValue[1:], '\'')
+
+		if end == -1 {
+			return "", fmt.Errorf("unterminated single quote")
+		}
+
+		return rawValue[1 : end+1], nil
+	case '"':
+		var value strings.Builder
+
+		for i := 1; i < len(rawValue); i++ {
+			switch rawValue[i] {
+			case '"':
+				return interpolate(value.String(), resolve)
+			case '\\':
+				i++
+
+				if i >= len(rawValue) {
+					return "", fmt.Errorf("unterminated double quote")
+				}
+
+				switch rawValue[i] {
+				case 'n':
+					value.WriteByte('\n')
+				case 't':
+					value.WriteByte('\t')
+				case '$':
+					// Keep escaped dollar signs escaped so they are not interpolated
+					value.WriteString("\\$")
+				default:
+					value.WriteByte(rawValue[i])
+				}
+			default:
+				value.WriteByte(rawValue[i])
+			}
+		}
+
+		return "", fmt.Errorf("unterminated double quote")
+	}
+
+	// Strip inline comments from unquoted values
+	if index := strings.Index(rawValue, " #"); index != -1 {
+		rawValue = strings.TrimSpace(rawValue[:index])
+	}
+
+	return interpolate(rawValue, resolve)
+}
+
+// Replace `${VAR}`, `${VAR:-default}` and `$VAR` references in the given value.
+//
+// Unknown variables are replaced with an empty string and `\$` results in a literal dollar sign.
+func interpolate(value string, resolve func(string) (string, bool)) (string, error) {
+	var result strings.Builder
+
+	for i := 0; i < len(value); i++ {
+		if value[i] == '\\' && i+1 < len(value) && value[i+1] == '$' {
+			result.WriteByte('$')
+			i++
+			continue
+		}
+
+		if value[i] != '$' || i+1 >= len(value) {
+			result.WriteByte(value[i])
+			continue
+		}
+
+		if value[i+1] == '{' {
+			end := strings.IndexByte(value[i+2:], '}')
+
+			if end == -1 {
+				return "", fmt.Errorf("unterminated variable reference in '%s'", value)
+			}
+
+			reference := value[i+2 : i+2+end]
+			name, defaultValue, hasDefault := strings.Cut(reference, ":-")
+
+			if !envVariableNameRegex.MatchString(name) {
+				return "", fmt.Errorf("invalid variable reference '${%s}'", reference)
+			}
+
+			resolved, ok := resolve(name)
+
+			if (!ok || resolved == "") && hasDefault {
+				resolved = defaultValue
+			}
+
+			result.WriteString(resolved)
+			i += 2 + end
+
+			continue
+		}
+
+		end := i + 1
+
+		for end < len(value) && (value[end] == '_' || isAlphaNumeric(value[end])) {
+			end++
+		}
+
+		if end == i+1 {
+			result.WriteByte('$')
+			continue
+		}
+
+		resolved, _ := resolve(value[i+1 : end])
+		result.WriteString(resolved)
+		i = end - 1
+	}
+
+	return result.String(), nil
+}
+
+func isAlphaNumeric(b byte) bool {
+	return (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z') || (b >= '0' && b <= '9')
+}
+
+// Load the environment variables of all dotenv files of the given project.
+//
+// Files are loaded in order, so variables of later files take precedence over earlier ones.
+// Relative paths are resolved from the directory of the project and files that do not exist are skipped.
+func (c *Core) loadEnvFiles(project Project) ([]string, error) {
+	var env []string
+	loaded := map[string]string{}
+
+	lookup := func(name string) (string, bool) {
+		if value, ok := loaded[name]; ok {
+			return value, true
+		}
+
+		return os.LookupEnv(name)
+	}
+
+	for _, envFile := range project.EnvFiles {
+		envFilePath := envFile.Path
+
+		if !filepath.IsAbs(envFilePath) {
+			envFilePath = filepath.Join(project.Dir.String, envFilePath)
+		}
+
+		file, err := os.Open(envFilePath)
+
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+
+			return nil, fmt.Errorf("error opening '%s': %s", envFile.Path, err)
+		}
+
+		fileEnv, err := parseDotenv(file, lookup)
+		file.Close()
+
+		if err != nil {
+			return nil, fmt.Errorf("error parsing '%s': %s", envFile.Path, err)
+		}
+
+		for _, variable := range fileEnv {
+			key, value, _ := strings.Cut(variable, "=")
+			loaded[key] = value
+		}
+
+		env = append(env, fileEnv...)
+	}
+
+	return env, nil
+}
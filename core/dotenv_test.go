@@ -0,0 +1,151 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/iskandervdh/spinup/common"
+)
+
+func noLookup(string) (string, bool) {
+	return "", false
+}
+
+func TestParseDotenv(t *testing.T) {
+	contents := `# Comment
+APP_NAME=spinup
+export APP_ENV=local
+EMPTY=
+UNQUOTED=hello world # inline comment
+SINGLE='${APP_NAME} stays literal'
+DOUBLE="line1\nline2"
+URL=http://${HOST:-localhost}:${PORT}
+APP_URL="https://$APP_NAME.test"
+ESCAPED="costs \$5"
+MULTILINE="first
+second"
+`
+
+	env, err := parseDotenv(strings.NewReader(contents), func(name string) (string, bool) {
+		if name == "PORT" {
+			return "8000", true
+		}
+
+		return "", false
+	})
+
+	if err != nil {
+		t.Error("Expected no error, got", err)
+		return
+	}
+
+	expected := []string{
+		"APP_NAME=spinup",
+		"APP_ENV=local",
+		"EMPTY=",
+		"UNQUOTED=hello world",
+		"SINGLE=${APP_NAME} stays literal",
+		"DOUBLE=line1\nline2",
+		"URL=http://localhost:8000",
+		"APP_URL=https://spinup.test",
+		"ESCAPED=costs $5",
+		"MULTILINE=first\nsecond",
+	}
+
+	if !slices.Equal(env, expected) {
+		t.Errorf("Expected %q, got %q", expected, env)
+	}
+}
+
+func TestParseDotenvErrors(t *testing.T) {
+	contents := []string{
+		"NOT A VARIABLE",
+		"KEY=\"unterminated",
+		"KEY='unterminated",
+		"KEY=${UNTERMINATED",
+		"KEY=${INVALID-NAME}",
+	}
+
+	for _, content := range contents {
+		_, err := parseDotenv(strings.NewReader(content), noLookup)
+
+		if err == nil {
+			t.Errorf("Expected error for %q, got nil", content)
+		}
+	}
+}
+
+func TestLoadEnvFiles(t *testing.T) {
+	c := TestingCore("load_env_files")
+
+	projectDir := filepath.Join(TestingConfigDir("load_env_files"), "project")
+	os.MkdirAll(projectDir, 0755)
+
+	os.WriteFile(filepath.Join(projectDir, ".env"), []byte("A=1\nB=2\n"), 0644)
+	os.WriteFile(filepath.Join(projectDir, ".env.local"), []byte("B=3\nC=${A}${B}\n"), 0644)
+
+	c.FetchProjects()
+	c.AddProject("test", 8000, []string{})
+	c.FetchProjects()
+
+	c.SetProjectDir("test", &projectDir)
+
+	c.AddEnvFile("test", ".env")
+	c.AddEnvFile("test", ".env.local")
+	c.AddEnvFile("test", ".env.missing")
+
+	c.FetchProjects()
+
+	msg := c.AddEnvFile("test", ".env")
+
+	if _, ok := msg.(*common.ErrMsg); !ok {
+		t.Error("Expected error message for duplicate env file, got", msg.GetText())
+	}
+
+	_, project := c.ProjectExists("test")
+
+	if len(project.EnvFiles) != 3 {
+		t.Error("Expected 3 env files, got", len(project.EnvFiles))
+	}
+
+	env, err := c.loadEnvFiles(project)
+
+	if err != nil {
+		t.Error("Expected no error, got", err)
+		return
+	}
+
+	expected := []string{"A=1", "B=2", "B=3", "C=13"}
+
+	if !slices.Equal(env, expected) {
+		t.Errorf("Expected %q, got %q", expected, env)
+	}
+}
+
+func TestRunEnvFileParseError(t *testing.T) {
+	c := TestingCore("run_env_file_parse_error")
+
+	projectDir := filepath.Join(TestingConfigDir("run_env_file_parse_error"), "project")
+	os.MkdirAll(projectDir, 0755)
+	os.WriteFile(filepath.Join(projectDir, ".env"), []byte("INVALID LINE\n"), 0644)
+
+	c.FetchCommands()
+	c.FetchProjects()
+
+	c.AddCommand("ls", "ls")
+	c.AddProject("test", 8000, []string{"ls"})
+	c.FetchProjects()
+
+	c.SetProjectDir("test", &projectDir)
+	c.AddEnvFile("test", ".env")
+	c.FetchProjects()
+
+	msg := c.TryToRun("test")
+
+	if _, ok := msg.(*common.ErrMsg); !ok {
+		t.Error("Expected error message, got", msg)
+	}
+}
@@ -0,0 +1,70 @@
+package core
+
+import (
+	"github.com/iskandervdh/spinup/common"
+	"github.com/iskandervdh/spinup/database/sqlc"
+)
+
+type EnvFile = sqlc.EnvFile
+
+// Add a dotenv file to the project with the given name.
+//
+// The path can be relative to the directory of the project. Files added later take precedence over earlier ones.
+func (c *Core) AddEnvFile(projectName string, path string) common.Msg {
+	if c.projects == nil {
+		return common.NewErrMsg("No projects found")
+	}
+
+	exists, project := c.ProjectExists(projectName)
+
+	if !exists {
+		return common.NewErrMsg("Project '%s' does not exist", projectName)
+	}
+
+	for _, envFile := range project.EnvFiles {
+		if envFile.Path == path {
+			return common.NewErrMsg("Env file '%s' already exists on project '%s'", path, projectName)
+		}
+	}
+
+	err := c.dbQueries.CreateEnvFile(c.dbContext, sqlc.CreateEnvFileParams{
+		Path:      path,
+		ProjectID: project.ID,
+	})
+
+	if err != nil {
+		return common.NewErrMsg("Error adding env file to database: %s", err)
+	}
+
+	return common.NewSuccessMsg("Added env file '%s' to project '%s'", path, projectName)
+}
+
+// Remove a dotenv file from the project with the given name.
+func (c *Core) RemoveEnvFile(projectName string, path string) common.Msg {
+	if c.projects == nil {
+		return common.NewErrMsg("No projects found")
+	}
+
+	exists, project := c.ProjectExists(projectName)
+
+	if !exists {
+		return common.NewErrMsg("Project '%s' does not exist", projectName)
+	}
+
+	for _, envFile := range project.EnvFiles {
+		if envFile.Path == path {
+			err := c.dbQueries.DeleteEnvFile(c.dbContext, sqlc.DeleteEnvFileParams{
+				Path:      path,
+				ProjectID: project.ID,
+			})
+
+			if err != nil {
+				return common.NewErrMsg("Error removing env file from database: %s", err)
+			}
+
+			return common.NewSuccessMsg("Removed env file '%s' from project '%s'", path, projectName)
+		}
+	}
+
+	return common.NewErrMsg("Env file '%s' does not exist on project '%s'", path, projectName)
+}
@@ -0,0 +1,90 @@
+package core
+
+import (
+	"regexp"
+
+	"github.com/iskandervdh/spinup/common"
+	"github.com/iskandervdh/spinup/database/sqlc"
+)
+
+type EnvVariable = sqlc.EnvVariable
+
+// Regex to match a valid environment variable name.
+var envVariableNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Add an environment variable with the given key and value to the project with the given name.
+//
+// If the variable already exists its value is updated.
+func (c *Core) AddEnvVariable(projectName string, key string, value string) common.Msg {
+	if c.projects == nil {
+		return common.NewErrMsg("No projects found")
+	}
+
+	exists, project := c.ProjectExists(projectName)
+
+	if !exists {
+		return common.NewErrMsg("Project '%s' does not exist", projectName)
+	}
+
+	if !envVariableNameRegex.MatchString(key) {
+		return common.NewErrMsg("'%s' is not a valid environment variable name", key)
+	}
+
+	for _, envVariable := range project.EnvVariables {
+		if envVariable.Name == key {
+			err := c.dbQueries.UpdateEnvVariable(c.dbContext, sqlc.UpdateEnvVariableParams{
+				Value:     value,
+				Name:      key,
+				ProjectID: project.ID,
+			})
+
+			if err != nil {
+				return common.NewErrMsg("Error updating environment variable: %s", err)
+			}
+
+			return common.NewSuccessMsg("Updated environment variable '%s' of project '%s' to '%s'", key, projectName, value)
+		}
+	}
+
+	err := c.dbQueries.CreateEnvVariable(c.dbContext, sqlc.CreateEnvVariableParams{
+		Name:      key,
+		Value:     value,
+		ProjectID: project.ID,
+	})
+
+	if err != nil {
+		return common.NewErrMsg("Error creating environment variable: %s", err)
+	}
+
+	return common.NewSuccessMsg("Added environment variable '%s' to project '%s' with value '%s'", key, projectName, value)
+}
+
+// Remove the environment variable with the given key from the project with the given name.
+func (c *Core) RemoveEnvVariable(projectName string, key string) common.Msg {
+	if c.projects == nil {
+		return common.NewErrMsg("No projects found")
+	}
+
+	exists, project := c.ProjectExists(projectName)
+
+	if !exists {
+		return common.NewErrMsg("Project '%s' does not exist, nothing to remove", projectName)
+	}
+
+	for _, envVariable := range project.EnvVariables {
+		if envVariable.Name == key {
+			err := c.dbQueries.DeleteEnvVariable(c.dbContext, sqlc.DeleteEnvVariableParams{
+				Name:      key,
+				ProjectID: project.ID,
+			})
+
+			if err != nil {
+				return common.NewErrMsg("Error deleting environment variable: %s", err)
+			}
+
+			return common.NewSuccessMsg("Removed environment variable '%s' from project '%s'", key, projectName)
+		}
+	}
+
+	return common.NewErrMsg("Environment variable '%s' does not exist on project '%s'", key, projectName)
+}
@@ -0,0 +1,117 @@
+package core
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/iskandervdh/spinup/common"
+)
+
+func TestAddEnvVariable(t *testing.T) {
+	c := TestingCore("add_env_variable")
+
+	c.FetchProjects()
+	c.AddProject("test", 8000, []string{})
+
+	// Refetch projects
+	c.FetchProjects()
+
+	msg := c.AddEnvVariable("test", "API_URL", "http://{{domain}}:{{port}}")
+
+	if _, ok := msg.(*common.SuccessMsg); !ok {
+		t.Error("Expected success message, got", msg.GetText())
+		return
+	}
+
+	// Refetch projects
+	c.FetchProjects()
+
+	_, project := c.ProjectExists("test")
+
+	if len(project.EnvVariables) != 1 {
+		t.Error("Expected 1 environment variable, got", len(project.EnvVariables))
+		return
+	}
+
+	// Adding the same variable again should update its value
+	c.AddEnvVariable("test", "API_URL", "http://localhost")
+	c.FetchProjects()
+
+	_, project = c.ProjectExists("test")
+
+	if len(project.EnvVariables) != 1 || project.EnvVariables[0].Value != "http://localhost" {
+		t.Error("Expected environment variable to be updated, got", project.EnvVariables)
+	}
+}
+
+func TestAddEnvVariableInvalidName(t *testing.T) {
+	c := TestingCore("add_env_variable_invalid_name")
+
+	c.FetchProjects()
+	c.AddProject("test", 8000, []string{})
+	c.FetchProjects()
+
+	msg := c.AddEnvVariable("test", "1INVALID-NAME", "value")
+
+	if _, ok := msg.(*common.ErrMsg); !ok {
+		t.Error("Expected error message, got", msg.GetText())
+	}
+}
+
+func TestRemoveEnvVariable(t *testing.T) {
+	c := TestingCore("remove_env_variable")
+
+	c.FetchProjects()
+	c.AddProject("test", 8000, []string{})
+	c.FetchProjects()
+
+	c.AddEnvVariable("test", "KEY", "value")
+	c.FetchProjects()
+
+	msg := c.RemoveEnvVariable("test", "KEY")
+
+	if _, ok := msg.(*common.SuccessMsg); !ok {
+		t.Error("Expected success message, got", msg.GetText())
+		return
+	}
+
+	c.FetchProjects()
+
+	_, project := c.ProjectExists("test")
+
+	if len(project.EnvVariables) != 0 {
+		t.Error("Expected no environment variables, got", len(project.EnvVariables))
+	}
+
+	msg = c.RemoveEnvVariable("test", "KEY")
+
+	if _, ok := msg.(*common.ErrMsg); !ok {
+		t.Error("Expected error message, got", msg.GetText())
+	}
+}
+
+func TestProjectEnvVariablesInCommandEnvironment(t *testing.T) {
+	c := TestingCore("project_env_variables_in_command_environment")
+
+	c.FetchCommands()
+	c.FetchProjects()
+
+	c.AddCommand("test", "ls")
+	c.SetCommandSettings("test", CommandSettings{Env: []string{"OVERRIDE=command"}})
+
+	c.AddProject("test", 8000, []string{"test"})
+	c.FetchProjects()
+
+	c.AddEnvVariable("test", "API_URL", "http://{{domain}}:{{port}}")
+	c.AddEnvVariable("test", "OVERRIDE", "project")
+	c.FetchProjects()
+
+	_, project := c.ProjectExists("test")
+
+	env := c.commandEnvironment(project.Commands[0], project)
+	expected := []string{"API_URL=http://" + c.config.GetDomain("test") + ":8000", "OVERRIDE=project", "OVERRIDE=command"}
+
+	if !slices.Equal(env, expected) {
+		t.Errorf("Expected %q, got %q", expected, env)
+	}
+}
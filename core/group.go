@@ -0,0 +1,212 @@
+package core
+
+import (
+	"strings"
+
+	"github.com/iskandervdh/spinup/common"
+	"github.com/iskandervdh/spinup/database/sqlc"
+)
+
+// Prefix of a project name that refers to a group of projects instead, like "@checkout".
+const GroupPrefix = "@"
+
+// Group is a named group of projects that are run together.
+type Group struct {
+	sqlc.Group
+	Projects []string
+}
+
+// Groups is a list of groups, sorted by name.
+type Groups []Group
+
+// Get the groups and the names of their projects from the database.
+func (c *Core) GetGroups() (Groups, error) {
+	groups, err := c.dbQueries.GetGroups(c.dbContext)
+
+	if err != nil {
+		return nil, err
+	}
+
+	groupsWithProjects := Groups{}
+
+	for _, group := range groups {
+		projects, err := c.dbQueries.GetGroupProjects(c.dbContext, group.ID)
+
+		if err != nil {
+			return nil, err
+		}
+
+		projectNames := []string{}
+
+		for _, project := range projects {
+			projectNames = append(projectNames, project.Name)
+		}
+
+		groupsWithProjects = append(groupsWithProjects, Group{Group: group, Projects: projectNames})
+	}
+
+	return groupsWithProjects, nil
+}
+
+// Check if a group with the given name exists. Returns the group if it exists.
+func (c *Core) GroupExists(name string) (bool, Group) {
+	groups, err := c.GetGroups()
+
+	if err != nil {
+		return false, Group{}
+	}
+
+	for _, group := range groups {
+		if group.Name == name {
+			return true, group
+		}
+	}
+
+	return false, Group{}
+}
+
+// Add a group with the given name, containing the projects with the given names.
+func (c *Core) AddGroup(name string, projectNames []string) common.Msg {
+	if name == "" || strings.HasPrefix(name, GroupPrefix) || strings.ContainsAny(name, " \t\n") {
+		return common.NewErrMsg("Invalid group name '%s'", name)
+	}
+
+	if exists, _ := c.GroupExists(name); exists {
+		return common.NewErrMsg("Group '%s' already exists", name)
+	}
+
+	projectIDs := []int64{}
+
+	for _, projectName := range projectNames {
+		exists, project := c.ProjectExists(projectName)
+
+		if !exists {
+			return common.NewErrMsg("Project '%s' does not exist", projectName)
+		}
+
+		projectIDs = append(projectIDs, project.ID)
+	}
+
+	group, err := c.dbQueries.CreateGroup(c.dbContext, name)
+
+	if err != nil {
+		return common.NewErrMsg("Error adding group to database: %s", err)
+	}
+
+	for _, projectID := range projectIDs {
+		err = c.dbQueries.CreateGroupProject(c.dbContext, sqlc.CreateGroupProjectParams{
+			GroupID:   group.ID,
+			ProjectID: projectID,
+		})
+
+		if err != nil {
+			return common.NewErrMsg("Error adding projects to group in database: %s", err)
+		}
+	}
+
+	return common.NewSuccessMsg("Added group '%s'", name)
+}
+
+// Remove the group with the given name, without removing its projects.
+func (c *Core) RemoveGroup(name string) common.Msg {
+	exists, group := c.GroupExists(name)
+
+	if !exists {
+		return common.NewErrMsg("Group '%s' does not exist, nothing to remove", name)
+	}
+
+	err := c.dbQueries.DeleteGroupProjects(c.dbContext, group.ID)
+
+	if err != nil {
+		return common.NewErrMsg("Error removing projects from group in database: %s", err)
+	}
+
+	err = c.dbQueries.DeleteGroup(c.dbContext, group.ID)
+
+	if err != nil {
+		return common.NewErrMsg("Error removing group from database: %s", err)
+	}
+
+	return common.NewSuccessMsg("Removed group '%s'", name)
+}
+
+// Add the project with the given name to a group.
+func (c *Core) AddProjectToGroup(groupName string, projectName string) common.Msg {
+	exists, group := c.GroupExists(groupName)
+
+	if !exists {
+		return common.NewErrMsg("Group '%s' does not exist", groupName)
+	}
+
+	exists, project := c.ProjectExists(projectName)
+
+	if !exists {
+		return common.NewErrMsg("Project '%s' does not exist", projectName)
+	}
+
+	for _, name := range group.Projects {
+		if name == projectName {
+			return common.NewErrMsg("Project '%s' is already part of group '%s'", projectName, groupName)
+		}
+	}
+
+	err := c.dbQueries.CreateGroupProject(c.dbContext, sqlc.CreateGroupProjectParams{
+		GroupID:   group.ID,
+		ProjectID: project.ID,
+	})
+
+	if err != nil {
+		return common.NewErrMsg("Error adding project to group in database: %s", err)
+	}
+
+	return common.NewSuccessMsg("Added project '%s' to group '%s'", projectName, groupName)
+}
+
+// Remove the project with the given name from a group.
+func (c *Core) RemoveProjectFromGroup(groupName string, projectName string) common.Msg {
+	exists, group := c.GroupExists(groupName)
+
+	if !exists {
+		return common.NewErrMsg("Group '%s' does not exist", groupName)
+	}
+
+	exists, project := c.ProjectExists(projectName)
+
+	if !exists {
+		return common.NewErrMsg("Project '%s' does not exist", projectName)
+	}
+
+	for _, name := range group.Projects {
+		if name != projectName {
+			continue
+		}
+
+		err := c.dbQueries.DeleteGroupProject(c.dbContext, sqlc.DeleteGroupProjectParams{
+			GroupID:   group.ID,
+			ProjectID: project.ID,
+		})
+
+		if err != nil {
+			return common.NewErrMsg("Error removing project from group in database: %s", err)
+		}
+
+		return common.NewSuccessMsg("Removed project '%s' from group '%s'", projectName, groupName)
+	}
+
+	return common.NewErrMsg("Project '%s' is not part of group '%s'", projectName, groupName)
+}
+
+// Get the names of the projects of the group with the given name.
+func (c *Core) GetGroupProjectNames(name string) ([]string, common.Msg) {
+	exists, group := c.GroupExists(name)
+
+	if !exists {
+		return nil, common.NewErrMsg("Group '%s' does not exist", name)
+	}
+
+	if len(group.Projects) == 0 {
+		return nil, common.NewErrMsg("Group '%s' does not contain any projects", name)
+	}
+
+	return group.Projects, nil
+}
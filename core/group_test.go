@@ -0,0 +1,113 @@
+package core
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/iskandervdh/spinup/common"
+)
+
+func TestAddGroup(t *testing.T) {
+	c := TestingCore("add_group")
+
+	c.FetchCommands()
+	c.FetchProjects()
+
+	c.AddProject("frontend", 1234, []string{})
+	c.AddProject("backend", 1235, []string{})
+
+	// "Refetch" the projects from the config file
+	c.FetchProjects()
+
+	msg := c.AddGroup("stack", []string{"frontend", "backend"})
+
+	if _, ok := msg.(*common.SuccessMsg); !ok {
+		t.Error("Expected group to be added, got", msg.GetText())
+		return
+	}
+
+	exists, group := c.GroupExists("stack")
+
+	if !exists {
+		t.Error("Expected group to exist")
+		return
+	}
+
+	if !slices.Equal(group.Projects, []string{"frontend", "backend"}) {
+		t.Error("Expected group to contain the projects in the order they were added, got", group.Projects)
+	}
+
+	if msg := c.AddGroup("stack", nil); msg.GetText() != "Group 'stack' already exists" {
+		t.Error("Expected error when adding a group that already exists, got", msg.GetText())
+	}
+
+	if msg := c.AddGroup("other", []string{"unknown"}); msg.GetText() != "Project 'unknown' does not exist" {
+		t.Error("Expected error when adding a group with a project that does not exist, got", msg.GetText())
+	}
+
+	if msg := c.AddGroup("@stack", nil); msg.GetText() != "Invalid group name '@stack'" {
+		t.Error("Expected error when adding a group with an invalid name, got", msg.GetText())
+	}
+}
+
+func TestGroupProjects(t *testing.T) {
+	c := TestingCore("group_projects")
+
+	c.FetchCommands()
+	c.FetchProjects()
+
+	c.AddProject("frontend", 1234, []string{})
+	c.AddProject("backend", 1235, []string{})
+
+	// "Refetch" the projects from the config file
+	c.FetchProjects()
+
+	c.AddGroup("stack", nil)
+
+	if _, msg := c.GetGroupProjectNames("stack"); msg == nil {
+		t.Error("Expected error for a group without projects")
+	}
+
+	c.AddProjectToGroup("stack", "backend")
+	c.AddProjectToGroup("stack", "frontend")
+
+	if msg := c.AddProjectToGroup("stack", "frontend"); msg.GetText() != "Project 'frontend' is already part of group 'stack'" {
+		t.Error("Expected error when adding a project to a group twice, got", msg.GetText())
+	}
+
+	projectNames, _ := c.GetGroupProjectNames("stack")
+
+	if !slices.Equal(projectNames, []string{"backend", "frontend"}) {
+		t.Error("Expected group to contain both projects, got", projectNames)
+	}
+
+	c.RemoveProjectFromGroup("stack", "backend")
+
+	if msg := c.RemoveProjectFromGroup("stack", "backend"); msg.GetText() != "Project 'backend' is not part of group 'stack'" {
+		t.Error("Expected error when removing a project that is not part of the group, got", msg.GetText())
+	}
+
+	projectNames, _ = c.GetGroupProjectNames("stack")
+
+	if !slices.Equal(projectNames, []string{"frontend"}) {
+		t.Error("Expected group to only contain frontend, got", projectNames)
+	}
+
+	msg := c.RemoveGroup("stack")
+
+	if _, ok := msg.(*common.SuccessMsg); !ok {
+		t.Error("Expected group to be removed, got", msg.GetText())
+	}
+
+	if exists, _ := c.GroupExists("stack"); exists {
+		t.Error("Expected group not to exist after removing it")
+	}
+
+	if exists, _ := c.ProjectExists("frontend"); !exists {
+		t.Error("Expected projects of the group to still exist after removing it")
+	}
+
+	if msg := c.RemoveGroup("stack"); msg.GetText() != "Group 'stack' does not exist, nothing to remove" {
+		t.Error("Expected error when removing a group that does not exist, got", msg.GetText())
+	}
+}
@@ -0,0 +1,126 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/iskandervdh/spinup/common"
+	"github.com/iskandervdh/spinup/config"
+)
+
+// Issue the certificate of the project with the given name for its domain and the given domain aliases,
+// when HTTPS is enabled and the existing certificate does not cover them.
+//
+// Returns whether a new certificate was issued, in which case the reverse proxy has to be reloaded.
+func (c *Core) ensureCertificate(name string, domainAliases []string) (bool, error) {
+	if !c.config.IsHTTPSEnabled() {
+		return false, nil
+	}
+
+	issued, err := c.config.EnsureCertificate(name, append([]string{c.config.GetDomain(name)}, domainAliases...))
+
+	if err != nil {
+		return false, fmt.Errorf("error issuing certificate: %s", err)
+	}
+
+	return issued, nil
+}
+
+// Issue the certificate of the project with the given name from its state in the database,
+// reloading the reverse proxy when a new certificate was issued.
+func (c *Core) updateCertificate(name string) error {
+	project, err := c.dbQueries.GetProject(c.dbContext, name)
+
+	if err != nil {
+		return err
+	}
+
+	projectWithInfo, err := c.getProjectWithInfo(project)
+
+	if err != nil {
+		return err
+	}
+
+	issued, err := c.ensureCertificate(name, projectDomainAliases(projectWithInfo))
+
+	if err != nil || !issued {
+		return err
+	}
+
+	proxy, err := c.reverseProxy()
+
+	if err != nil {
+		return err
+	}
+
+	return proxy.Reload()
+}
+
+// Serve all projects over HTTPS with certificates that are issued by the development CA.
+//
+// The CA is generated if it does not exist yet, it has to be trusted by the system and browsers.
+func (c *Core) EnableHTTPS() common.Msg {
+	proxy, err := c.reverseProxy()
+
+	if err != nil {
+		return common.NewErrMsg("%s", err)
+	}
+
+	if proxy.Name() == config.ReverseProxyBuiltin {
+		return common.NewErrMsg("The built-in reverse proxy does not support HTTPS, use another reverse proxy with '%s proxy use'", common.ProgramName)
+	}
+
+	err = c.config.EnsureCA()
+
+	if err != nil {
+		return common.NewErrMsg("Error generating CA: %s", err)
+	}
+
+	err = c.config.SetSetting(config.HTTPSSettingKey, true)
+
+	if err != nil {
+		return common.NewErrMsg("Error saving HTTPS setting: %s", err)
+	}
+
+	msg := c.SyncProxyConfigs(false)
+
+	if _, ok := msg.(*common.ErrMsg); ok {
+		return msg
+	}
+
+	caCertPath, _ := c.config.GetCAPaths()
+
+	c.sendMsg(msg)
+	c.sendMsg(common.NewInfoMsg("Trust the development CA %s to use HTTPS without warnings, see '%s https export-ca'", caCertPath, common.ProgramName))
+
+	return common.NewSuccessMsg("Enabled HTTPS")
+}
+
+// Serve all projects over plain HTTP again. The development CA and the certificates are kept.
+func (c *Core) DisableHTTPS() common.Msg {
+	err := c.config.SetSetting(config.HTTPSSettingKey, false)
+
+	if err != nil {
+		return common.NewErrMsg("Error saving HTTPS setting: %s", err)
+	}
+
+	msg := c.SyncProxyConfigs(false)
+
+	if _, ok := msg.(*common.ErrMsg); ok {
+		return msg
+	}
+
+	c.sendMsg(msg)
+
+	return common.NewSuccessMsg("Disabled HTTPS")
+}
+
+// Get the certificate of the development CA in PEM format, so it can be added to a trust store.
+func (c *Core) ExportCA() ([]byte, common.Msg) {
+	data, err := c.config.ExportCA()
+
+	if err != nil {
+		return nil, common.NewErrMsg("Error exporting CA: %s", err)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,179 @@
+package core
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/iskandervdh/spinup/common"
+)
+
+// Get the DNS names of the certificate of the project with the given name.
+func certificateDNSNames(c *Core, name string) []string {
+	certPath, _ := c.config.GetCertificatePaths(name)
+	data, err := os.ReadFile(certPath)
+
+	if err != nil {
+		return nil
+	}
+
+	block, _ := pem.Decode(data)
+	cert, err := x509.ParseCertificate(block.Bytes)
+
+	if err != nil {
+		return nil
+	}
+
+	return cert.DNSNames
+}
+
+func TestHTTPS(t *testing.T) {
+	c := TestingCore("https")
+
+	c.FetchCommands()
+	c.FetchProjects()
+
+	c.AddProject("example", 1234, []string{})
+	c.FetchProjects()
+
+	msg := c.EnableHTTPS()
+
+	if _, ok := msg.(*common.SuccessMsg); !ok {
+		t.Fatal("Expected HTTPS to be enabled, got", msg.GetText())
+	}
+
+	nginxConfig, _ := os.ReadFile(filepath.Join(c.config.GetNginxConfigDir(), "example.conf"))
+
+	if !strings.Contains(string(nginxConfig), "listen 443 ssl;") {
+		t.Error("Expected nginx config to be served over HTTPS, got", string(nginxConfig))
+	}
+
+	if !slices.Equal(certificateDNSNames(c, "example"), []string{"example.test"}) {
+		t.Error("Expected certificate for the domain of the project, got", certificateDNSNames(c, "example"))
+	}
+
+	c.AddDomainAlias("example", "example.localhost")
+
+	if !slices.Contains(certificateDNSNames(c, "example"), "example.localhost") {
+		t.Error("Expected certificate to be issued again with the added domain alias, got", certificateDNSNames(c, "example"))
+	}
+
+	c.FetchProjects()
+	c.RemoveDomainAlias("example", "example.localhost")
+
+	if slices.Contains(certificateDNSNames(c, "example"), "example.localhost") {
+		t.Error("Expected certificate to be issued again without the removed domain alias, got", certificateDNSNames(c, "example"))
+	}
+
+	c.AddProject("other", 1235, []string{})
+
+	if !slices.Equal(certificateDNSNames(c, "other"), []string{"other.test"}) {
+		t.Error("Expected certificate to be issued for a new project, got", certificateDNSNames(c, "other"))
+	}
+
+	c.FetchProjects()
+	c.RenameProject("other", "renamed")
+
+	if certificateDNSNames(c, "other") != nil || !slices.Equal(certificateDNSNames(c, "renamed"), []string{"renamed.test"}) {
+		t.Error("Expected certificate of the renamed project to replace the old one")
+	}
+
+	c.FetchProjects()
+	c.RemoveProject("renamed")
+
+	if certificateDNSNames(c, "renamed") != nil {
+		t.Error("Expected certificate of the removed project to be removed")
+	}
+
+	msg = c.DisableHTTPS()
+
+	if _, ok := msg.(*common.SuccessMsg); !ok {
+		t.Fatal("Expected HTTPS to be disabled, got", msg.GetText())
+	}
+
+	nginxConfig, _ = os.ReadFile(filepath.Join(c.config.GetNginxConfigDir(), "example.conf"))
+
+	if strings.Contains(string(nginxConfig), "listen 443 ssl;") {
+		t.Error("Expected nginx config to be served over HTTP after disabling HTTPS, got", string(nginxConfig))
+	}
+
+	if data, msg := c.ExportCA(); msg != nil || !strings.HasPrefix(string(data), "-----BEGIN CERTIFICATE-----") {
+		t.Error("Expected CA certificate to be exported")
+	}
+}
+
+func TestHTTPSProjectNamedCA(t *testing.T) {
+	c := TestingCore("https_project_named_ca")
+
+	c.FetchCommands()
+	c.FetchProjects()
+
+	c.AddProject("example", 1234, []string{})
+	c.FetchProjects()
+
+	if _, ok := c.EnableHTTPS().(*common.SuccessMsg); !ok {
+		t.Fatal("Expected HTTPS to be enabled")
+	}
+
+	caCertPath, caKeyPath := c.config.GetCAPaths()
+	caCert, _ := os.ReadFile(caCertPath)
+	caKey, _ := os.ReadFile(caKeyPath)
+
+	c.AddProject("ca", 1235, []string{})
+	c.AddProject("ca-key", 1236, []string{})
+	c.AddProject("other", 1237, []string{})
+
+	if cert, _ := os.ReadFile(caCertPath); !slices.Equal(cert, caCert) {
+		t.Error("Expected CA certificate not to be changed by a project named ca")
+	}
+
+	if key, _ := os.ReadFile(caKeyPath); !slices.Equal(key, caKey) {
+		t.Error("Expected CA key not to be changed by a project named ca")
+	}
+
+	block, _ := pem.Decode(caCert)
+	ca, err := x509.ParseCertificate(block.Bytes)
+
+	if err != nil {
+		t.Fatal("Could not parse CA certificate:", err)
+	}
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca)
+
+	for _, name := range []string{"ca", "ca-key", "other"} {
+		certPath, _ := c.config.GetCertificatePaths(name)
+		data, _ := os.ReadFile(certPath)
+		block, _ := pem.Decode(data)
+
+		if block == nil {
+			t.Errorf("Expected certificate of project '%s'", name)
+			continue
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+
+		if err == nil {
+			_, err = cert.Verify(x509.VerifyOptions{DNSName: name + ".test", Roots: roots})
+		}
+
+		if err != nil {
+			t.Errorf("Expected certificate of project '%s' to be signed by the CA, got %s", name, err)
+		}
+	}
+
+	c.FetchProjects()
+	c.RemoveProject("ca")
+
+	if cert, err := os.ReadFile(caCertPath); err != nil || !slices.Equal(cert, caCert) {
+		t.Error("Expected CA certificate to be kept when removing a project named ca")
+	}
+
+	if _, err := os.Stat(caKeyPath); err != nil {
+		t.Error("Expected CA key to be kept when removing a project named ca")
+	}
+}
@@ -29,7 +29,7 @@ func (c *Core) InitSqliteDB() error {
 	return nil
 }
 
-// Initialize the config directory, hosts and nginx.
+// Initialize the config directory, hosts and the reverse proxy.
 func (c *Core) Init() common.Msg {
 	err := c.createConfigDir()
 
@@ -37,10 +37,16 @@ func (c *Core) Init() common.Msg {
 		return common.NewErrMsg("Error creating config directory: %v", err)
 	}
 
-	err = c.config.InitNginx()
+	proxy, err := c.config.GetReverseProxy()
 
 	if err != nil {
-		return common.NewErrMsg("Error initializing nginx: %v", err)
+		return common.NewErrMsg("Error getting reverse proxy: %v", err)
+	}
+
+	err = proxy.Init()
+
+	if err != nil {
+		return common.NewErrMsg("Error initializing %s: %v", proxy.Name(), err)
 	}
 
 	return common.NewSuccessMsg("\nInitialization complete")
@@ -0,0 +1,264 @@
+package core
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Levels of log lines, determined by looking at the text of the line.
+const (
+	LogDebug = "debug"
+	LogInfo  = "info"
+	LogWarn  = "warn"
+	LogError = "error"
+)
+
+// Levels of log lines, from the least to the most severe.
+var logLevels = []string{LogDebug, LogInfo, LogWarn, LogError}
+
+// Number of lines in a page of logs if no limit is given.
+const logPageSize = 500
+
+var (
+	errorLevelRegex = regexp.MustCompile(`(?i)\b(error|err|fatal|panic|critical|crit|exception|failed)\b`)
+	warnLevelRegex  = regexp.MustCompile(`(?i)\b(warn|warning|deprecated)\b`)
+	debugLevelRegex = regexp.MustCompile(`(?i)\b(debug|trace|verbose)\b`)
+)
+
+// LogLine is a line of output of a command, or of spinup itself, read from the log files.
+type LogLine struct {
+	Time    time.Time `json:"time"`
+	Command string    `json:"command"`
+	Stream  string    `json:"stream"`
+	Level   string    `json:"level"`
+
+	// Text of the line, including the ANSI escape sequences of the output
+	Text string `json:"text"`
+
+	// Parts of the text with their style, only set for lines returned by SearchLogs and FollowFilteredLogs
+	Segments []AnsiSegment `json:"segments"`
+}
+
+// LogFilter determines which lines are returned when searching or following the logs of a project.
+//
+// Empty fields do not filter any lines.
+type LogFilter struct {
+	// Only return lines of the command with this name, or of spinup itself with "spinup"
+	Command string `json:"command"`
+
+	// Only return lines written to this stream, "stdout" or "stderr"
+	Stream string `json:"stream"`
+
+	// Only return lines with at least this level, "debug", "info", "warn" or "error"
+	Level string `json:"level"`
+
+	// Only return lines containing this text, ignoring case, or matching it if Regex is set
+	Query string `json:"query"`
+	Regex bool   `json:"regex"`
+}
+
+// LogPage is a page of log lines that match a filter, ordered from old to new.
+type LogPage struct {
+	Lines []LogLine `json:"lines"`
+
+	// Offset of the first line of the page, used to get the page of older lines
+	Before int64 `json:"before"`
+
+	// Whether there are older lines that match the filter
+	HasMore bool `json:"hasMore"`
+
+	// Offset in the log file to follow the logs from
+	Offset int64 `json:"offset"`
+}
+
+// Guess the level of a line of output by looking for words like "error" and "warning".
+func guessLogLevel(text string) string {
+	text = stripAnsi(text)
+
+	switch {
+	case errorLevelRegex.MatchString(text):
+		return LogError
+	case warnLevelRegex.MatchString(text):
+		return LogWarn
+	case debugLevelRegex.MatchString(text):
+		return LogDebug
+	default:
+		return LogInfo
+	}
+}
+
+// Parse a line of a log file.
+func parseLogLine(text string) (LogLine, bool) {
+	var entry logEntry
+
+	if err := json.Unmarshal([]byte(text), &entry); err != nil || entry.Time.IsZero() {
+		return LogLine{}, false
+	}
+
+	return LogLine{
+		Time:    entry.Time,
+		Command: entry.Command,
+		Stream:  entry.Stream,
+		Level:   guessLogLevel(entry.Text),
+		Text:    entry.Text,
+	}, true
+}
+
+// logMatcher checks if log lines match a filter.
+type logMatcher struct {
+	filter   LogFilter
+	minLevel int
+	query    string
+	regex    *regexp.Regexp
+}
+
+// Create a matcher for the given filter, checking that its values are valid.
+func newLogMatcher(filter LogFilter) (*logMatcher, error) {
+	m := &logMatcher{filter: filter}
+
+	if filter.Stream != "" && filter.Stream != LogStdout && filter.Stream != LogStderr {
+		return nil, fmt.Errorf("invalid stream '%s', expected '%s' or '%s'", filter.Stream, LogStdout, LogStderr)
+	}
+
+	if filter.Level != "" {
+		m.minLevel = slices.Index(logLevels, filter.Level)
+
+		if m.minLevel == -1 {
+			return nil, fmt.Errorf("invalid level '%s', expected one of %s", filter.Level, strings.Join(logLevels, ", "))
+		}
+	}
+
+	if filter.Regex && filter.Query != "" {
+		regex, err := regexp.Compile(filter.Query)
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid regular expression: %s", err)
+		}
+
+		m.regex = regex
+	}
+
+	m.query = strings.ToLower(filter.Query)
+
+	return m, nil
+}
+
+func (m *logMatcher) matches(line LogLine) bool {
+	if m.filter.Command != "" && line.Command != m.filter.Command {
+		return false
+	}
+
+	if m.filter.Stream != "" && line.Stream != m.filter.Stream {
+		return false
+	}
+
+	if slices.Index(logLevels, line.Level) < m.minLevel {
+		return false
+	}
+
+	if m.query == "" {
+		return true
+	}
+
+	text := stripAnsi(line.Text)
+
+	if m.regex != nil {
+		return m.regex.MatchString(text)
+	}
+
+	return strings.Contains(strings.ToLower(text), m.query)
+}
+
+// Search the combined logs of the last run of a project for lines that match the given filter.
+//
+// Returns the last page of at most limit matching lines that start before the given offset,
+// or at the end of the logs if the offset is 0.
+func (c *Core) SearchLogs(projectName string, filter LogFilter, before int64, limit int) (LogPage, error) {
+	matcher, err := newLogMatcher(filter)
+
+	if err != nil {
+		return LogPage{}, err
+	}
+
+	if limit <= 0 {
+		limit = logPageSize
+	}
+
+	file, err := c.openLogs(projectName, "")
+
+	if err != nil {
+		return LogPage{}, err
+	}
+
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	page := LogPage{Lines: []LogLine{}}
+	offsets := []int64{}
+	var offset int64
+
+	for {
+		text, err := reader.ReadString('\n')
+
+		// Lines that are still being written are left for following the logs
+		if err != nil {
+			break
+		}
+
+		lineOffset := offset
+		offset += int64(len(text))
+
+		if before > 0 && lineOffset >= before {
+			continue
+		}
+
+		line, ok := parseLogLine(text)
+
+		if !ok || !matcher.matches(line) {
+			continue
+		}
+
+		page.Lines = append(page.Lines, line)
+		offsets = append(offsets, lineOffset)
+
+		if len(page.Lines) > limit {
+			page.Lines = page.Lines[1:]
+			offsets = offsets[1:]
+			page.HasMore = true
+		}
+	}
+
+	if len(offsets) > 0 {
+		page.Before = offsets[0]
+	}
+
+	for i := range page.Lines {
+		page.Lines[i].Segments = ParseAnsi(page.Lines[i].Text)
+	}
+
+	page.Offset = offset
+
+	return page, nil
+}
+
+// Follow the combined logs of a project starting at the given offset, calling onLine for
+// every new line that matches the given filter until the stop channel is closed.
+func (c *Core) FollowFilteredLogs(projectName string, filter LogFilter, offset int64, stop <-chan struct{}, onLine func(LogLine)) error {
+	matcher, err := newLogMatcher(filter)
+
+	if err != nil {
+		return err
+	}
+
+	return c.FollowLogs(projectName, "", offset, stop, func(line LogLine) {
+		if matcher.matches(line) {
+			line.Segments = ParseAnsi(line.Text)
+			onLine(line)
+		}
+	})
+}
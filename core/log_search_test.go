@@ -0,0 +1,191 @@
+package core
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseLogLine(t *testing.T) {
+	now := time.Now()
+
+	text := formatLogLine(now, "server", LogStderr, "\x1b[32mlistening\x1b[0m on :3000")
+
+	if strings.Count(text, "\n") != 1 || !strings.HasSuffix(text, "\n") {
+		t.Errorf("Expected log line to be a single line, got %q", text)
+	}
+
+	line, ok := parseLogLine(text)
+
+	if !ok {
+		t.Fatal("Expected log line to be parsed")
+	}
+
+	if line.Command != "server" || line.Stream != LogStderr || line.Text != "\x1b[32mlistening\x1b[0m on :3000" || !line.Time.Equal(now) {
+		t.Error("Expected parsed log line to match the written line, got", line)
+	}
+
+	if _, ok := parseLogLine("2024-01-02T15:04:05.000Z [server] not a log line"); ok {
+		t.Error("Expected invalid log line not to be parsed")
+	}
+}
+
+func TestGuessLogLevel(t *testing.T) {
+	levels := map[string]string{
+		"Error: could not connect":          LogError,
+		"\x1b[31mERROR\x1b[0m failed":       LogError,
+		"Warning: deprecated option":        LogWarn,
+		"[debug] cache hit":                 LogDebug,
+		"Server listening on port 3000":     LogInfo,
+		"errors.go was compiled":            LogInfo,
+		"WARN something might be off today": LogWarn,
+	}
+
+	for text, expected := range levels {
+		if level := guessLogLevel(text); level != expected {
+			t.Errorf("Expected level of %q to be %s, got %s", text, expected, level)
+		}
+	}
+}
+
+// Write the given lines of the given commands to the combined log file of the project "test".
+func writeTestingLogs(t *testing.T, c *Core, lines [][3]string) {
+	l, err := openLogFile(c.config.GetLogFilePath("test", ""))
+
+	if err != nil {
+		t.Fatal("Expected no error opening log file, got", err)
+	}
+
+	defer l.Close()
+
+	for _, line := range lines {
+		l.Write([]byte(formatLogLine(time.Now(), line[0], line[1], line[2])))
+	}
+}
+
+func TestSearchLogs(t *testing.T) {
+	c := TestingCore("search_logs")
+
+	writeTestingLogs(t, c, [][3]string{
+		{"frontend", LogStdout, "compiled successfully"},
+		{"backend", LogStdout, "listening on port 8000"},
+		{"backend", LogStderr, "Error: database is not available"},
+		{"frontend", LogStderr, "Warning: chunk is too large"},
+		{"backend", LogStdout, "GET /api/users 200"},
+		{"backend", LogStdout, "GET /api/posts 500"},
+	})
+
+	tests := []struct {
+		filter   LogFilter
+		expected []string
+	}{
+		{LogFilter{}, []string{"compiled successfully", "listening on port 8000", "Error: database is not available", "Warning: chunk is too large", "GET /api/users 200", "GET /api/posts 500"}},
+		{LogFilter{Command: "frontend"}, []string{"compiled successfully", "Warning: chunk is too large"}},
+		{LogFilter{Stream: LogStderr}, []string{"Error: database is not available", "Warning: chunk is too large"}},
+		{LogFilter{Level: LogWarn}, []string{"Error: database is not available", "Warning: chunk is too large"}},
+		{LogFilter{Level: LogError, Command: "frontend"}, []string{}},
+		{LogFilter{Query: "get /API"}, []string{"GET /api/users 200", "GET /api/posts 500"}},
+		{LogFilter{Query: `GET .* 5\d\d`, Regex: true}, []string{"GET /api/posts 500"}},
+	}
+
+	for _, test := range tests {
+		page, err := c.SearchLogs("test", test.filter, 0, 0)
+
+		if err != nil {
+			t.Errorf("Expected no error searching logs with %+v, got %s", test.filter, err)
+			continue
+		}
+
+		texts := []string{}
+
+		for _, line := range page.Lines {
+			texts = append(texts, line.Text)
+		}
+
+		if strings.Join(texts, "\n") != strings.Join(test.expected, "\n") {
+			t.Errorf("Expected lines %q when searching logs with %+v, got %q", test.expected, test.filter, texts)
+		}
+	}
+
+	if _, err := c.SearchLogs("test", LogFilter{Query: "(", Regex: true}, 0, 0); err == nil {
+		t.Error("Expected error for invalid regular expression, got nil")
+	}
+
+	if _, err := c.SearchLogs("test", LogFilter{Stream: "stdin"}, 0, 0); err == nil {
+		t.Error("Expected error for invalid stream, got nil")
+	}
+
+	if _, err := c.SearchLogs("test", LogFilter{Level: "critical"}, 0, 0); err == nil {
+		t.Error("Expected error for invalid level, got nil")
+	}
+}
+
+func TestSearchLogsPages(t *testing.T) {
+	c := TestingCore("search_logs_pages")
+
+	writeTestingLogs(t, c, [][3]string{
+		{"test", LogStdout, "1"},
+		{"test", LogStdout, "2"},
+		{"test", LogStdout, "3"},
+		{"test", LogStdout, "4"},
+		{"test", LogStdout, "5"},
+	})
+
+	page, err := c.SearchLogs("test", LogFilter{}, 0, 2)
+
+	if err != nil || len(page.Lines) != 2 || page.Lines[0].Text != "4" || page.Lines[1].Text != "5" || !page.HasMore {
+		t.Fatal("Expected last page with lines 4 and 5, got", page, err)
+	}
+
+	page, _ = c.SearchLogs("test", LogFilter{}, page.Before, 2)
+
+	if len(page.Lines) != 2 || page.Lines[0].Text != "2" || page.Lines[1].Text != "3" || !page.HasMore {
+		t.Fatal("Expected page with lines 2 and 3, got", page)
+	}
+
+	page, _ = c.SearchLogs("test", LogFilter{}, page.Before, 2)
+
+	if len(page.Lines) != 1 || page.Lines[0].Text != "1" || page.HasMore {
+		t.Error("Expected first page with line 1, got", page)
+	}
+}
+
+func TestFollowFilteredLogs(t *testing.T) {
+	c := TestingCore("follow_filtered_logs")
+
+	l, err := openLogFile(c.config.GetLogFilePath("test", ""))
+
+	if err != nil {
+		t.Fatal("Expected no error opening log file, got", err)
+	}
+
+	defer l.Close()
+
+	stop := make(chan struct{})
+	lines := make(chan LogLine, 10)
+	done := make(chan error)
+
+	go func() {
+		done <- c.FollowFilteredLogs("test", LogFilter{Command: "backend"}, 0, stop, func(line LogLine) {
+			lines <- line
+		})
+	}()
+
+	l.Write([]byte(formatLogLine(time.Now(), "frontend", LogStdout, "ignored")))
+	l.Write([]byte(formatLogLine(time.Now(), "backend", LogStdout, "matched")))
+
+	select {
+	case line := <-lines:
+		if line.Command != "backend" || line.Text != "matched" || len(line.Segments) != 1 {
+			t.Error("Expected only matching lines to be followed, got", line)
+		}
+	case <-time.After(2 * time.Second):
+		t.Error("Expected matching line to be followed")
+	}
+
+	close(stop)
+
+	if err := <-done; err != nil {
+		t.Error("Expected no error following logs, got", err)
+	}
+}
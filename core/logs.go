@@ -0,0 +1,396 @@
+package core
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/iskandervdh/spinup/common"
+)
+
+// Log files are rotated once they grow larger than this size.
+const logMaxSize = 10 * 1024 * 1024
+
+// Log files are rotated once they have been written to for this long.
+const logMaxAge = 24 * time.Hour
+
+// Number of rotated log files that are kept for every log, including the ones of previous runs.
+const logRetention = 5
+
+// Rotated log files that were last written to longer ago than this are removed.
+const logRetentionAge = 7 * 24 * time.Hour
+
+// How often followed log files are checked for new lines.
+const logFollowInterval = 200 * time.Millisecond
+
+// Streams of the output of a command that are written to the log files.
+const (
+	LogStdout = "stdout"
+	LogStderr = "stderr"
+)
+
+// Get the path of the rotated log file with the given index, where 1 is the most recent one.
+func rotatedLogPath(logPath string, index int) string {
+	return fmt.Sprintf("%s.%d.log", strings.TrimSuffix(logPath, ".log"), index)
+}
+
+// logEntry is a line of output of a command, or of spinup itself, as it is stored in the log files.
+//
+// Every line in the log files is a JSON object, so the stream and the ANSI escape sequences of the output are preserved.
+type logEntry struct {
+	Time    time.Time `json:"time"`
+	Stream  string    `json:"stream"`
+	Command string    `json:"command"`
+	Text    string    `json:"text"`
+}
+
+// Format a line of output of the given command, or of spinup itself, for the log files.
+func formatLogLine(t time.Time, commandName string, stream string, text string) string {
+	data, _ := json.Marshal(logEntry{Time: t, Stream: stream, Command: commandName, Text: text})
+
+	return string(data) + "\n"
+}
+
+// logFile is a log file that is rotated when it becomes too large or too old.
+type logFile struct {
+	mu       sync.Mutex
+	path     string
+	file     *os.File
+	size     int64
+	openedAt time.Time
+}
+
+// Open a new log file at the given path, keeping the logs of a previous run as a rotated log file.
+func openLogFile(logPath string) (*logFile, error) {
+	err := os.MkdirAll(filepath.Dir(logPath), 0755)
+
+	if err != nil {
+		return nil, fmt.Errorf("error creating logs directory: %s", err)
+	}
+
+	l := &logFile{path: logPath}
+	err = l.rotate()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+// Move the current log file to the first rotated log file and start a new one.
+//
+// Older rotated log files are shifted and the ones that are no longer retained are removed.
+func (l *logFile) rotate() error {
+	if l.file != nil {
+		l.file.Close()
+		l.file = nil
+	}
+
+	if _, err := os.Stat(l.path); err == nil {
+		os.Remove(rotatedLogPath(l.path, logRetention))
+
+		for i := logRetention - 1; i >= 1; i-- {
+			os.Rename(rotatedLogPath(l.path, i), rotatedLogPath(l.path, i+1))
+		}
+
+		err := os.Rename(l.path, rotatedLogPath(l.path, 1))
+
+		if err != nil {
+			return fmt.Errorf("error rotating log file: %s", err)
+		}
+	}
+
+	for i := 1; i <= logRetention; i++ {
+		info, err := os.Stat(rotatedLogPath(l.path, i))
+
+		if err == nil && time.Since(info.ModTime()) > logRetentionAge {
+			os.Remove(rotatedLogPath(l.path, i))
+		}
+	}
+
+	file, err := os.OpenFile(l.path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+
+	if err != nil {
+		return fmt.Errorf("error creating log file: %s", err)
+	}
+
+	l.file = file
+	l.size = 0
+	l.openedAt = time.Now()
+
+	return nil
+}
+
+func (l *logFile) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.file == nil {
+		return 0, os.ErrClosed
+	}
+
+	if l.size > 0 && (l.size+int64(len(p)) > logMaxSize || time.Since(l.openedAt) > logMaxAge) {
+		err := l.rotate()
+
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := l.file.Write(p)
+	l.size += int64(n)
+
+	return n, err
+}
+
+func (l *logFile) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.file == nil {
+		return nil
+	}
+
+	err := l.file.Close()
+	l.file = nil
+
+	return err
+}
+
+// projectLogs writes the output of the commands of a running project to
+// a log file per command and a combined log file for all commands.
+type projectLogs struct {
+	combined *logFile
+	commands map[string]*logFile
+}
+
+// Open the log files of the given project and its commands.
+func (c *Core) newProjectLogs(projectName string, commandNames []string) (*projectLogs, error) {
+	combined, err := openLogFile(c.config.GetLogFilePath(projectName, ""))
+
+	if err != nil {
+		return nil, err
+	}
+
+	logs := &projectLogs{combined: combined, commands: map[string]*logFile{}}
+
+	for _, commandName := range commandNames {
+		logs.commands[commandName], err = openLogFile(c.config.GetLogFilePath(projectName, commandName))
+
+		if err != nil {
+			logs.Close()
+			return nil, err
+		}
+	}
+
+	return logs, nil
+}
+
+// Write a line of output of the command with the given name to its log file and the combined log file.
+func (pl *projectLogs) writeLine(commandName string, stream string, text string) {
+	if pl == nil {
+		return
+	}
+
+	line := []byte(formatLogLine(time.Now(), commandName, stream, text))
+
+	if file, ok := pl.commands[commandName]; ok {
+		file.Write(line)
+	}
+
+	pl.combined.Write(line)
+}
+
+// Write a message of spinup itself to the combined log file.
+func (pl *projectLogs) writeMsg(msg common.Msg) {
+	if pl == nil {
+		return
+	}
+
+	now := time.Now()
+	stream := LogStdout
+
+	if _, ok := msg.(*common.ErrMsg); ok {
+		stream = LogStderr
+	}
+
+	for _, text := range strings.Split(strings.TrimSpace(msg.GetText()), "\n") {
+		pl.combined.Write([]byte(formatLogLine(now, common.ProgramName, stream, text)))
+	}
+}
+
+func (pl *projectLogs) Close() {
+	pl.combined.Close()
+
+	for _, file := range pl.commands {
+		file.Close()
+	}
+}
+
+// LogOptions determine which lines are returned by ReadLogs.
+type LogOptions struct {
+	// Only return lines that were written at or after this time, if it is set
+	Since time.Time
+
+	// Only return this number of lines from the end of the logs, if it is larger than 0
+	Tail int
+}
+
+// Open the log file of the given command of a project, or the combined log file if no command is given.
+func (c *Core) openLogs(projectName string, commandName string) (*os.File, error) {
+	file, err := os.Open(c.config.GetLogFilePath(projectName, commandName))
+
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return nil, err
+		}
+
+		if commandName != "" {
+			return nil, fmt.Errorf("no logs available for command '%s' of project '%s'", commandName, projectName)
+		}
+
+		return nil, fmt.Errorf("no logs available for project '%s'", projectName)
+	}
+
+	return file, nil
+}
+
+// Read the logs of the last run of the given command of a project, or the combined logs
+// of all its commands if no command is given.
+//
+// Returns the lines and the offset in the log file to continue following the logs from.
+func (c *Core) ReadLogs(projectName string, commandName string, options LogOptions) ([]LogLine, int64, error) {
+	file, err := c.openLogs(projectName, commandName)
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	lines := []LogLine{}
+	var offset int64
+
+	for {
+		text, err := reader.ReadString('\n')
+
+		// Lines that are still being written are left for following the logs
+		if err != nil {
+			break
+		}
+
+		offset += int64(len(text))
+		line, ok := parseLogLine(text)
+
+		if !ok || (!options.Since.IsZero() && line.Time.Before(options.Since)) {
+			continue
+		}
+
+		lines = append(lines, line)
+	}
+
+	if options.Tail > 0 && len(lines) > options.Tail {
+		lines = lines[len(lines)-options.Tail:]
+	}
+
+	return lines, offset, nil
+}
+
+// Follow the logs of the given command of a project, or the combined logs if no command is given,
+// starting at the given offset. Calls onLine for every new line until the stop channel is closed.
+//
+// Log files that are rotated or recreated by running the project again are followed from the start.
+func (c *Core) FollowLogs(projectName string, commandName string, offset int64, stop <-chan struct{}, onLine func(LogLine)) error {
+	file, err := c.openLogs(projectName, commandName)
+
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		file.Close()
+	}()
+
+	_, err = file.Seek(offset, io.SeekStart)
+
+	if err != nil {
+		return err
+	}
+
+	reader := bufio.NewReader(file)
+	partial := ""
+
+	// Read all complete lines that were written since the last read
+	readLines := func() {
+		for {
+			line, err := reader.ReadString('\n')
+			partial += line
+			offset += int64(len(line))
+
+			if err != nil {
+				return
+			}
+
+			if line, ok := parseLogLine(partial); ok {
+				onLine(line)
+			}
+
+			partial = ""
+		}
+	}
+
+	ticker := time.NewTicker(logFollowInterval)
+	defer ticker.Stop()
+
+	for {
+		readLines()
+
+		select {
+		case <-stop:
+			return nil
+		case <-ticker.C:
+		}
+
+		openInfo, err := file.Stat()
+
+		if err != nil {
+			return err
+		}
+
+		pathInfo, err := os.Stat(file.Name())
+
+		// Switch to the new log file once it was rotated, after reading the rest of the old one
+		if err == nil && !os.SameFile(openInfo, pathInfo) {
+			newFile, err := os.Open(file.Name())
+
+			if err != nil {
+				continue
+			}
+
+			readLines()
+
+			file.Close()
+			file = newFile
+			reader = bufio.NewReader(file)
+			partial = ""
+			offset = 0
+			continue
+		}
+
+		// Start from the beginning if the log file was truncated
+		if openInfo.Size() < offset {
+			file.Seek(0, io.SeekStart)
+			reader.Reset(file)
+			partial = ""
+			offset = 0
+		}
+	}
+}
@@ -0,0 +1,216 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRotatedLogPath(t *testing.T) {
+	path := rotatedLogPath("/logs/test/combined.log", 2)
+
+	if path != "/logs/test/combined.2.log" {
+		t.Error("Expected /logs/test/combined.2.log, got", path)
+	}
+}
+
+func TestLogFileRetention(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "test.log")
+
+	for i := 0; i < logRetention+2; i++ {
+		l, err := openLogFile(logPath)
+
+		if err != nil {
+			t.Fatal("Expected no error opening log file, got", err)
+		}
+
+		l.Write([]byte(formatLogLine(time.Now(), "test", LogStdout, "run")))
+		l.Close()
+	}
+
+	for i := 1; i <= logRetention; i++ {
+		if _, err := os.Stat(rotatedLogPath(logPath, i)); err != nil {
+			t.Errorf("Expected rotated log file %d to exist, got %s", i, err)
+		}
+	}
+
+	if _, err := os.Stat(rotatedLogPath(logPath, logRetention+1)); !os.IsNotExist(err) {
+		t.Error("Expected no more than", logRetention, "rotated log files")
+	}
+
+	// Rotated log files that are too old are removed
+	old := time.Now().Add(-logRetentionAge - time.Hour)
+	os.Chtimes(rotatedLogPath(logPath, 3), old, old)
+
+	l, err := openLogFile(logPath)
+
+	if err != nil {
+		t.Fatal("Expected no error opening log file, got", err)
+	}
+
+	l.Close()
+
+	if _, err := os.Stat(rotatedLogPath(logPath, 4)); !os.IsNotExist(err) {
+		t.Error("Expected old rotated log file to be removed")
+	}
+}
+
+func TestLogFileRotateSize(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "test.log")
+	l, err := openLogFile(logPath)
+
+	if err != nil {
+		t.Fatal("Expected no error opening log file, got", err)
+	}
+
+	defer l.Close()
+
+	l.Write([]byte(strings.Repeat("a", logMaxSize-1) + "\n"))
+	l.Write([]byte("b\n"))
+
+	data, _ := os.ReadFile(logPath)
+
+	if string(data) != "b\n" {
+		t.Errorf("Expected log file to be rotated once it is too large, got %d bytes", len(data))
+	}
+
+	if info, err := os.Stat(rotatedLogPath(logPath, 1)); err != nil || info.Size() != logMaxSize {
+		t.Error("Expected rotated log file with the previous lines, got", err)
+	}
+}
+
+func TestRunLogs(t *testing.T) {
+	c := TestingCore("run_logs")
+
+	c.FetchCommands()
+	c.FetchProjects()
+
+	c.AddCommand("first", "echo first")
+	c.AddCommand("second", "echo second")
+	c.AddProject("test", 1234, []string{"first", "second"})
+
+	c.FetchProjects()
+
+	if _, _, err := c.ReadLogs("test", "", LogOptions{}); err == nil {
+		t.Error("Expected error when reading logs of a project that did not run, got nil")
+	}
+
+	c.TryToRun("test")
+
+	lines, offset, err := c.ReadLogs("test", "", LogOptions{})
+
+	if err != nil {
+		t.Fatal("Expected no error reading logs, got", err)
+	}
+
+	combined := []string{}
+
+	for _, line := range lines {
+		combined = append(combined, fmt.Sprintf("%s [%s] %s", line.Stream, line.Command, line.Text))
+	}
+
+	if !slices.Contains(combined, "stdout [first] first") || !slices.Contains(combined, "stdout [second] second") {
+		t.Error("Expected combined logs to contain the output of both commands, got", combined)
+	}
+
+	if !slices.Contains(combined, "stdout [spinup] Running project 'test'...") {
+		t.Error("Expected combined logs to contain the messages of spinup, got", combined)
+	}
+
+	if info, _ := os.Stat(c.config.GetLogFilePath("test", "")); info == nil || info.Size() != offset {
+		t.Error("Expected offset to be at the end of the log file, got", offset)
+	}
+
+	lines, _, err = c.ReadLogs("test", "first", LogOptions{})
+
+	if err != nil || len(lines) != 1 || lines[0].Command != "first" || lines[0].Text != "first" {
+		t.Error("Expected logs of command 'first' to only contain its output, got", lines, err)
+	}
+
+	if lines[0].Time.IsZero() || time.Since(lines[0].Time) > time.Minute {
+		t.Error("Expected log line to have the time it was written, got", lines[0].Time)
+	}
+
+	lines, _, _ = c.ReadLogs("test", "", LogOptions{Tail: 1})
+
+	if len(lines) != 1 {
+		t.Error("Expected 1 line with tail, got", lines)
+	}
+
+	lines, _, _ = c.ReadLogs("test", "", LogOptions{Since: time.Now().Add(time.Hour)})
+
+	if len(lines) != 0 {
+		t.Error("Expected no lines written after since, got", lines)
+	}
+
+	// The logs of the previous run are kept
+	c.TryToRun("test")
+
+	if _, err := os.Stat(rotatedLogPath(c.config.GetLogFilePath("test", "first"), 1)); err != nil {
+		t.Error("Expected logs of the previous run to be kept, got", err)
+	}
+}
+
+func TestFollowLogs(t *testing.T) {
+	c := TestingCore("follow_logs")
+
+	logPath := c.config.GetLogFilePath("test", "")
+	l, err := openLogFile(logPath)
+
+	if err != nil {
+		t.Fatal("Expected no error opening log file, got", err)
+	}
+
+	defer l.Close()
+
+	l.Write([]byte(formatLogLine(time.Now(), "test", LogStdout, "before")))
+
+	_, offset, _ := c.ReadLogs("test", "", LogOptions{})
+
+	stop := make(chan struct{})
+	lines := make(chan LogLine, 10)
+	done := make(chan error)
+
+	go func() {
+		done <- c.FollowLogs("test", "", offset, stop, func(line LogLine) {
+			lines <- line
+		})
+	}()
+
+	l.Write([]byte(formatLogLine(time.Now(), "test", LogStdout, "after")))
+
+	select {
+	case line := <-lines:
+		if line.Text != "after" {
+			t.Error("Expected followed line to be written after the offset, got", line)
+		}
+	case <-time.After(2 * time.Second):
+		t.Error("Expected new line to be followed")
+	}
+
+	// Lines written after the log file was rotated are followed as well
+	l.mu.Lock()
+	l.rotate()
+	l.mu.Unlock()
+
+	l.Write([]byte(formatLogLine(time.Now(), "test", LogStdout, "rotated")))
+
+	select {
+	case line := <-lines:
+		if line.Text != "rotated" {
+			t.Error("Expected followed line of the rotated log file, got", line)
+		}
+	case <-time.After(2 * time.Second):
+		t.Error("Expected line of the rotated log file to be followed")
+	}
+
+	close(stop)
+
+	if err := <-done; err != nil {
+		t.Error("Expected no error following logs, got", err)
+	}
+}
@@ -14,9 +14,13 @@ import (
 // Project is a struct that represents a project and its linked structs.
 type Project struct {
 	sqlc.Project
+	Domain        string
 	Commands      []Command
 	Variables     []Variable
+	EnvVariables  []EnvVariable
+	EnvFiles      []EnvFile
 	DomainAliases []DomainAlias
+	Routes        []Route
 }
 
 // Projects is a map of project names to their Projects.
@@ -35,17 +39,39 @@ func (c *Core) getProjectWithInfo(project sqlc.Project) (Project, error) {
 		return Project{}, fmt.Errorf("error getting project variables: %s", err)
 	}
 
+	projectEnvVariables, err := c.dbQueries.GetProjectEnvVariables(c.dbContext, project.ID)
+
+	if err != nil {
+		return Project{}, fmt.Errorf("error getting project environment variables: %s", err)
+	}
+
+	projectEnvFiles, err := c.dbQueries.GetProjectEnvFiles(c.dbContext, project.ID)
+
+	if err != nil {
+		return Project{}, fmt.Errorf("error getting project env files: %s", err)
+	}
+
 	projectDomainAliases, err := c.dbQueries.GetProjectDomainAliases(c.dbContext, project.ID)
 
 	if err != nil {
 		return Project{}, fmt.Errorf("error getting project domain aliases: %s", err)
 	}
 
+	projectRoutes, err := c.dbQueries.GetProjectRoutes(c.dbContext, project.ID)
+
+	if err != nil {
+		return Project{}, fmt.Errorf("error getting project routes: %s", err)
+	}
+
 	return Project{
 		Project:       project,
+		Domain:        c.config.GetDomain(project.Name),
 		Commands:      projectCommands,
 		Variables:     projectVariables,
+		EnvVariables:  projectEnvVariables,
+		EnvFiles:      projectEnvFiles,
 		DomainAliases: projectDomainAliases,
+		Routes:        projectRoutes,
 	}, nil
 }
 
@@ -145,10 +171,22 @@ func (c *Core) AddProject(name string, port int64, commandNames []string) common
 		}
 	}
 
-	err := c.config.AddNginxConfig(name, port)
+	proxy, err := c.reverseProxy()
 
 	if err != nil {
-		return common.NewErrMsg(fmt.Sprintln("Error trying to create nginx config file", err))
+		return common.NewErrMsg("%s", err)
+	}
+
+	_, err = c.ensureCertificate(name, nil)
+
+	if err != nil {
+		return common.NewErrMsg("%s", err)
+	}
+
+	err = proxy.AddSite(name, port, nil)
+
+	if err != nil {
+		return common.NewErrMsg(fmt.Sprintln("Error trying to create "+proxy.Name()+" config file", err))
 	}
 
 	project, err := c.dbQueries.CreateProject(c.dbContext, sqlc.CreateProjectParams{
@@ -182,10 +220,22 @@ func (c *Core) RemoveProject(name string) common.Msg {
 		return common.NewErrMsg("Project '" + name + "' does not exist, nothing to remove")
 	}
 
-	err := c.config.RemoveNginxConfig(name)
+	proxy, err := c.reverseProxy()
+
+	if err != nil {
+		return common.NewErrMsg("%s", err)
+	}
+
+	err = proxy.RemoveSite(name)
+
+	if err != nil {
+		return common.NewErrMsg("Could not remove %s config file: %s", proxy.Name(), err)
+	}
+
+	err = c.config.RemoveCertificate(name)
 
 	if err != nil {
-		return common.NewErrMsg("Could not remove nginx config file: " + err.Error())
+		return common.NewErrMsg("Could not remove certificate: %s", err)
 	}
 
 	c.dbQueries.DeleteProject(c.dbContext, name)
@@ -200,10 +250,22 @@ func (c *Core) RemoveProjectById(projectID int64) common.Msg {
 		return common.NewErrMsg("Project with id %d does not exist, nothing to remove", projectID)
 	}
 
-	err := c.config.RemoveNginxConfig(project.Name)
+	proxy, err := c.reverseProxy()
 
 	if err != nil {
-		return common.NewErrMsg("Could not remove nginx config file: %s", err)
+		return common.NewErrMsg("%s", err)
+	}
+
+	err = proxy.RemoveSite(project.Name)
+
+	if err != nil {
+		return common.NewErrMsg("Could not remove %s config file: %s", proxy.Name(), err)
+	}
+
+	err = c.config.RemoveCertificate(project.Name)
+
+	if err != nil {
+		return common.NewErrMsg("Could not remove certificate: %s", err)
 	}
 
 	err = c.dbQueries.DeleteProjectById(c.dbContext, projectID)
@@ -300,13 +362,7 @@ func (c *Core) UpdateProject(name string, port int64, commandNames []string) com
 		}
 	}
 
-	err := c.config.UpdateNginxConfig(name, port)
-
-	if err != nil {
-		return common.NewErrMsg("Error trying to update nginx config file: %s", err)
-	}
-
-	err = c.dbQueries.UpdateProject(c.dbContext, sqlc.UpdateProjectParams{
+	err := c.dbQueries.UpdateProject(c.dbContext, sqlc.UpdateProjectParams{
 		Name: name,
 		Port: port,
 		Dir: sql.NullString{
@@ -325,6 +381,12 @@ func (c *Core) UpdateProject(name string, port int64, commandNames []string) com
 		return common.NewErrMsg("Error updating project commands: %s", err)
 	}
 
+	err = c.writeProxyConfig(name)
+
+	if err != nil {
+		return common.NewErrMsg("Error trying to update reverse proxy config file: %s", err)
+	}
+
 	return common.NewSuccessMsg("Updated project '%s' with domain '%s', port %d and commands %s", name, port, commandNames)
 }
 
@@ -355,10 +417,23 @@ func (c *Core) UpdateProjectByID(projectID int64, name string, port int64, comma
 		}
 	}
 
-	err := c.config.RenameNginxConfig(project.Name, name)
+	proxy, err := c.reverseProxy()
+
+	if err != nil {
+		return common.NewErrMsg("%s", err)
+	}
+
+	// The configuration file of the project with the new name uses its certificate
+	_, err = c.ensureCertificate(name, projectDomainAliases(project))
 
 	if err != nil {
-		return common.NewErrMsg("Error trying to update nginx config file: %s", err)
+		return common.NewErrMsg("%s", err)
+	}
+
+	err = proxy.RenameSite(project.Name, name)
+
+	if err != nil {
+		return common.NewErrMsg("Error trying to update %s config file: %s", proxy.Name(), err)
 	}
 
 	err = c.dbQueries.UpdateProjectById(c.dbContext, sqlc.UpdateProjectByIdParams{
@@ -381,12 +456,26 @@ func (c *Core) UpdateProjectByID(projectID int64, name string, port int64, comma
 		return common.NewErrMsg("Error updating project commands: %s", err)
 	}
 
+	err = c.writeProxyConfig(name)
+
+	if err != nil {
+		return common.NewErrMsg("Error trying to update reverse proxy config file: %s", err)
+	}
+
+	if project.Name != name {
+		err = c.config.RemoveCertificate(project.Name)
+
+		if err != nil {
+			return common.NewErrMsg("Could not remove certificate: %s", err)
+		}
+	}
+
 	return common.NewSuccessMsg("Updated project '%s' with domain '%s', port %d and commands %s", name, port, commandNames)
 }
 
 // Rename the project with the given old name to the given new name.
 func (c *Core) RenameProject(oldName string, newName string) common.Msg {
-	exists, _ := c.ProjectExists(oldName)
+	exists, project := c.ProjectExists(oldName)
 
 	if !exists {
 		return common.NewErrMsg("Project '%s' does not exist", oldName)
@@ -398,10 +487,23 @@ func (c *Core) RenameProject(oldName string, newName string) common.Msg {
 		return common.NewErrMsg("Project '%s' already exists", newName)
 	}
 
-	err := c.config.RenameNginxConfig(oldName, newName)
+	proxy, err := c.reverseProxy()
+
+	if err != nil {
+		return common.NewErrMsg("%s", err)
+	}
+
+	// The configuration file of the project with the new name uses its certificate
+	_, err = c.ensureCertificate(newName, projectDomainAliases(project))
+
+	if err != nil {
+		return common.NewErrMsg("%s", err)
+	}
+
+	err = proxy.RenameSite(oldName, newName)
 
 	if err != nil {
-		return common.NewErrMsg("Error trying to rename nginx config file: %s", err)
+		return common.NewErrMsg("Error trying to rename %s config file: %s", proxy.Name(), err)
 	}
 
 	err = c.dbQueries.RenameProject(c.dbContext, sqlc.RenameProjectParams{
@@ -413,6 +515,12 @@ func (c *Core) RenameProject(oldName string, newName string) common.Msg {
 		return common.NewErrMsg("Error renaming project in database: %s", err)
 	}
 
+	err = c.config.RemoveCertificate(oldName)
+
+	if err != nil {
+		return common.NewErrMsg("Could not remove certificate: %s", err)
+	}
+
 	return common.NewSuccessMsg("Renamed project '%s' to '%s'", oldName, newName)
 }
 
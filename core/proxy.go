@@ -0,0 +1,384 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/iskandervdh/spinup/common"
+	"github.com/iskandervdh/spinup/config"
+	"github.com/iskandervdh/spinup/proxy"
+)
+
+// States of a reverse proxy configuration file compared to the one that is generated from the database.
+const (
+	ProxyConfigOK       = "ok"
+	ProxyConfigMissing  = "missing"
+	ProxyConfigOutdated = "outdated"
+	ProxyConfigOrphan   = "orphan"
+)
+
+// ProxyConfigCheck is the state of the reverse proxy configuration file of a project, or of a generated
+// configuration file of a project that no longer exists.
+//
+// Diff contains the lines that would be removed and added when the file is generated from the database.
+type ProxyConfigCheck struct {
+	Name  string
+	State string
+	Diff  string
+}
+
+// Get the values of the domain aliases of the given project.
+func projectDomainAliases(project Project) []string {
+	domainAliases := []string{}
+
+	for _, domainAlias := range project.DomainAliases {
+		domainAliases = append(domainAliases, domainAlias.Value)
+	}
+
+	return domainAliases
+}
+
+// Get the routes of the given project for the reverse proxy.
+func projectRoutes(project Project) []config.Route {
+	routes := []config.Route{}
+
+	for _, route := range project.Routes {
+		routes = append(routes, config.Route{Path: route.Path, Port: route.Port, StripPrefix: route.StripPrefix})
+	}
+
+	return routes
+}
+
+// Get the reverse proxy backend that is selected in the settings.
+func (c *Core) reverseProxy() (config.ReverseProxy, error) {
+	proxy, err := c.config.GetReverseProxy()
+
+	if err != nil {
+		return nil, fmt.Errorf("error getting reverse proxy: %s", err)
+	}
+
+	return proxy, nil
+}
+
+// Write the reverse proxy configuration file of the project with the given name from its state in the database.
+func (c *Core) writeProxyConfig(name string) error {
+	proxy, err := c.reverseProxy()
+
+	if err != nil {
+		return err
+	}
+
+	project, err := c.dbQueries.GetProject(c.dbContext, name)
+
+	if err != nil {
+		return err
+	}
+
+	projectWithInfo, err := c.getProjectWithInfo(project)
+
+	if err != nil {
+		return err
+	}
+
+	err = proxy.WriteSite(project.Name, project.Port, projectDomainAliases(projectWithInfo), projectRoutes(projectWithInfo))
+
+	if err != nil {
+		return err
+	}
+
+	return proxy.Reload()
+}
+
+// Get the lines that differ between the before and after text, prefixed with - and + like a diff.
+func diffLines(before string, after string) string {
+	var beforeLines, afterLines []string
+
+	if before != "" {
+		beforeLines = strings.Split(strings.TrimSuffix(before, "\n"), "\n")
+	}
+
+	if after != "" {
+		afterLines = strings.Split(strings.TrimSuffix(after, "\n"), "\n")
+	}
+
+	// Length of the longest common subsequence of the remaining lines, starting at every pair of lines
+	lengths := make([][]int, len(beforeLines)+1)
+
+	for i := range lengths {
+		lengths[i] = make([]int, len(afterLines)+1)
+	}
+
+	for i := len(beforeLines) - 1; i >= 0; i-- {
+		for j := len(afterLines) - 1; j >= 0; j-- {
+			if beforeLines[i] == afterLines[j] {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else {
+				lengths[i][j] = max(lengths[i+1][j], lengths[i][j+1])
+			}
+		}
+	}
+
+	var builder strings.Builder
+	i, j := 0, 0
+
+	for i < len(beforeLines) || j < len(afterLines) {
+		switch {
+		case i < len(beforeLines) && j < len(afterLines) && beforeLines[i] == afterLines[j]:
+			i++
+			j++
+		case j >= len(afterLines) || (i < len(beforeLines) && lengths[i+1][j] >= lengths[i][j+1]):
+			fmt.Fprintf(&builder, "-%s\n", beforeLines[i])
+			i++
+		default:
+			fmt.Fprintf(&builder, "+%s\n", afterLines[j])
+			j++
+		}
+	}
+
+	return builder.String()
+}
+
+// Compare the reverse proxy configuration files on disk with the ones that are generated from the database.
+//
+// The checks of the projects are followed by the generated configuration files of projects that no longer exist.
+func (c *Core) CheckProxyConfigs() ([]ProxyConfigCheck, error) {
+	proxy, err := c.reverseProxy()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return c.checkProxyConfigs(proxy)
+}
+
+// Compare the configuration files of the given reverse proxy on disk with the ones that are generated from the database.
+func (c *Core) checkProxyConfigs(proxy config.ReverseProxy) ([]ProxyConfigCheck, error) {
+	err := c.FetchProjects()
+
+	if err != nil {
+		return nil, err
+	}
+
+	configs, err := proxy.ReadSites()
+
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s configs: %s", proxy.Name(), err)
+	}
+
+	checks := []ProxyConfigCheck{}
+
+	for _, project := range c.projects {
+		expected := proxy.RenderSite(project.Name, project.Port, projectDomainAliases(project), projectRoutes(project))
+		actual, exists := configs[project.Name]
+
+		switch {
+		case proxy.Name() == config.ReverseProxyBuiltin:
+			// The built-in reverse proxy routes using the database, there are no configuration files
+			checks = append(checks, ProxyConfigCheck{project.Name, ProxyConfigOK, ""})
+		case !exists:
+			checks = append(checks, ProxyConfigCheck{project.Name, ProxyConfigMissing, diffLines("", expected)})
+		case actual != expected:
+			checks = append(checks, ProxyConfigCheck{project.Name, ProxyConfigOutdated, diffLines(actual, expected)})
+		default:
+			checks = append(checks, ProxyConfigCheck{project.Name, ProxyConfigOK, ""})
+		}
+	}
+
+	names := make([]string, 0, len(configs))
+
+	for name := range configs {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	for _, name := range names {
+		if exists, _ := c.ProjectExists(name); exists || !proxy.IsGeneratedSite(name, configs[name]) {
+			continue
+		}
+
+		checks = append(checks, ProxyConfigCheck{name, ProxyConfigOrphan, diffLines(configs[name], "")})
+	}
+
+	return checks, nil
+}
+
+// Generate the reverse proxy configuration files of all projects that are missing or outdated and,
+// if removeOrphans is set, remove the generated configuration files of projects that no longer exist.
+//
+// Returns the checks of the configuration files from before they were synced.
+func (c *Core) syncProxyConfigs(removeOrphans bool) ([]ProxyConfigCheck, error) {
+	proxy, err := c.reverseProxy()
+
+	if err != nil {
+		return nil, err
+	}
+
+	checks, err := c.checkProxyConfigs(proxy)
+
+	if err != nil {
+		return nil, err
+	}
+
+	changed := false
+
+	// Issue the certificates that are missing or do not cover the domain aliases of their project
+	for _, project := range c.projects {
+		issued, err := c.ensureCertificate(project.Name, projectDomainAliases(project))
+
+		if err != nil {
+			return nil, err
+		}
+
+		changed = changed || issued
+	}
+
+	for _, check := range checks {
+		switch check.State {
+		case ProxyConfigMissing, ProxyConfigOutdated:
+			_, project := c.ProjectExists(check.Name)
+			err = proxy.WriteSite(project.Name, project.Port, projectDomainAliases(project), projectRoutes(project))
+		case ProxyConfigOrphan:
+			if !removeOrphans {
+				continue
+			}
+
+			err = os.Remove(proxy.GetSitePath(check.Name))
+		default:
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		changed = true
+	}
+
+	if changed {
+		err = proxy.Reload()
+
+		if err != nil {
+			return nil, fmt.Errorf("error reloading %s: %s", proxy.Name(), err)
+		}
+	}
+
+	return checks, nil
+}
+
+// Generate the reverse proxy configuration files of all projects from the database, replacing the ones that differ.
+//
+// Generated configuration files of projects that no longer exist are only reported, unless removeOrphans is set.
+func (c *Core) SyncProxyConfigs(removeOrphans bool) common.Msg {
+	proxy, err := c.reverseProxy()
+
+	if err != nil {
+		return common.NewErrMsg("%s", err)
+	}
+
+	checks, err := c.syncProxyConfigs(removeOrphans)
+
+	if err != nil {
+		return common.NewErrMsg("Error syncing %s configs: %s", proxy.Name(), err)
+	}
+
+	written, removed, upToDate := 0, 0, 0
+
+	for _, check := range checks {
+		switch check.State {
+		case ProxyConfigMissing, ProxyConfigOutdated:
+			c.sendMsg(common.NewInfoMsg("Wrote %s %s config of project '%s'", check.State, proxy.Name(), check.Name))
+			written++
+		case ProxyConfigOrphan:
+			if !removeOrphans {
+				c.sendMsg(common.NewWarnMsg("Found %s config '%s' of a project that does not exist", proxy.Name(), proxy.GetSitePath(check.Name)))
+				continue
+			}
+
+			c.sendMsg(common.NewInfoMsg("Removed %s config '%s' of a project that does not exist", proxy.Name(), proxy.GetSitePath(check.Name)))
+			removed++
+		default:
+			upToDate++
+		}
+	}
+
+	return common.NewSuccessMsg("Synced %s configs: %d written, %d removed, %d up to date", proxy.Name(), written, removed, upToDate)
+}
+
+// Get the routes of the built-in reverse proxy from the database, mapping the domain and the domain aliases
+// of every project to a route for / with its port, unless the project has a route for / itself, and its routes.
+func (c *Core) GetProxyRoutes() (map[string][]proxy.Route, error) {
+	err := c.FetchProjects()
+
+	if err != nil {
+		return nil, err
+	}
+
+	routes := map[string][]proxy.Route{}
+
+	for _, project := range c.projects {
+		projectRoutes := []proxy.Route{}
+		rootRoute := proxy.Route{Path: "/", Port: project.Port}
+
+		for _, route := range project.Routes {
+			if route.Path == "/" {
+				rootRoute.Port = route.Port
+				continue
+			}
+
+			projectRoutes = append(projectRoutes, proxy.Route{Path: route.Path, Port: route.Port, StripPrefix: route.StripPrefix})
+		}
+
+		projectRoutes = append(projectRoutes, rootRoute)
+
+		for _, domain := range append([]string{c.config.GetDomain(project.Name)}, projectDomainAliases(project)...) {
+			routes[strings.ToLower(domain)] = slices.Clone(projectRoutes)
+		}
+	}
+
+	return routes, nil
+}
+
+// Check that the configuration files of the reverse proxy are valid.
+func (c *Core) ValidateProxyConfigs() common.Msg {
+	proxy, err := c.reverseProxy()
+
+	if err != nil {
+		return common.NewErrMsg("%s", err)
+	}
+
+	err = proxy.Validate()
+
+	if err != nil {
+		return common.NewErrMsg("Invalid %s config: %s", proxy.Name(), err)
+	}
+
+	return common.NewSuccessMsg("The %s config is valid", proxy.Name())
+}
+
+// Select the reverse proxy backend with the given name and generate the configuration files of all projects for it.
+//
+// The configuration files of the previous backend are left in place, so switching back does not lose manual changes.
+func (c *Core) UseReverseProxy(name string) common.Msg {
+	proxy, err := c.config.NewReverseProxy(name)
+
+	if err != nil {
+		return common.NewErrMsg("%s", err)
+	}
+
+	err = proxy.Init()
+
+	if err != nil {
+		return common.NewErrMsg("Error initializing %s: %s", name, err)
+	}
+
+	err = c.config.SetReverseProxy(name)
+
+	if err != nil {
+		return common.NewErrMsg("Error saving reverse proxy setting: %s", err)
+	}
+
+	return c.SyncProxyConfigs(false)
+}
@@ -0,0 +1,214 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/iskandervdh/spinup/common"
+	"github.com/iskandervdh/spinup/proxy"
+)
+
+func TestCheckProxyConfigs(t *testing.T) {
+	c := TestingCore("check_nginx_configs")
+
+	c.FetchCommands()
+	c.FetchProjects()
+
+	c.AddProject("example", 1234, []string{})
+	c.AddProject("missing", 1235, []string{})
+
+	nginxConfigDir := c.config.GetNginxConfigDir()
+
+	os.WriteFile(filepath.Join(nginxConfigDir, "example.conf"), []byte(strings.Replace(c.config.RenderNginxConfig("example", 1234, nil), "1234", "4321", 1)), 0644)
+	os.Remove(filepath.Join(nginxConfigDir, "missing.conf"))
+	os.WriteFile(filepath.Join(nginxConfigDir, "orphan.conf"), []byte("server {\n    server_name orphan.test;\n}\n"), 0644)
+	os.WriteFile(filepath.Join(nginxConfigDir, "manual.conf"), []byte("server {\n    server_name manual.local;\n}\n"), 0644)
+
+	checks, err := c.CheckProxyConfigs()
+
+	if err != nil {
+		t.Fatal("Expected nginx configs to be checked, got", err)
+	}
+
+	states := map[string]string{}
+
+	for _, check := range checks {
+		states[check.Name] = check.State
+
+		if check.Name == "example" && !strings.Contains(check.Diff, "-\t\tproxy_pass http://127.0.0.1:4321/;\n+\t\tproxy_pass http://127.0.0.1:1234/;\n") {
+			t.Error("Expected diff of the outdated config to contain the changed port, got", check.Diff)
+		}
+	}
+
+	expected := map[string]string{
+		"example": ProxyConfigOutdated,
+		"missing": ProxyConfigMissing,
+		"orphan":  ProxyConfigOrphan,
+	}
+
+	if len(states) != len(expected) {
+		t.Error("Expected checks", expected, "got", states)
+	}
+
+	for name, state := range expected {
+		if states[name] != state {
+			t.Errorf("Expected nginx config of '%s' to be %s, got %s", name, state, states[name])
+		}
+	}
+}
+
+func TestSyncProxyConfigs(t *testing.T) {
+	c := TestingCore("sync_nginx_configs")
+
+	c.FetchCommands()
+	c.FetchProjects()
+
+	c.AddProject("example", 1234, []string{})
+	c.FetchProjects()
+	c.AddDomainAlias("example", "example.localhost")
+
+	nginxConfigDir := c.config.GetNginxConfigDir()
+
+	os.Remove(filepath.Join(nginxConfigDir, "example.conf"))
+	os.WriteFile(filepath.Join(nginxConfigDir, "orphan.conf"), []byte("server {\n    server_name orphan.test;\n}\n"), 0644)
+
+	msg := c.SyncProxyConfigs(false)
+
+	if msg.GetText() != "Synced nginx configs: 1 written, 0 removed, 0 up to date" {
+		t.Error("Expected missing config to be written, got", msg.GetText())
+	}
+
+	nginxConfig, err := os.ReadFile(filepath.Join(nginxConfigDir, "example.conf"))
+
+	if err != nil || !strings.Contains(string(nginxConfig), "server_name example.test example.localhost;") {
+		t.Error("Expected nginx config to be generated from the database, got", string(nginxConfig), err)
+	}
+
+	if _, err := os.Stat(filepath.Join(nginxConfigDir, "orphan.conf")); err != nil {
+		t.Error("Expected orphan config not to be removed without removeOrphans, got", err)
+	}
+
+	msg = c.SyncProxyConfigs(true)
+
+	if msg.GetText() != "Synced nginx configs: 0 written, 1 removed, 1 up to date" {
+		t.Error("Expected orphan config to be removed, got", msg.GetText())
+	}
+
+	if _, err := os.Stat(filepath.Join(nginxConfigDir, "orphan.conf")); !os.IsNotExist(err) {
+		t.Error("Expected orphan config to be removed with removeOrphans")
+	}
+}
+
+func TestUpdateProjectKeepsDomainAliases(t *testing.T) {
+	c := TestingCore("update_project_keeps_domain_aliases")
+
+	c.FetchCommands()
+	c.FetchProjects()
+
+	c.AddProject("example", 1234, []string{})
+	c.FetchProjects()
+	c.AddDomainAlias("example", "example.localhost")
+
+	msg := c.UpdateProject("example", 4321, []string{})
+
+	if _, ok := msg.(*common.SuccessMsg); !ok {
+		t.Fatal("Expected project to be updated, got", msg.GetText())
+	}
+
+	nginxConfig, _ := os.ReadFile(filepath.Join(c.config.GetNginxConfigDir(), "example.conf"))
+
+	if !strings.Contains(string(nginxConfig), "server_name example.test example.localhost;") || !strings.Contains(string(nginxConfig), "127.0.0.1:4321") {
+		t.Error("Expected nginx config to have the new port and keep the domain aliases, got", string(nginxConfig))
+	}
+
+	c.RenameProject("example", "renamed")
+
+	nginxConfig, _ = os.ReadFile(filepath.Join(c.config.GetNginxConfigDir(), "renamed.conf"))
+
+	if !strings.Contains(string(nginxConfig), "server_name renamed.test example.localhost;") {
+		t.Error("Expected nginx config of the renamed project to have the new domain, got", string(nginxConfig))
+	}
+}
+
+func TestUseReverseProxy(t *testing.T) {
+	c := TestingCore("use_reverse_proxy")
+
+	c.FetchCommands()
+	c.FetchProjects()
+
+	c.AddProject("example", 1234, []string{})
+	c.FetchProjects()
+
+	msg := c.UseReverseProxy("caddy")
+
+	if msg.GetText() != "Synced caddy configs: 1 written, 0 removed, 0 up to date" {
+		t.Fatal("Expected caddy config of the project to be written, got", msg.GetText())
+	}
+
+	c.AddDomainAlias("example", "example.localhost")
+
+	proxy, _ := c.config.GetReverseProxy()
+	caddyConfig, _ := os.ReadFile(proxy.GetSitePath("example"))
+
+	if !strings.Contains(string(caddyConfig), "http://example.test, http://example.localhost {") {
+		t.Error("Expected domain alias to be added to the caddy config, got", string(caddyConfig))
+	}
+
+	if _, err := os.Stat(filepath.Join(c.config.GetNginxConfigDir(), "example.conf")); err != nil {
+		t.Error("Expected nginx config to be left in place, got", err)
+	}
+
+	if _, ok := c.UseReverseProxy("apache").(*common.ErrMsg); !ok {
+		t.Error("Expected error when using an unknown reverse proxy")
+	}
+}
+
+func TestGetProxyRoutes(t *testing.T) {
+	c := TestingCore("get_proxy_routes")
+
+	c.FetchCommands()
+	c.FetchProjects()
+
+	c.AddProject("example", 1234, []string{})
+	c.AddProject("other", 1235, []string{})
+	c.FetchProjects()
+	c.AddDomainAlias("example", "Example.localhost")
+	c.AddRoute("example", "/api", 2345, true)
+	c.AddRoute("other", "/", 2346, false)
+
+	routes, err := c.GetProxyRoutes()
+
+	if err != nil {
+		t.Fatal("Expected routes, got", err)
+	}
+
+	exampleRoutes := []proxy.Route{{Path: "/api", Port: 2345, StripPrefix: true}, {Path: "/", Port: 1234}}
+	expected := map[string][]proxy.Route{
+		"example.test":      exampleRoutes,
+		"example.localhost": exampleRoutes,
+		"other.test":        {{Path: "/", Port: 2346}},
+	}
+
+	if len(routes) != len(expected) {
+		t.Error("Expected routes", expected, "got", routes)
+	}
+
+	for domain, domainRoutes := range expected {
+		if !slices.Equal(routes[domain], domainRoutes) {
+			t.Errorf("Expected %s to have routes %v, got %v", domain, domainRoutes, routes[domain])
+		}
+	}
+
+	c.UseReverseProxy("builtin")
+
+	checks, _ := c.CheckProxyConfigs()
+
+	for _, check := range checks {
+		if check.State != ProxyConfigOK {
+			t.Errorf("Expected the built-in reverse proxy to have no configs to sync, got %s for '%s'", check.State, check.Name)
+		}
+	}
+}
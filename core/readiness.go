@@ -0,0 +1,192 @@
+package core
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Types of readiness checks that can be configured for a command.
+const (
+	ReadyCheckNone  = ""
+	ReadyCheckTCP   = "tcp"
+	ReadyCheckHTTP  = "http"
+	ReadyCheckLog   = "log"
+	ReadyCheckDelay = "delay"
+)
+
+// How long to wait for a command to become ready before giving up.
+const readinessTimeout = 2 * time.Minute
+
+// How often TCP and HTTP readiness checks are retried.
+const readinessInterval = 250 * time.Millisecond
+
+// readinessProbe determines when a running command is ready to be used by the commands that depend on it.
+type readinessProbe struct {
+	check   string
+	address string
+	url     string
+	regex   *regexp.Regexp
+	delay   time.Duration
+}
+
+// Check if the given readiness check and value are valid, ignoring any placeholders in the value.
+func validateReadiness(check string, value string) error {
+	_, err := newReadinessProbe(check, value, 0)
+
+	return err
+}
+
+// Create a new readiness probe for the given check and (templated) value.
+//
+// TCP checks accept a port or host:port and HTTP checks accept a path or full URL,
+// both default to the port of the project. Log checks accept a regular expression
+// and delay checks accept a duration like 5s or a number of seconds.
+func newReadinessProbe(check string, value string, port int64) (readinessProbe, error) {
+	probe := readinessProbe{check: check}
+
+	switch check {
+	case ReadyCheckNone:
+	case ReadyCheckTCP:
+		switch {
+		case value == "":
+			probe.address = fmt.Sprintf("127.0.0.1:%d", port)
+		case isNumeric(value):
+			probe.address = "127.0.0.1:" + value
+		default:
+			probe.address = value
+		}
+	case ReadyCheckHTTP:
+		switch {
+		case value == "":
+			probe.url = fmt.Sprintf("http://127.0.0.1:%d/", port)
+		case strings.HasPrefix(value, "/"):
+			probe.url = fmt.Sprintf("http://127.0.0.1:%d%s", port, value)
+		default:
+			probe.url = value
+		}
+	case ReadyCheckLog:
+		if value == "" {
+			return probe, fmt.Errorf("log readiness check requires a regular expression")
+		}
+
+		regex, err := regexp.Compile(value)
+
+		if err != nil {
+			return probe, fmt.Errorf("invalid regular expression '%s': %s", value, err)
+		}
+
+		probe.regex = regex
+	case ReadyCheckDelay:
+		if isNumeric(value) {
+			seconds, _ := strconv.Atoi(value)
+			probe.delay = time.Duration(seconds) * time.Second
+			break
+		}
+
+		delay, err := time.ParseDuration(value)
+
+		if err != nil {
+			return probe, fmt.Errorf("invalid delay '%s': %s", value, err)
+		}
+
+		probe.delay = delay
+	default:
+		return probe, fmt.Errorf("unknown readiness check '%s', expected 'tcp', 'http', 'log' or 'delay'", check)
+	}
+
+	return probe, nil
+}
+
+func isNumeric(value string) bool {
+	if value == "" {
+		return false
+	}
+
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Check if the given output line of the command marks it as ready.
+func (p readinessProbe) matchesLine(line string) bool {
+	return p.regex != nil && p.regex.MatchString(line)
+}
+
+// Wait until the probe succeeds, the timeout is reached or the given stop channel is closed.
+//
+// Log checks are handled while reading the output of the command, so waiting for them only ends on timeout or stop.
+func (p readinessProbe) wait(ready <-chan struct{}, stop <-chan struct{}) error {
+	timeout := time.After(readinessTimeout)
+
+	if p.check == ReadyCheckDelay {
+		select {
+		case <-time.After(p.delay):
+			return nil
+		case <-stop:
+			return nil
+		}
+	}
+
+	ticker := time.NewTicker(readinessInterval)
+	defer ticker.Stop()
+
+	for {
+		switch p.check {
+		case ReadyCheckNone:
+			return nil
+		case ReadyCheckTCP:
+			conn, err := net.DialTimeout("tcp", p.address, time.Second)
+
+			if err == nil {
+				conn.Close()
+				return nil
+			}
+		case ReadyCheckHTTP:
+			client := http.Client{Timeout: time.Second}
+			response, err := client.Get(p.url)
+
+			if err == nil {
+				response.Body.Close()
+
+				if response.StatusCode >= 200 && response.StatusCode < 300 {
+					return nil
+				}
+			}
+		}
+
+		select {
+		case <-ready:
+			return nil
+		case <-stop:
+			return nil
+		case <-timeout:
+			return fmt.Errorf("not ready within %s", readinessTimeout)
+		case <-ticker.C:
+		}
+	}
+}
+
+// Describe the probe in a human readable way.
+func (p readinessProbe) String() string {
+	switch p.check {
+	case ReadyCheckTCP:
+		return fmt.Sprintf("port %s to accept connections", p.address)
+	case ReadyCheckHTTP:
+		return fmt.Sprintf("%s to respond with a 2xx status", p.url)
+	case ReadyCheckLog:
+		return fmt.Sprintf("output matching '%s'", p.regex)
+	case ReadyCheckDelay:
+		return fmt.Sprintf("%s to pass", p.delay)
+	}
+
+	return "the command to start"
+}
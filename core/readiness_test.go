@@ -0,0 +1,141 @@
+package core
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestNewReadinessProbe(t *testing.T) {
+	tests := []struct {
+		check    string
+		value    string
+		expected string
+	}{
+		{ReadyCheckTCP, "", "127.0.0.1:1234"},
+		{ReadyCheckTCP, "5432", "127.0.0.1:5432"},
+		{ReadyCheckTCP, "localhost:5432", "localhost:5432"},
+		{ReadyCheckHTTP, "", "http://127.0.0.1:1234/"},
+		{ReadyCheckHTTP, "/health", "http://127.0.0.1:1234/health"},
+		{ReadyCheckHTTP, "http://localhost:3000/ready", "http://localhost:3000/ready"},
+	}
+
+	for _, test := range tests {
+		probe, err := newReadinessProbe(test.check, test.value, 1234)
+
+		if err != nil {
+			t.Error("Expected no error for", test.check, test.value, "got", err)
+			continue
+		}
+
+		if probe.address != test.expected && probe.url != test.expected {
+			t.Error("Expected", test.expected, "got", probe.address, probe.url)
+		}
+	}
+
+	probe, err := newReadinessProbe(ReadyCheckDelay, "3", 1234)
+
+	if err != nil || probe.delay != 3*time.Second {
+		t.Error("Expected delay of 3 seconds, got", probe.delay, err)
+	}
+
+	probe, err = newReadinessProbe(ReadyCheckDelay, "500ms", 1234)
+
+	if err != nil || probe.delay != 500*time.Millisecond {
+		t.Error("Expected delay of 500ms, got", probe.delay, err)
+	}
+
+	if _, err := newReadinessProbe("unknown", "", 1234); err == nil {
+		t.Error("Expected error for unknown readiness check, got nil")
+	}
+}
+
+func TestReadinessProbeLog(t *testing.T) {
+	probe, err := newReadinessProbe(ReadyCheckLog, `listening on port \d+`, 1234)
+
+	if err != nil {
+		t.Error("Expected no error, got", err)
+		return
+	}
+
+	if !probe.matchesLine("Server listening on port 3000") {
+		t.Error("Expected line to match")
+	}
+
+	if probe.matchesLine("Starting server...") {
+		t.Error("Expected line not to match")
+	}
+
+	// Probes without a log check never match
+	probe, _ = newReadinessProbe(ReadyCheckNone, "", 1234)
+
+	if probe.matchesLine("Server listening on port 3000") {
+		t.Error("Expected line not to match without a log check")
+	}
+}
+
+func TestReadinessProbeTCP(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+
+	if err != nil {
+		t.Error("Expected no error, got", err)
+		return
+	}
+
+	defer listener.Close()
+
+	probe, _ := newReadinessProbe(ReadyCheckTCP, listener.Addr().String(), 0)
+
+	err = probe.wait(make(chan struct{}), make(chan struct{}))
+
+	if err != nil {
+		t.Error("Expected TCP probe to succeed, got", err)
+	}
+}
+
+func TestReadinessProbeHTTP(t *testing.T) {
+	requests := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		// Only report ready after the first request
+		if requests == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	defer server.Close()
+
+	probe, _ := newReadinessProbe(ReadyCheckHTTP, server.URL+"/health", 0)
+
+	err := probe.wait(make(chan struct{}), make(chan struct{}))
+
+	if err != nil {
+		t.Error("Expected HTTP probe to succeed, got", err)
+	}
+
+	if requests < 2 {
+		t.Error("Expected the probe to retry until ready, got", requests, "requests")
+	}
+}
+
+func TestReadinessProbeDelay(t *testing.T) {
+	probe, _ := newReadinessProbe(ReadyCheckDelay, "100ms", 0)
+
+	start := time.Now()
+	err := probe.wait(make(chan struct{}), make(chan struct{}))
+
+	if err != nil {
+		t.Error("Expected delay probe to succeed, got", err)
+	}
+
+	if time.Since(start) < 100*time.Millisecond {
+		t.Error("Expected the probe to wait for the delay, waited", time.Since(start))
+	}
+}
@@ -0,0 +1,57 @@
+package core
+
+import (
+	"time"
+)
+
+// Policies that determine when a command is restarted after it exits.
+const (
+	RestartNever     = "never"
+	RestartOnFailure = "on-failure"
+	RestartAlways    = "always"
+)
+
+// Maximum number of consecutive restarts of a newly added command.
+const DefaultMaxRetries = 5
+
+// Delay before the first restart of a command, doubled for every consecutive restart.
+const restartInitialBackoff = time.Second
+
+// Maximum delay between consecutive restarts of a command.
+const restartMaxBackoff = 30 * time.Second
+
+// When a command ran at least this long before exiting, it is considered to have been
+// running fine and the number of consecutive restarts is reset.
+const restartResetAfter = time.Minute
+
+// Check if the given restart policy is valid.
+func isValidRestartPolicy(policy string) bool {
+	return policy == RestartNever || policy == RestartOnFailure || policy == RestartAlways
+}
+
+// Check if a command with the given restart policy should be restarted after exiting.
+func shouldRestart(policy string, failed bool) bool {
+	switch policy {
+	case RestartAlways:
+		return true
+	case RestartOnFailure:
+		return failed
+	}
+
+	return false
+}
+
+// Get the delay before the given restart attempt (starting at 1) using exponential backoff.
+func restartBackoff(attempt int) time.Duration {
+	backoff := restartInitialBackoff
+
+	for i := 1; i < attempt; i++ {
+		backoff *= 2
+
+		if backoff >= restartMaxBackoff {
+			return restartMaxBackoff
+		}
+	}
+
+	return backoff
+}
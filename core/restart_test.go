@@ -0,0 +1,45 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestShouldRestart(t *testing.T) {
+	tests := []struct {
+		policy   string
+		failed   bool
+		expected bool
+	}{
+		{RestartNever, false, false},
+		{RestartNever, true, false},
+		{RestartOnFailure, false, false},
+		{RestartOnFailure, true, true},
+		{RestartAlways, false, true},
+		{RestartAlways, true, true},
+	}
+
+	for _, test := range tests {
+		if shouldRestart(test.policy, test.failed) != test.expected {
+			t.Error("Expected shouldRestart to be", test.expected, "for", test.policy, "failed", test.failed)
+		}
+	}
+}
+
+func TestRestartBackoff(t *testing.T) {
+	expected := []time.Duration{
+		time.Second,
+		2 * time.Second,
+		4 * time.Second,
+		8 * time.Second,
+		16 * time.Second,
+		30 * time.Second,
+		30 * time.Second,
+	}
+
+	for i, backoff := range expected {
+		if restartBackoff(i+1) != backoff {
+			t.Error("Expected backoff of restart", i+1, "to be", backoff, "got", restartBackoff(i+1))
+		}
+	}
+}
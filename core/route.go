@@ -0,0 +1,107 @@
+package core
+
+import (
+	"github.com/iskandervdh/spinup/common"
+	"github.com/iskandervdh/spinup/config"
+	"github.com/iskandervdh/spinup/database/sqlc"
+)
+
+type Route = sqlc.Route
+
+// Add a route to the project with the given name, forwarding the requests to the given path prefix
+// of its domains to the given port, optionally stripping the prefix from the path.
+//
+// A route for / replaces the port of the project for the requests that do not match another route.
+func (c *Core) AddRoute(projectName string, path string, port int64, stripPrefix bool) common.Msg {
+	if c.projects == nil {
+		return common.NewErrMsg("No projects found")
+	}
+
+	exists, project := c.ProjectExists(projectName)
+
+	if !exists {
+		return common.NewErrMsg("Project '%s' does not exist", projectName)
+	}
+
+	path, err := config.NormalizeRoutePath(path)
+
+	if err != nil {
+		return common.NewErrMsg("Invalid route: %s", err)
+	}
+
+	if port <= 0 || port > 65535 {
+		return common.NewErrMsg("Invalid route: port %d is not between 1 and 65535", port)
+	}
+
+	if path == "/" && stripPrefix {
+		return common.NewErrMsg("Invalid route: the prefix of the route of / cannot be stripped")
+	}
+
+	for _, route := range project.Routes {
+		if route.Path == path {
+			return common.NewErrMsg("Route '%s' already exists on project '%s'", path, projectName)
+		}
+	}
+
+	err = c.dbQueries.CreateRoute(c.dbContext, sqlc.CreateRouteParams{
+		Path:        path,
+		Port:        port,
+		StripPrefix: stripPrefix,
+		ProjectID:   project.ID,
+	})
+
+	if err != nil {
+		return common.NewErrMsg("Error adding route to database: %s", err)
+	}
+
+	err = c.writeProxyConfig(projectName)
+
+	if err != nil {
+		return common.NewErrMsg("Error trying to update reverse proxy config file: %s", err)
+	}
+
+	return common.NewSuccessMsg("Added route '%s' to port %d to project '%s'", path, port, projectName)
+}
+
+// Remove the route of the given path prefix from the project with the given name.
+func (c *Core) RemoveRoute(projectName string, path string) common.Msg {
+	if c.projects == nil {
+		return common.NewErrMsg("No projects found")
+	}
+
+	exists, project := c.ProjectExists(projectName)
+
+	if !exists {
+		return common.NewErrMsg("Project '%s' does not exist", projectName)
+	}
+
+	// Paths are stored normalized, an invalid path cannot be a route of the project
+	if normalized, err := config.NormalizeRoutePath(path); err == nil {
+		path = normalized
+	}
+
+	for _, route := range project.Routes {
+		if route.Path != path {
+			continue
+		}
+
+		err := c.dbQueries.DeleteRoute(c.dbContext, sqlc.DeleteRouteParams{
+			Path:      path,
+			ProjectID: project.ID,
+		})
+
+		if err != nil {
+			return common.NewErrMsg("Error removing route from database: %s", err)
+		}
+
+		err = c.writeProxyConfig(projectName)
+
+		if err != nil {
+			return common.NewErrMsg("Error trying to update reverse proxy config file: %s", err)
+		}
+
+		return common.NewSuccessMsg("Removed route '%s' from project '%s'", path, projectName)
+	}
+
+	return common.NewErrMsg("Route '%s' does not exist on project '%s'", path, projectName)
+}
@@ -0,0 +1,139 @@
+package core
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/iskandervdh/spinup/common"
+)
+
+func TestAddRoute(t *testing.T) {
+	c := TestingCore("add_route")
+
+	c.FetchCommands()
+	c.FetchProjects()
+
+	c.AddProject("example", 3000, []string{})
+	c.FetchProjects()
+
+	msg := c.AddRoute("example", "/api/", 8001, true)
+
+	if _, ok := msg.(*common.SuccessMsg); !ok {
+		t.Fatal("Expected route to be added, got", msg.GetText())
+	}
+
+	c.AddRoute("example", "/docs", 8002, false)
+	c.FetchProjects()
+
+	_, project := c.ProjectExists("example")
+
+	if len(project.Routes) != 2 || project.Routes[0].Path != "/api" || project.Routes[0].Port != 8001 || !project.Routes[0].StripPrefix {
+		t.Error("Expected the normalized routes to be stored, got", project.Routes)
+	}
+
+	invalid := []struct {
+		path string
+		port int64
+	}{
+		{"/api", 8003},
+		{"api", 8003},
+		{"/api;", 8003},
+		{"/admin", 0},
+		{"/admin", 70000},
+	}
+
+	for _, route := range invalid {
+		if _, ok := c.AddRoute("example", route.path, route.port, false).(*common.ErrMsg); !ok {
+			t.Errorf("Expected route '%s' to port %d to be rejected", route.path, route.port)
+		}
+	}
+
+	if _, ok := c.AddRoute("example", "/", 3001, true).(*common.ErrMsg); !ok {
+		t.Error("Expected stripping the prefix of the route of / to be rejected")
+	}
+
+	if _, ok := c.AddRoute("missing", "/api", 8001, false).(*common.ErrMsg); !ok {
+		t.Error("Expected adding a route to a project that does not exist to fail")
+	}
+
+	nginxConfig, err := os.ReadFile(c.config.GetNginxConfigPath("example"))
+
+	if err != nil {
+		t.Fatal("Expected nginx config to exist, got", err)
+	}
+
+	for _, location := range []string{
+		"location / {\n\t\tproxy_pass http://127.0.0.1:3000/;",
+		"location /api/ {\n\t\tproxy_pass http://127.0.0.1:8001/;",
+		"location /docs/ {\n\t\tproxy_pass http://127.0.0.1:8002;",
+	} {
+		if !strings.Contains(string(nginxConfig), location) {
+			t.Errorf("Expected nginx config to contain %q, got:\n%s", location, nginxConfig)
+		}
+	}
+}
+
+func TestRoutesAreKept(t *testing.T) {
+	for _, reverseProxy := range []string{"nginx", "caddy", "traefik"} {
+		t.Run(reverseProxy, func(t *testing.T) {
+			c := TestingCore("routes_are_kept_" + reverseProxy)
+
+			c.FetchCommands()
+			c.FetchProjects()
+			c.UseReverseProxy(reverseProxy)
+
+			c.AddProject("example", 3000, []string{})
+			c.FetchProjects()
+			c.AddRoute("example", "/api", 8001, true)
+			c.UpdateProject("example", 3001, []string{})
+
+			// Adding a domain alias and renaming the project change the config file without the database
+			c.FetchProjects()
+			c.AddDomainAlias("example", "api.example.test")
+			c.FetchProjects()
+			c.RenameProject("example", "renamed")
+
+			checks, err := c.CheckProxyConfigs()
+
+			if err != nil || len(checks) != 1 || checks[0].State != ProxyConfigOK {
+				t.Errorf("Expected the config to match the database after changing the project, got %v %s", checks, err)
+			}
+		})
+	}
+}
+
+func TestRemoveRoute(t *testing.T) {
+	c := TestingCore("remove_route")
+
+	c.FetchCommands()
+	c.FetchProjects()
+
+	c.AddProject("example", 3000, []string{})
+	c.FetchProjects()
+	c.AddRoute("example", "/api", 8001, true)
+	c.FetchProjects()
+
+	if _, ok := c.RemoveRoute("example", "/docs").(*common.ErrMsg); !ok {
+		t.Error("Expected removing a route that does not exist to fail")
+	}
+
+	msg := c.RemoveRoute("example", "/api/")
+
+	if _, ok := msg.(*common.SuccessMsg); !ok {
+		t.Fatal("Expected route to be removed, got", msg.GetText())
+	}
+
+	c.FetchProjects()
+	_, project := c.ProjectExists("example")
+
+	if len(project.Routes) != 0 {
+		t.Error("Expected no routes, got", project.Routes)
+	}
+
+	nginxConfig, _ := os.ReadFile(c.config.GetNginxConfigPath("example"))
+
+	if strings.Contains(string(nginxConfig), "location /api/") {
+		t.Error("Expected the location of the route to be removed, got", string(nginxConfig))
+	}
+}
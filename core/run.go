@@ -7,23 +7,164 @@ import (
 	"os"
 	"os/exec"
 	"os/signal"
+	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
 	"syscall"
+	"time"
 
 	"github.com/iskandervdh/spinup/common"
 )
 
+// How long to wait for the remaining output of a command after its process exited.
+//
+// Processes started by a command in the background can keep its output open, so this does not wait forever.
+const outputTimeout = time.Second
+
 type runningCommand struct {
-	command string
-	name    string
-	cmd     *exec.Cmd
+	command   string
+	name      string
+	args      []string
+	env       []string
+	dir       string
+	readiness readinessProbe
+	cmd       *exec.Cmd
+
+	// Log files the output of the command is written to, nil if the output is not logged
+	logs *projectLogs
+
+	// Prefix of the output of the command, colored differently for every command of the project
+	prefix string
+
+	restartPolicy string
+	maxRetries    int64
+
+	// Signal used to stop the command and how long to wait for it to stop before killing it
+	stopSignal  string
+	stopTimeout time.Duration
+
+	// Closed when the process of the command exited, replaced every time the command is started
+	exited chan struct{}
+
+	// Waits until the processes of the command that is being stopped exited or were killed
+	stops sync.WaitGroup
+
+	// Closed when all output of the process of the command has been read, replaced every time the command is started
+	outputRead chan struct{}
+
+	// Watches the files of the project to restart the command when they change, nil if not watching
+	watcher *fileWatcher
+
+	// Receives a value when the command should be restarted, because watched files changed or it was requested by the user
+	restartRequests chan struct{}
+
+	// Receives a value when a command that was stopped by the user should be started again
+	startRequests chan struct{}
+
+	// Makes sure the watcher and the readiness probe are only started once, even if the command is started again after it finished
+	watchOnce     sync.Once
+	readinessOnce sync.Once
+
+	// The commands of the project that need to be ready before this command can be started
+	dependencies []*runningCommand
+
+	// The commands of the project that wait for this command to be ready
+	dependents []*runningCommand
+
+	// Guards cmd, running and the status of the command, so the command is not started while the project is being stopped
+	mu      sync.Mutex
+	running bool
+
+	// Whether the command was stopped by the user and should not be restarted until it is started again
+	stopped bool
+
+	// Status of the command, see CommandStatus
+	state     string
+	startedAt time.Time
+	restarts  int
+	exitCode  *int
+
+	// Closed once the command is ready to be used by the commands that depend on it
+	ready     chan struct{}
+	readyOnce sync.Once
+
+	// Closed when the command exited or could not be started before it was ready
+	failed     chan struct{}
+	failedOnce sync.Once
+
+	// Whether runCommand returned for this command, guarded by Core.runMu
+	done bool
+}
+
+// A project that is being run, see Core.run.
+type projectRun struct {
+	project   Project
+	commands  []*runningCommand
+	startedAt time.Time
+
+	// Closed when the project is being stopped
+	stopping chan struct{}
+
+	// Waits for runCommand of all commands to return
+	wg sync.WaitGroup
+
+	// Number of commands for which runCommand did not return yet, guarded by Core.runMu
+	active int
+}
+
+func newRunningCommand(name string, command string) *runningCommand {
+	return &runningCommand{
+		name:    name,
+		command: command,
+		state:   CommandWaiting,
+		ready:   make(chan struct{}),
+		failed:  make(chan struct{}),
+
+		restartRequests: make(chan struct{}, 1),
+		startRequests:   make(chan struct{}, 1),
+	}
+}
+
+func (rc *runningCommand) markReady() {
+	rc.readyOnce.Do(func() {
+		close(rc.ready)
+	})
+}
+
+func (rc *runningCommand) markFailed() {
+	rc.failedOnce.Do(func() {
+		close(rc.failed)
+	})
+}
+
+func (rc *runningCommand) setState(state string) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	rc.state = state
+}
+
+func (rc *runningCommand) isStopped() bool {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	return rc.stopped
+}
+
+func (rc *runningCommand) isReady() bool {
+	select {
+	case <-rc.ready:
+		return true
+	default:
+		return false
+	}
 }
 
 func (c *Core) commandTemplate(command string, project Project) string {
 	// Replace placeholders in command with project values
 	command = strings.ReplaceAll(command, "{{port}}", fmt.Sprintf("%d", project.Port))
-	command = strings.ReplaceAll(command, "{{domain}}", common.GetDomain(project.Name))
+	command = strings.ReplaceAll(command, "{{domain}}", c.config.GetDomain(project.Name))
 
 	for _, variable := range project.Variables {
 		command = strings.ReplaceAll(command, fmt.Sprintf("{{%s}}", variable.Name), variable.Value)
@@ -32,11 +173,49 @@ func (c *Core) commandTemplate(command string, project Project) string {
 	return command
 }
 
-func (c *Core) prefixOutput(prefix string, reader io.Reader, writer io.Writer) error {
+// Get the directory the given command should be run in for the given project.
+//
+// The directory of the command is relative to the directory of the project.
+func (c *Core) commandDir(command Command, project Project) string {
+	if !command.Dir.Valid {
+		return project.Dir.String
+	}
+
+	return filepath.Join(project.Dir.String, c.commandTemplate(command.Dir.String, project))
+}
+
+// Get the environment variables that should be added to the environment of the given command.
+func (c *Core) commandEnvironment(command Command, project Project) []string {
+	var env []string
+
+	if command.ForceColor {
+		env = append(env, "FORCE_COLOR=1")
+	}
+
+	// Environment variables of the project are added before the ones of the command so the command can override them
+	for _, envVariable := range project.EnvVariables {
+		env = append(env, fmt.Sprintf("%s=%s", envVariable.Name, c.commandTemplate(envVariable.Value, project)))
+	}
+
+	for _, variable := range commandEnv(command) {
+		env = append(env, c.commandTemplate(variable, project))
+	}
+
+	return env
+}
+
+func (c *Core) prefixOutput(command *runningCommand, reader io.ReadCloser, writer io.Writer, stream string) error {
+	defer reader.Close()
+
 	scanner := bufio.NewScanner(reader)
 
 	for scanner.Scan() {
-		fmt.Fprintf(writer, "%s %s\n", prefix, scanner.Text())
+		fmt.Fprintf(writer, "%s %s\n", command.prefix, scanner.Text())
+		command.logs.writeLine(command.name, stream, scanner.Text())
+
+		if command.readiness.matchesLine(scanner.Text()) {
+			command.markReady()
+		}
 	}
 
 	if err := scanner.Err(); err != nil {
@@ -46,52 +225,406 @@ func (c *Core) prefixOutput(prefix string, reader io.Reader, writer io.Writer) e
 	return nil
 }
 
-func (c *Core) runCommand(wg *sync.WaitGroup, project Project, command *runningCommand) error {
-	defer wg.Done()
+// Get the colored prefix of the output of the command with the given index in the project.
+func (c *Core) commandPrefix(projectName string, commandName string, index int) string {
+	if c.prefixProject {
+		return common.PrefixText(fmt.Sprintf("[%s/%s]", projectName, commandName), c.prefixColorOffset+index)
+	}
+
+	return common.PrefixText(fmt.Sprintf("[%s]", commandName), index)
+}
+
+// Wait until all dependencies of the given command are ready.
+//
+// Returns false if the command should not be started, either because a dependency
+// failed before it was ready or because the project is being stopped.
+func (c *Core) waitForDependencies(command *runningCommand, stopping <-chan struct{}) bool {
+	for _, dependency := range command.dependencies {
+		if dependency.isReady() {
+			continue
+		}
+
+		c.sendMsg(common.NewInfoMsg("Command '%s' is waiting for '%s' to be ready...", command.name, dependency.name))
+
+		select {
+		case <-dependency.ready:
+		case <-dependency.failed:
+			c.sendMsg(common.NewErrMsg("Not starting command '%s' because '%s' did not become ready", command.name, dependency.name))
+			return false
+		case <-stopping:
+			return false
+		}
+	}
+
+	return true
+}
+
+// Wait until the given command is ready according to its readiness probe.
+func (c *Core) waitForReadiness(command *runningCommand, stopping <-chan struct{}) {
+	err := command.readiness.wait(command.ready, command.failed)
 
-	command.cmd = exec.Command(strings.Split(command.command, " ")[0], strings.Split(command.command, " ")[1:]...)
+	if err != nil {
+		c.sendMsg(common.NewErrMsg("Command '%s' is %s while waiting for %s", command.name, err, command.readiness))
+		command.markFailed()
+		return
+	}
+
+	select {
+	case <-command.failed:
+	case <-stopping:
+	default:
+		if !command.isReady() && len(command.dependents) > 0 {
+			c.sendMsg(common.NewInfoMsg("Command '%s' is ready", command.name))
+		}
+
+		command.markReady()
+	}
+}
+
+// Start the process of the given command.
+//
+// Returns false if the command was not started because the project or the command is being stopped.
+func (c *Core) startCommand(command *runningCommand, stopping <-chan struct{}) (bool, error) {
+	command.mu.Lock()
+	defer command.mu.Unlock()
+
+	// Do not start the command if the project or the command was stopped in the meantime
+	select {
+	case <-stopping:
+		return false, nil
+	default:
+	}
+
+	if command.stopped {
+		return false, nil
+	}
+
+	command.cmd = exec.Command(command.args[0], command.args[1:]...)
 
 	// create a new process group for the command
 	command.cmd.SysProcAttr = createProcessGroup()
 
-	// Force color output
-	command.cmd.Env = append(os.Environ(), "FORCE_COLOR=1")
+	command.cmd.Env = append(os.Environ(), command.env...)
 
-	stdout, err := command.cmd.StdoutPipe()
+	// Pipes are created manually instead of with StdoutPipe and StderrPipe, since Wait closes
+	// those before all output of a command that exits right away may have been read
+	stdout, stdoutWriter, err := os.Pipe()
 
 	if err != nil {
-		return fmt.Errorf("error creating StdoutPipe: %s", err)
+		return false, fmt.Errorf("error creating stdout pipe: %s", err)
 	}
 
-	stderr, err := command.cmd.StderrPipe()
+	stderr, stderrWriter, err := os.Pipe()
 
 	if err != nil {
-		return fmt.Errorf("error creating StderrPipe: %s", err)
+		stdout.Close()
+		stdoutWriter.Close()
+		return false, fmt.Errorf("error creating stderr pipe: %s", err)
 	}
 
-	go c.prefixOutput(fmt.Sprintf("[%s]", command.name), stdout, c.out)
-	go c.prefixOutput(fmt.Sprintf("[%s]", command.name), stderr, c.err)
+	command.cmd.Stdout = stdoutWriter
+	command.cmd.Stderr = stderrWriter
 
-	// Run the project in the project's directory if it's set
-	if project.Dir.Valid {
-		command.cmd.Dir = project.Dir.String
-	}
+	// Run the command in its directory inside the project's directory if it's set
+	command.cmd.Dir = command.dir
 
 	err = command.cmd.Start()
 
+	// The write ends are only needed by the process of the command
+	stdoutWriter.Close()
+	stderrWriter.Close()
+
 	if err != nil {
-		return fmt.Errorf("error starting command: %s", err)
+		stdout.Close()
+		stderr.Close()
+		return false, err
 	}
 
-	err = command.cmd.Wait()
+	outputRead := make(chan struct{})
+	output := sync.WaitGroup{}
+	output.Add(2)
 
-	if err != nil {
-		// Gracefully exit if the command was interrupted by the user
-		if exitError, ok := err.(*exec.ExitError); ok && exitError.ExitCode() == -1 {
-			return nil
+	go func() {
+		defer output.Done()
+		c.prefixOutput(command, stdout, c.out, LogStdout)
+	}()
+
+	go func() {
+		defer output.Done()
+		c.prefixOutput(command, stderr, c.err, LogStderr)
+	}()
+
+	go func() {
+		output.Wait()
+		close(outputRead)
+	}()
+
+	// Every start after the first one is a restart
+	if !command.startedAt.IsZero() {
+		command.restarts++
+	}
+
+	command.running = true
+	command.exited = make(chan struct{})
+	command.outputRead = outputRead
+	command.state = CommandRunning
+	command.startedAt = time.Now()
+	command.exitCode = nil
+
+	return true, nil
+}
+
+// Run the given command once its dependencies are ready and restart it according to its restart policy.
+func (c *Core) runCommand(run *projectRun, command *runningCommand) {
+	defer c.finishCommand(run, command)
+	defer command.markFailed()
+
+	stopping := run.stopping
+
+	// Commands that did not exit by themselves were stopped, either by the user or because they could not be started
+	defer func() {
+		command.mu.Lock()
+		defer command.mu.Unlock()
+
+		if command.state != CommandExited {
+			command.state = CommandStopped
+		}
+	}()
+
+	if !c.waitForDependencies(command, stopping) {
+		return
+	}
+
+	if command.watcher != nil {
+		command.watchOnce.Do(func() {
+			go command.watcher.watch(stopping, func(changedFile string) {
+				c.requestRestart(command, changedFile)
+			})
+		})
+	}
+
+	// Number of consecutive restarts of the command
+	restarts := 0
+
+	for {
+		if command.isStopped() {
+			if !c.waitForStart(command, stopping) {
+				return
+			}
+
+			restarts = 0
+		}
+
+		started, err := c.startCommand(command, stopping)
+
+		if err != nil {
+			c.sendMsg(common.NewErrMsg("Could not start command '%s': %s", command.name, err))
+			return
+		}
+
+		if !started {
+			// The command was stopped by the user right before it was started
+			if command.isStopped() {
+				continue
+			}
+
+			return
+		}
+
+		// The readiness of the command only has to be determined once, restarts do not affect the dependents
+		command.readinessOnce.Do(func() {
+			go c.waitForReadiness(command, stopping)
+		})
+
+		err = command.cmd.Wait()
+
+		// The last lines of output, which can make the command ready, may still be read after the process exited
+		select {
+		case <-command.outputRead:
+		case <-time.After(outputTimeout):
+		}
+
+		command.mu.Lock()
+		startedAt := command.startedAt
+		exitCode := command.cmd.ProcessState.ExitCode()
+		stopped := command.stopped
+		command.running = false
+		command.state = CommandExited
+		command.exitCode = &exitCode
+		close(command.exited)
+		command.mu.Unlock()
+
+		// Make sure all processes of the command are stopped before it is restarted
+		command.stops.Wait()
+
+		// Gracefully exit if the project was stopped by the user
+		select {
+		case <-stopping:
+			command.setState(CommandStopped)
+			return
+		default:
+		}
+
+		// Wait until the command is started again if only the command was stopped by the user
+		if stopped {
+			command.setState(CommandStopped)
+			c.sendMsg(common.NewInfoMsg("Stopped command '%s'", command.name))
+			continue
+		}
+
+		select {
+		case <-command.restartRequests:
+			restarts = 0
+			continue
+		default:
+		}
+
+		exitReason := "exit status 0"
+
+		if err != nil {
+			exitReason = err.Error()
+		}
+
+		if !shouldRestart(command.restartPolicy, err != nil) {
+			if err != nil {
+				c.sendMsg(common.NewErrMsg("Command '%s' exited with %s", command.name, exitReason))
+			}
+
+			if !c.waitForRestartRequest(command, stopping) {
+				return
+			}
+
+			restarts = 0
+			continue
 		}
 
-		return fmt.Errorf("command finished with error: %s", err)
+		if time.Since(startedAt) >= restartResetAfter {
+			restarts = 0
+		}
+
+		restarts++
+
+		if command.maxRetries > 0 && int64(restarts) > command.maxRetries {
+			c.sendMsg(common.NewErrMsg("Command '%s' exited with %s, giving up after %d restarts", command.name, exitReason, command.maxRetries))
+
+			if !c.waitForRestartRequest(command, stopping) {
+				return
+			}
+
+			restarts = 0
+			continue
+		}
+
+		backoff := restartBackoff(restarts)
+		command.setState(CommandRestarting)
+		c.sendMsg(common.NewWarnMsg("Command '%s' exited with %s, restarting in %s (restart %d)", command.name, exitReason, backoff, restarts))
+
+		select {
+		case <-time.After(backoff):
+		case <-command.restartRequests:
+			restarts = 0
+		case <-stopping:
+			return
+		}
+	}
+}
+
+// Restart the given command because the given watched file changed.
+func (c *Core) requestRestart(command *runningCommand, changedFile string) {
+	command.mu.Lock()
+	defer command.mu.Unlock()
+
+	// Commands that were stopped by the user are only started again by the user
+	if command.stopped {
+		return
+	}
+
+	c.sendMsg(common.NewInfoMsg("File '%s' changed, restarting command '%s'...", changedFile, command.name))
+	c.restartCommand(command)
+}
+
+// Restart the given command, must be called while holding the lock of the command.
+func (c *Core) restartCommand(command *runningCommand) {
+	select {
+	case command.restartRequests <- struct{}{}:
+	default:
+		// A restart has already been requested
+		return
+	}
+
+	// The command is started again by runCommand once the process exited
+	if command.running {
+		c.stopProcess(command)
+	}
+}
+
+// Wait until watched files of the given command change after it exited.
+//
+// Returns false if the command is not watching any files or the project is being stopped.
+func (c *Core) waitForRestartRequest(command *runningCommand, stopping <-chan struct{}) bool {
+	if command.watcher == nil {
+		return false
+	}
+
+	c.sendMsg(common.NewInfoMsg("Command '%s' will be restarted when watched files change", command.name))
+
+	select {
+	case <-command.restartRequests:
+		return true
+	case <-stopping:
+		return false
+	}
+}
+
+// Link the running commands to the running commands they depend on.
+//
+// Dependencies on commands that are not part of the project are ignored.
+func (c *Core) linkDependencies(runningCommands []*runningCommand, project Project) error {
+	byName := map[string]*runningCommand{}
+
+	for _, runningCommand := range runningCommands {
+		byName[runningCommand.name] = runningCommand
+	}
+
+	// The running commands are in the same order as the commands of the project
+	for i, runningCommand := range runningCommands {
+		dependencyNames, err := c.dbQueries.GetCommandDependencies(c.dbContext, project.Commands[i].ID)
+
+		if err != nil {
+			return err
+		}
+
+		for _, dependencyName := range dependencyNames {
+			dependency, ok := byName[dependencyName]
+
+			if !ok {
+				c.sendMsg(common.NewWarnMsg("Command '%s' depends on '%s' which is not part of project '%s'", runningCommand.name, dependencyName, project.Name))
+				continue
+			}
+
+			runningCommand.dependencies = append(runningCommand.dependencies, dependency)
+			dependency.dependents = append(dependency.dependents, runningCommand)
+		}
+	}
+
+	// Make sure there are no dependency cycles, since they would make the commands wait forever
+	for _, command := range runningCommands {
+		visited := map[*runningCommand]bool{}
+		stack := slices.Clone(command.dependencies)
+
+		for len(stack) > 0 {
+			current := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+
+			if current == command {
+				return fmt.Errorf("command '%s' has a dependency cycle", command.name)
+			}
+
+			if !visited[current] {
+				visited[current] = true
+				stack = append(stack, current.dependencies...)
+			}
+		}
 	}
 
 	return nil
@@ -99,34 +632,111 @@ func (c *Core) runCommand(wg *sync.WaitGroup, project Project, command *runningC
 
 // Run a project with the given name.
 func (c *Core) run(project Project, projectName string) common.Msg {
-	var wg sync.WaitGroup
-	wg.Add(len(project.Commands))
-
 	// Start a signal listener for Ctrl+C (SIGINT) to gracefully stop the project when the user interrupts the process.
-	sigChan := make(chan os.Signal, 1)
-	c.sigChan = &sigChan
-	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	if c.sigChan == nil {
+		sigChan := make(chan os.Signal, 1)
+		c.sigChan = &sigChan
+	}
 
-	c.sendMsg(common.NewInfoMsg("Running project '%s'...", projectName))
+	signal.Notify(*c.sigChan, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(*c.sigChan)
+
+	// Load the env files before starting any commands so parse errors can be reported
+	envFileEnv, err := c.loadEnvFiles(project)
+
+	if err != nil {
+		return common.NewErrMsg("Could not load env files of project '%s': %s", projectName, err)
+	}
 
 	runningCommands := []*runningCommand{}
 
 	// Add all commands to the commands array in a form that includes the command name.
 	for _, command := range project.Commands {
-		runningCommands = append(
-			runningCommands,
-			&runningCommand{
-				command: c.commandTemplate(command.Command, project),
-				name:    command.Name,
-			})
+		commandString := c.commandTemplate(command.Command, project)
+		env, args, err := commandArgs(commandString, command.Shell)
+
+		if err != nil {
+			return common.NewErrMsg("Could not parse command '%s': %s", command.Name, err)
+		}
+
+		readiness, err := newReadinessProbe(command.ReadyCheck, c.commandTemplate(command.ReadyValue, project), project.Port)
+
+		if err != nil {
+			return common.NewErrMsg("Invalid readiness check for command '%s': %s", command.Name, err)
+		}
+
+		runningCommand := newRunningCommand(command.Name, commandString)
+		runningCommand.prefix = c.commandPrefix(projectName, command.Name, len(runningCommands))
+		runningCommand.args = args
+		runningCommand.env = slices.Concat(envFileEnv, c.commandEnvironment(command, project), env)
+		runningCommand.dir = c.commandDir(command, project)
+		runningCommand.readiness = readiness
+		runningCommand.restartPolicy = command.RestartPolicy
+		runningCommand.maxRetries = command.MaxRetries
+		runningCommand.stopSignal, runningCommand.stopTimeout = commandStopSignal(command)
+		runningCommand.watcher = newFileWatcher(project.Dir.String, splitLines(command.Watch), splitLines(command.WatchExclude))
+
+		runningCommands = append(runningCommands, runningCommand)
 	}
 
 	if len(runningCommands) == 0 {
 		return common.NewErrMsg("No commands found")
 	}
 
+	err = c.linkDependencies(runningCommands, project)
+
+	if err != nil {
+		return common.NewErrMsg("Could not determine the order of the commands: %s", err)
+	}
+
+	commandNames := []string{}
+
+	for _, runningCommand := range runningCommands {
+		commandNames = append(commandNames, runningCommand.name)
+	}
+
+	logs, err := c.newProjectLogs(projectName, commandNames)
+
+	if err != nil {
+		return common.NewErrMsg("Could not open log files of project '%s': %s", projectName, err)
+	}
+
+	for _, runningCommand := range runningCommands {
+		runningCommand.logs = logs
+	}
+
+	// Messages are written to the combined log file while the project is running
+	c.logs.Store(logs)
+
+	defer func() {
+		c.logs.Store(nil)
+		logs.Close()
+	}()
+
+	c.sendMsg(common.NewInfoMsg("Running project '%s'...", projectName))
+
+	run := &projectRun{
+		project:   project,
+		commands:  runningCommands,
+		startedAt: time.Now(),
+		stopping:  make(chan struct{}),
+		active:    len(runningCommands),
+	}
+
+	run.wg.Add(len(runningCommands))
+
+	c.runMu.Lock()
+	c.currentRun = run
+	c.runMu.Unlock()
+
+	defer func() {
+		c.runMu.Lock()
+		c.currentRun = nil
+		c.runMu.Unlock()
+	}()
+
 	for _, runningCommand := range runningCommands {
-		go c.runCommand(&wg, project, runningCommand)
+		go c.runCommand(run, runningCommand)
 	}
 
 	go func() {
@@ -134,19 +744,21 @@ func (c *Core) run(project Project, projectName string) common.Msg {
 
 		c.sendMsg(common.NewInfoMsg("\nGracefully stopping project '%s'...", projectName))
 
-		// Send terminate signal to all running commands
+		close(run.stopping)
+
+		// Send the stop signal to all running commands
 		for _, runningCommand := range runningCommands {
-			if runningCommand.cmd.Process != nil {
-				err := killProcess(runningCommand.cmd.Process)
+			runningCommand.mu.Lock()
 
-				if err != nil {
-					c.sendMsg(common.NewErrMsg("Failed to send SIGTERM to command '%s': %s", runningCommand.name, err))
-				}
+			if runningCommand.running {
+				c.stopProcess(runningCommand)
 			}
+
+			runningCommand.mu.Unlock()
 		}
 	}()
 
-	wg.Wait()
+	run.wg.Wait()
 
 	return common.NewSuccessMsg("")
 }
@@ -165,3 +777,108 @@ func (c *Core) TryToRun(name string) common.Msg {
 
 	return c.run(project, name)
 }
+
+// Run the projects with the given names at the same time, until all of them stopped.
+//
+// The output of the commands is prefixed with the name of their project and a signal stops all projects.
+func (c *Core) RunProjects(names []string) common.Msg {
+	if len(names) == 0 {
+		return common.NewErrMsg("No projects provided")
+	}
+
+	projects := []Project{}
+
+	for _, name := range names {
+		exists, project := c.ProjectExists(name)
+
+		if !exists {
+			return common.NewErrMsg("Project '%s' does not exist", name)
+		}
+
+		if slices.ContainsFunc(projects, func(p Project) bool { return p.Name == name }) {
+			continue
+		}
+
+		projects = append(projects, project)
+	}
+
+	if c.sigChan == nil {
+		sigChan := make(chan os.Signal, 1)
+		c.sigChan = &sigChan
+	}
+
+	signal.Notify(*c.sigChan, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(*c.sigChan)
+
+	// Every project is run by its own core, since a core can only run one project at a time
+	runs := []*Core{}
+	colorOffset := 0
+
+	for _, project := range projects {
+		sigChan := make(chan os.Signal, 1)
+
+		runs = append(runs, &Core{
+			config:    c.config,
+			msgChan:   c.msgChan,
+			sigChan:   &sigChan,
+			out:       c.out,
+			err:       c.err,
+			db:        c.db,
+			dbQueries: c.dbQueries,
+			dbContext: c.dbContext,
+			commands:  c.commands,
+			projects:  c.projects,
+
+			prefixProject:     true,
+			prefixColorOffset: colorOffset,
+		})
+
+		colorOffset += len(project.Commands)
+	}
+
+	done := make(chan struct{})
+
+	// Stop all projects once a signal is received
+	go func() {
+		select {
+		case sig := <-*c.sigChan:
+			for _, run := range runs {
+				select {
+				case *run.sigChan <- sig:
+				default:
+				}
+			}
+		case <-done:
+		}
+	}()
+
+	results := make([]common.Msg, len(projects))
+	wg := sync.WaitGroup{}
+
+	for i, project := range projects {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			results[i] = runs[i].run(project, project.Name)
+		}()
+	}
+
+	wg.Wait()
+	close(done)
+
+	errors := []string{}
+
+	for _, result := range results {
+		if _, ok := result.(*common.ErrMsg); ok {
+			errors = append(errors, result.GetText())
+		}
+	}
+
+	if len(errors) > 0 {
+		return common.NewErrMsg("%s", strings.Join(errors, "\n"))
+	}
+
+	return common.NewSuccessMsg("")
+}
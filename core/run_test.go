@@ -1,7 +1,14 @@
 package core
 
 import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/iskandervdh/spinup/common"
 )
 
 func TestRun(t *testing.T) {
@@ -19,3 +26,192 @@ func TestRun(t *testing.T) {
 
 	c.TryToRun("test")
 }
+
+func TestRunShell(t *testing.T) {
+	c := TestingCore("run_shell")
+
+	c.FetchCommands()
+	c.FetchProjects()
+
+	c.AddCommand("ls", "ls | wc -l")
+	c.SetCommandShell("ls", true)
+
+	c.AddProject("test", 1234, []string{"ls"})
+
+	// "Refetch" the projects from the config file
+	c.FetchProjects()
+
+	c.TryToRun("test")
+}
+
+func TestCommandDirAndEnvironment(t *testing.T) {
+	c := TestingCore("command_dir_and_environment")
+
+	c.FetchCommands()
+	c.FetchProjects()
+
+	c.AddCommand("frontend", "npm run dev")
+	c.SetCommandSettings("frontend", CommandSettings{
+		Dir:        "frontend",
+		Env:        []string{"PORT={{port}}"},
+		ForceColor: true,
+	})
+
+	c.AddProject("test", 1234, []string{"frontend"})
+	c.FetchProjects()
+
+	projectDir := os.TempDir()
+	c.SetProjectDir("test", &projectDir)
+
+	// "Refetch" the projects from the config file
+	c.FetchProjects()
+
+	_, project := c.ProjectExists("test")
+	command := project.Commands[0]
+
+	dir := c.commandDir(command, project)
+
+	if dir != filepath.Join(os.TempDir(), "frontend") {
+		t.Error("Expected command dir to be inside the project dir, got", dir)
+	}
+
+	env := c.commandEnvironment(command, project)
+
+	if !slices.Equal(env, []string{"FORCE_COLOR=1", "PORT=1234"}) {
+		t.Errorf("Expected FORCE_COLOR and PORT to be set, got %q", env)
+	}
+}
+
+func TestRunDependencies(t *testing.T) {
+	c := TestingCore("run_dependencies")
+
+	c.FetchCommands()
+	c.FetchProjects()
+
+	output := filepath.Join(TestingConfigDir("run_dependencies"), "output.txt")
+
+	c.AddCommand("first", "sleep 0.2 && echo first >> "+output+" && echo ready")
+	c.SetCommandShell("first", true)
+	c.SetCommandReadiness("first", ReadyCheckLog, "ready")
+
+	c.AddCommand("second", "echo second >> "+output)
+	c.SetCommandShell("second", true)
+
+	c.FetchCommands()
+	c.SetCommandDependencies("second", []string{"first"})
+
+	c.AddProject("test", 1234, []string{"second", "first"})
+
+	// "Refetch" the projects from the config file
+	c.FetchProjects()
+
+	c.TryToRun("test")
+
+	contents, err := os.ReadFile(output)
+
+	if err != nil {
+		t.Error("Expected output file to exist, got", err)
+		return
+	}
+
+	if string(contents) != "first\nsecond\n" {
+		t.Errorf("Expected second command to run after the first one, got %q", contents)
+	}
+}
+
+func TestRunRestartOnFailure(t *testing.T) {
+	c := TestingCore("run_restart_on_failure")
+
+	c.FetchCommands()
+	c.FetchProjects()
+
+	output := filepath.Join(TestingConfigDir("run_restart_on_failure"), "output.txt")
+
+	c.AddCommand("crash", "echo started >> "+output+" && exit 1")
+	c.SetCommandShell("crash", true)
+	c.SetCommandRestartPolicy("crash", RestartOnFailure, 1)
+
+	c.AddProject("test", 1234, []string{"crash"})
+
+	// "Refetch" the projects from the config file
+	c.FetchProjects()
+
+	c.TryToRun("test")
+
+	contents, err := os.ReadFile(output)
+
+	if err != nil {
+		t.Error("Expected output file to exist, got", err)
+		return
+	}
+
+	if string(contents) != "started\nstarted\n" {
+		t.Errorf("Expected command to be restarted once, got %q", contents)
+	}
+}
+
+func TestRunProjects(t *testing.T) {
+	c := TestingCore("run_projects")
+
+	c.FetchCommands()
+	c.FetchProjects()
+
+	c.AddCommand("server", "sleep 10")
+	c.AddProject("first", 1234, []string{"server"})
+	c.AddProject("second", 1235, []string{"server"})
+
+	// "Refetch" the projects from the config file
+	c.FetchProjects()
+
+	msg := c.RunProjects([]string{"first", "unknown"})
+
+	if _, ok := msg.(*common.ErrMsg); !ok {
+		t.Error("Expected error when running a project that does not exist, got", msg)
+	}
+
+	// Create the signal channel before running, so the test can stop the projects
+	sigChan := make(chan os.Signal, 1)
+	c.sigChan = &sigChan
+
+	done := make(chan common.Msg)
+
+	go func() {
+		done <- c.RunProjects([]string{"first", "second"})
+	}()
+
+	time.Sleep(500 * time.Millisecond)
+
+	for _, projectName := range []string{"first", "second"} {
+		lines, _, err := c.ReadLogs(projectName, "", LogOptions{})
+
+		if err != nil || !slices.ContainsFunc(lines, func(line LogLine) bool { return line.Text == "Running project '"+projectName+"'..." }) {
+			t.Errorf("Expected project '%s' to be running, got %v %v", projectName, lines, err)
+		}
+	}
+
+	// A single signal stops all projects
+	sigChan <- os.Interrupt
+
+	select {
+	case msg := <-done:
+		if _, ok := msg.(*common.ErrMsg); ok {
+			t.Error("Expected projects to stop without errors, got", msg.GetText())
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("Expected all projects to stop after a signal")
+	}
+}
+
+func TestCommandPrefix(t *testing.T) {
+	c := TestingCore("command_prefix")
+
+	if prefix := c.commandPrefix("project", "server", 0); !strings.Contains(prefix, "[server]") || strings.Contains(prefix, "project") {
+		t.Error("Expected prefix to only contain the name of the command, got", prefix)
+	}
+
+	c.prefixProject = true
+
+	if prefix := c.commandPrefix("project", "server", 0); !strings.Contains(prefix, "[project/server]") {
+		t.Error("Expected prefix to contain the name of the project and the command, got", prefix)
+	}
+}
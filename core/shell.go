@@ -0,0 +1,143 @@
+package core
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Regex to match an environment variable assignment like NODE_ENV=development.
+var envAssignmentRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*=`)
+
+// Characters that have a special meaning to a shell when they are not quoted or escaped.
+const shellOperators = "|&;<>()`"
+
+// Split the given command string into arguments following POSIX shell quoting rules.
+//
+// Single quotes preserve everything literally, double quotes allow escaping of
+// `"`, `\`, `$` and '`' with a backslash and an unquoted backslash escapes the next character.
+// An error is returned for unterminated quotes or unquoted shell operators like pipes,
+// since those only work when the command is run in shell mode.
+func splitCommand(command string) ([]string, error) {
+	var args []string
+	var current strings.Builder
+
+	// Whether the current argument has been started, needed to support empty quoted arguments like ""
+	inArg := false
+
+	runes := []rune(command)
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+
+		switch {
+		case r == ' ' || r == '\t' || r == '\n':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		case r == '\\':
+			i++
+
+			if i >= len(runes) {
+				return nil, fmt.Errorf("unexpected end of command after '\\'")
+			}
+
+			// A backslash followed by a newline is a line continuation
+			if runes[i] != '\n' {
+				inArg = true
+				current.WriteRune(runes[i])
+			}
+		case r == '\'':
+			inArg = true
+			closed := false
+
+			for i++; i < len(runes); i++ {
+				if runes[i] == '\'' {
+					closed = true
+					break
+				}
+
+				current.WriteRune(runes[i])
+			}
+
+			if !closed {
+				return nil, fmt.Errorf("unterminated single quote in command")
+			}
+		case r == '"':
+			inArg = true
+			closed := false
+
+			for i++; i < len(runes); i++ {
+				if runes[i] == '"' {
+					closed = true
+					break
+				}
+
+				if runes[i] == '\\' && i+1 < len(runes) && strings.ContainsRune("\"\\$`\n", runes[i+1]) {
+					i++
+
+					if runes[i] != '\n' {
+						current.WriteRune(runes[i])
+					}
+
+					continue
+				}
+
+				current.WriteRune(runes[i])
+			}
+
+			if !closed {
+				return nil, fmt.Errorf("unterminated double quote in command")
+			}
+		case strings.ContainsRune(shellOperators, r):
+			return nil, fmt.Errorf("command contains shell operator '%c', enable shell mode to use it", r)
+		default:
+			inArg = true
+			current.WriteRune(r)
+		}
+	}
+
+	if inArg {
+		args = append(args, current.String())
+	}
+
+	return args, nil
+}
+
+// Parse the given command string into leading environment variable assignments and the arguments to execute.
+//
+// For example `NODE_ENV=dev npm run dev` results in env ["NODE_ENV=dev"] and args ["npm", "run", "dev"].
+func parseCommand(command string) ([]string, []string, error) {
+	args, err := splitCommand(command)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var env []string
+
+	for len(args) > 0 && envAssignmentRegex.MatchString(args[0]) {
+		env = append(env, args[0])
+		args = args[1:]
+	}
+
+	if len(args) == 0 {
+		return nil, nil, fmt.Errorf("command is empty")
+	}
+
+	return env, args, nil
+}
+
+// Get the environment variable assignments and arguments needed to execute the given command.
+//
+// In shell mode the command is passed as a whole to the shell of the system,
+// otherwise it is parsed using parseCommand.
+func commandArgs(command string, shell bool) ([]string, []string, error) {
+	if shell {
+		return nil, shellCommandArgs(command), nil
+	}
+
+	return parseCommand(command)
+}
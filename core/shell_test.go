@@ -0,0 +1,95 @@
+package core
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSplitCommand(t *testing.T) {
+	tests := map[string][]string{
+		"npm run dev":                        {"npm", "run", "dev"},
+		"npm  run   dev":                     {"npm", "run", "dev"},
+		`echo "hello world"`:                 {"echo", "hello world"},
+		`echo 'hello "world"'`:               {"echo", `hello "world"`},
+		`echo "say \"hi\""`:                  {"echo", `say "hi"`},
+		`echo hello\ world`:                  {"echo", "hello world"},
+		`echo ""`:                            {"echo", ""},
+		`echo "a"'b'c`:                       {"echo", "abc"},
+		`echo 'it''s'`:                       {"echo", "its"},
+		`echo "a|b"`:                         {"echo", "a|b"},
+		`go run . -- --name="{{domain}}"`:    {"go", "run", ".", "--", "--name={{domain}}"},
+		"php artisan serve --port=8000 \\\n": {"php", "artisan", "serve", "--port=8000"},
+	}
+
+	for command, expected := range tests {
+		args, err := splitCommand(command)
+
+		if err != nil {
+			t.Errorf("Expected no error for %q, got %s", command, err)
+			continue
+		}
+
+		if !slices.Equal(args, expected) {
+			t.Errorf("Expected %q for %q, got %q", expected, command, args)
+		}
+	}
+}
+
+func TestSplitCommandErrors(t *testing.T) {
+	commands := []string{
+		`echo "hello`,
+		`echo 'hello`,
+		`echo hello\`,
+		"npm run build && npm run start",
+		"cat file | grep test",
+		"echo test > file",
+	}
+
+	for _, command := range commands {
+		_, err := splitCommand(command)
+
+		if err == nil {
+			t.Errorf("Expected error for %q, got nil", command)
+		}
+	}
+}
+
+func TestParseCommand(t *testing.T) {
+	env, args, err := parseCommand("NODE_ENV=development PORT=3000 npm run dev")
+
+	if err != nil {
+		t.Error("Expected no error, got", err)
+		return
+	}
+
+	if !slices.Equal(env, []string{"NODE_ENV=development", "PORT=3000"}) {
+		t.Errorf("Expected env to be NODE_ENV and PORT, got %q", env)
+	}
+
+	if !slices.Equal(args, []string{"npm", "run", "dev"}) {
+		t.Errorf("Expected args to be npm run dev, got %q", args)
+	}
+
+	_, _, err = parseCommand("NODE_ENV=development")
+
+	if err == nil {
+		t.Error("Expected error for command without arguments, got nil")
+	}
+}
+
+func TestCommandArgsShell(t *testing.T) {
+	env, args, err := commandArgs("npm run build && npm run start", true)
+
+	if err != nil {
+		t.Error("Expected no error, got", err)
+		return
+	}
+
+	if len(env) != 0 {
+		t.Errorf("Expected no env, got %q", env)
+	}
+
+	if args[len(args)-1] != "npm run build && npm run start" {
+		t.Errorf("Expected command to be passed to the shell as a whole, got %q", args)
+	}
+}
@@ -0,0 +1,1181 @@
+package core
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/iskandervdh/spinup/common"
+	"github.com/iskandervdh/spinup/config"
+	"gopkg.in/yaml.v3"
+)
+
+// Version of the spinup file schema that is written by ExportProjects and understood by ImportProjects.
+const SpinupFileVersion = 1
+
+// Names of the spinup files that are looked for when running a project from its repository.
+var SpinupFileNames = []string{".spinup.yaml", ".spinup.yml", "spinup.yaml", "spinup.yml"}
+
+// Ways to handle commands and projects of an imported spinup file that already exist with different settings.
+const (
+	ImportConflictAbort     = "abort"
+	ImportConflictSkip      = "skip"
+	ImportConflictOverwrite = "overwrite"
+)
+
+// SpinupFile is the declarative form of projects and the commands they use,
+// meant to be checked into a repository so others can import them:
+//
+//	version: 1
+//	commands:
+//	  - name: api
+//	    command: go run .
+//	    ready_check: http
+//	    ready_value: /health
+//	  - name: web
+//	    command: npm run dev
+//	    dir: frontend
+//	    depends_on: [api]
+//	projects:
+//	  - name: shop
+//	    port: 3000
+//	    dir: .
+//	    commands: [api, web]
+//	    variables:
+//	      API_URL: http://localhost:3000
+//	    env:
+//	      NODE_ENV: development
+//	    env_files: [.env]
+//	    domain_aliases: [admin.shop.test]
+//	    routes:
+//	      - path: /api
+//	        port: 3001
+//	        strip_prefix: true
+//
+// Commands map onto Command and its CommandSettings, projects map onto Project and its
+// Variables, EnvVariables, EnvFiles, DomainAliases and Routes. A relative project directory is
+// relative to the directory of the spinup file.
+type SpinupFile struct {
+	Version  int           `yaml:"version"`
+	Commands []CommandSpec `yaml:"commands,omitempty"`
+	Projects []ProjectSpec `yaml:"projects,omitempty"`
+}
+
+// CommandSpec is a command in a spinup file. Settings that are omitted use the defaults of a new command.
+type CommandSpec struct {
+	Name         string   `yaml:"name"`
+	Command      string   `yaml:"command"`
+	Shell        bool     `yaml:"shell,omitempty"`
+	Dir          string   `yaml:"dir,omitempty"`
+	Env          []string `yaml:"env,omitempty"`
+	ForceColor   *bool    `yaml:"force_color,omitempty"`
+	ReadyCheck   string   `yaml:"ready_check,omitempty"`
+	ReadyValue   string   `yaml:"ready_value,omitempty"`
+	Restart      string   `yaml:"restart,omitempty"`
+	MaxRetries   *int64   `yaml:"max_retries,omitempty"`
+	Watch        []string `yaml:"watch,omitempty"`
+	WatchExclude []string `yaml:"watch_exclude,omitempty"`
+	StopSignal   string   `yaml:"stop_signal,omitempty"`
+	StopTimeout  int64    `yaml:"stop_timeout,omitempty"`
+	DependsOn    []string `yaml:"depends_on,omitempty"`
+}
+
+// ProjectSpec is a project in a spinup file.
+//
+// Variables are the template variables of the project and Env its environment variables.
+type ProjectSpec struct {
+	Name          string            `yaml:"name"`
+	Port          int64             `yaml:"port"`
+	Dir           string            `yaml:"dir,omitempty"`
+	Commands      []string          `yaml:"commands,omitempty"`
+	Variables     map[string]string `yaml:"variables,omitempty"`
+	Env           map[string]string `yaml:"env,omitempty"`
+	EnvFiles      []string          `yaml:"env_files,omitempty"`
+	DomainAliases []string          `yaml:"domain_aliases,omitempty"`
+	Routes        []RouteSpec       `yaml:"routes,omitempty"`
+}
+
+// RouteSpec is a route of a project in a spinup file.
+type RouteSpec struct {
+	Path        string `yaml:"path"`
+	Port        int64  `yaml:"port"`
+	StripPrefix bool   `yaml:"strip_prefix,omitempty"`
+}
+
+// Get the route spec as text, used to show the differences between project specs.
+func (s RouteSpec) String() string {
+	if s.StripPrefix {
+		return fmt.Sprintf("%s -> %d (strip prefix)", s.Path, s.Port)
+	}
+
+	return fmt.Sprintf("%s -> %d", s.Path, s.Port)
+}
+
+// Get the grace period of the default stop timeout in seconds.
+func defaultStopTimeoutSeconds() int64 {
+	return int64(DefaultStopTimeout / time.Second)
+}
+
+// Get the command spec with the defaults filled in for the settings that are omitted.
+func (s CommandSpec) withDefaults() CommandSpec {
+	if s.ForceColor == nil {
+		forceColor := DefaultCommandSettings().ForceColor
+		s.ForceColor = &forceColor
+	}
+
+	if s.Restart == "" {
+		s.Restart = RestartNever
+	}
+
+	if s.MaxRetries == nil {
+		maxRetries := int64(DefaultMaxRetries)
+		s.MaxRetries = &maxRetries
+	}
+
+	if s.StopSignal == "" {
+		s.StopSignal = defaultStopSignal
+	}
+
+	s.StopSignal = normalizeStopSignal(s.StopSignal)
+
+	if s.StopTimeout == 0 {
+		s.StopTimeout = defaultStopTimeoutSeconds()
+	}
+
+	return s
+}
+
+// Get the command spec without the settings that have their default value, to keep exported files short.
+func (s CommandSpec) withoutDefaults() CommandSpec {
+	if s.ForceColor != nil && *s.ForceColor == DefaultCommandSettings().ForceColor {
+		s.ForceColor = nil
+	}
+
+	if s.Restart == RestartNever {
+		s.Restart = ""
+	}
+
+	if s.Restart == "" || (s.MaxRetries != nil && *s.MaxRetries == DefaultMaxRetries) {
+		s.MaxRetries = nil
+	}
+
+	if s.StopSignal == defaultStopSignal {
+		s.StopSignal = ""
+	}
+
+	if s.StopTimeout == defaultStopTimeoutSeconds() {
+		s.StopTimeout = 0
+	}
+
+	return s
+}
+
+// Get the settings of the command spec that are stored with the command itself.
+func (s CommandSpec) settings() CommandSettings {
+	s = s.withDefaults()
+
+	return CommandSettings{
+		Shell:        s.Shell,
+		Dir:          s.Dir,
+		Env:          s.Env,
+		ForceColor:   *s.ForceColor,
+		Watch:        s.Watch,
+		WatchExclude: s.WatchExclude,
+	}
+}
+
+// Check if the settings of the command spec are valid.
+func (s CommandSpec) validate() error {
+	s = s.withDefaults()
+
+	if strings.TrimSpace(s.Command) == "" {
+		return fmt.Errorf("command '%s' has no command to run", s.Name)
+	}
+
+	err := ValidateCommandSettings(s.settings())
+
+	if err != nil {
+		return fmt.Errorf("command '%s': %s", s.Name, err)
+	}
+
+	err = validateReadiness(s.ReadyCheck, s.ReadyValue)
+
+	if err != nil {
+		return fmt.Errorf("command '%s': %s", s.Name, err)
+	}
+
+	if !isValidRestartPolicy(s.Restart) {
+		return fmt.Errorf("command '%s': invalid restart policy '%s'", s.Name, s.Restart)
+	}
+
+	if *s.MaxRetries < 0 {
+		return fmt.Errorf("command '%s': maximum number of retries can not be negative", s.Name)
+	}
+
+	if !isValidStopSignal(s.StopSignal) {
+		return fmt.Errorf("command '%s': invalid stop signal '%s'", s.Name, s.StopSignal)
+	}
+
+	if s.StopTimeout < 0 {
+		return fmt.Errorf("command '%s': grace period must be at least 1 second", s.Name)
+	}
+
+	if slices.Contains(s.DependsOn, s.Name) {
+		return fmt.Errorf("command '%s' can not depend on itself", s.Name)
+	}
+
+	return nil
+}
+
+// Check if the settings of the project spec are valid.
+func (s ProjectSpec) validate() error {
+	if s.Port <= 0 || s.Port > 65535 {
+		return fmt.Errorf("project '%s' has invalid port %d", s.Name, s.Port)
+	}
+
+	for key := range s.Env {
+		if !envVariableNameRegex.MatchString(key) {
+			return fmt.Errorf("project '%s': '%s' is not a valid environment variable name", s.Name, key)
+		}
+	}
+
+	for _, route := range s.Routes {
+		if _, err := config.NormalizeRoutePath(route.Path); err != nil {
+			return fmt.Errorf("project '%s': %s", s.Name, err)
+		}
+
+		if route.Port <= 0 || route.Port > 65535 {
+			return fmt.Errorf("project '%s': route '%s' has invalid port %d", s.Name, route.Path, route.Port)
+		}
+	}
+
+	return nil
+}
+
+// A single setting of a command or project spec, used to show the differences between them.
+type specField struct {
+	name  string
+	value string
+}
+
+// Get the settings of the command spec as fields, in the order of the schema.
+func (s CommandSpec) fields() []specField {
+	s = s.withDefaults()
+
+	return []specField{
+		{"command", s.Command},
+		{"shell", strconv.FormatBool(s.Shell)},
+		{"dir", s.Dir},
+		{"env", strings.Join(s.Env, ", ")},
+		{"force_color", strconv.FormatBool(*s.ForceColor)},
+		{"ready_check", s.ReadyCheck},
+		{"ready_value", s.ReadyValue},
+		{"restart", s.Restart},
+		{"max_retries", strconv.FormatInt(*s.MaxRetries, 10)},
+		{"watch", strings.Join(s.Watch, ", ")},
+		{"watch_exclude", strings.Join(s.WatchExclude, ", ")},
+		{"stop_signal", s.StopSignal},
+		{"stop_timeout", strconv.FormatInt(s.StopTimeout, 10)},
+		{"depends_on", strings.Join(s.DependsOn, ", ")},
+	}
+}
+
+// Get the settings of the project spec as fields, with a field per variable and environment variable.
+func (s ProjectSpec) fields() []specField {
+	fields := []specField{
+		{"port", strconv.FormatInt(s.Port, 10)},
+		{"dir", s.Dir},
+		{"commands", strings.Join(s.Commands, ", ")},
+	}
+
+	for _, key := range sortedKeys(s.Variables) {
+		fields = append(fields, specField{"variables." + key, s.Variables[key]})
+	}
+
+	for _, key := range sortedKeys(s.Env) {
+		fields = append(fields, specField{"env." + key, s.Env[key]})
+	}
+
+	return append(fields,
+		specField{"env_files", strings.Join(s.EnvFiles, ", ")},
+		specField{"domain_aliases", strings.Join(s.DomainAliases, ", ")},
+		specField{"routes", joinRouteSpecs(s.Routes)},
+	)
+}
+
+// Join the given route specs as text, sorted by path so the order in the spinup file does not matter.
+func joinRouteSpecs(routes []RouteSpec) string {
+	values := []string{}
+
+	for _, route := range routes {
+		values = append(values, route.normalized().String())
+	}
+
+	slices.Sort(values)
+
+	return strings.Join(values, ", ")
+}
+
+// Get the route spec with its path normalized like it is stored in the database.
+func (s RouteSpec) normalized() RouteSpec {
+	if path, err := config.NormalizeRoutePath(s.Path); err == nil {
+		s.Path = path
+	}
+
+	return s
+}
+
+// Get the keys of the given map in alphabetical order.
+func sortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	return keys
+}
+
+// A field that differs between the existing and the imported settings.
+type fieldChange struct {
+	name string
+	old  string
+	new  string
+}
+
+// Get the fields that differ between the old and new fields.
+func diffFields(oldFields []specField, newFields []specField) []fieldChange {
+	changes := []fieldChange{}
+
+	for _, field := range newFields {
+		index := slices.IndexFunc(oldFields, func(old specField) bool { return old.name == field.name })
+		old := ""
+
+		if index != -1 {
+			old = oldFields[index].value
+		}
+
+		if old != field.value {
+			changes = append(changes, fieldChange{field.name, old, field.value})
+		}
+	}
+
+	for _, field := range oldFields {
+		index := slices.IndexFunc(newFields, func(other specField) bool { return other.name == field.name })
+
+		if index == -1 && field.value != "" {
+			changes = append(changes, fieldChange{field.name, field.value, ""})
+		}
+	}
+
+	return changes
+}
+
+// Actions that are taken for a command or project when importing a spinup file.
+const (
+	importCreate    = "create"
+	importUpdate    = "update"
+	importUnchanged = "unchanged"
+	importSkip      = "skip"
+	importConflict  = "conflict"
+)
+
+// A command or project of a spinup file and what importing it would change.
+type importChange struct {
+	kind    string
+	name    string
+	action  string
+	reason  string
+	changes []fieldChange
+
+	command *CommandSpec
+	project *ProjectSpec
+}
+
+// Get the change as a line of a diff, followed by the fields that are changed.
+func (c importChange) String() string {
+	var builder strings.Builder
+
+	switch c.action {
+	case importCreate:
+		fmt.Fprintf(&builder, "+ %s '%s'\n", c.kind, c.name)
+	case importUpdate:
+		fmt.Fprintf(&builder, "~ %s '%s'\n", c.kind, c.name)
+	case importUnchanged:
+		fmt.Fprintf(&builder, "= %s '%s' (unchanged)\n", c.kind, c.name)
+	case importSkip:
+		fmt.Fprintf(&builder, "- %s '%s' (skipped: %s)\n", c.kind, c.name, c.reason)
+	case importConflict:
+		fmt.Fprintf(&builder, "! %s '%s' (conflict: %s)\n", c.kind, c.name, c.reason)
+	}
+
+	for _, change := range c.changes {
+		switch {
+		case c.action == importCreate:
+			fmt.Fprintf(&builder, "    %s: %s\n", change.name, change.new)
+		case change.old == "":
+			fmt.Fprintf(&builder, "    %s: (none) -> %s\n", change.name, change.new)
+		case change.new == "":
+			fmt.Fprintf(&builder, "    %s: %s -> (none)\n", change.name, change.old)
+		default:
+			fmt.Fprintf(&builder, "    %s: %s -> %s\n", change.name, change.old, change.new)
+		}
+	}
+
+	return builder.String()
+}
+
+// The changes that importing a spinup file would make, commands before projects.
+type importPlan []importChange
+
+// Get the plan as a diff of all commands and projects in the spinup file.
+func (p importPlan) String() string {
+	var builder strings.Builder
+
+	for _, change := range p {
+		builder.WriteString(change.String())
+	}
+
+	return builder.String()
+}
+
+// Count the commands and projects in the plan with the given action.
+func (p importPlan) count(action string) int {
+	count := 0
+
+	for _, change := range p {
+		if change.action == action {
+			count++
+		}
+	}
+
+	return count
+}
+
+// Parse and validate the given contents of a spinup file.
+func ParseSpinupFile(data []byte) (SpinupFile, error) {
+	var file SpinupFile
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	err := decoder.Decode(&file)
+
+	if err != nil {
+		return file, err
+	}
+
+	if file.Version != SpinupFileVersion {
+		return file, fmt.Errorf("unsupported version %d, expected %d", file.Version, SpinupFileVersion)
+	}
+
+	commandNames := []string{}
+
+	for _, command := range file.Commands {
+		if command.Name == "" {
+			return file, fmt.Errorf("command without a name")
+		}
+
+		if slices.Contains(commandNames, command.Name) {
+			return file, fmt.Errorf("command '%s' is defined more than once", command.Name)
+		}
+
+		err = command.validate()
+
+		if err != nil {
+			return file, err
+		}
+
+		commandNames = append(commandNames, command.Name)
+	}
+
+	projectNames := []string{}
+	ports := map[int64]string{}
+
+	for _, project := range file.Projects {
+		if project.Name == "" {
+			return file, fmt.Errorf("project without a name")
+		}
+
+		if slices.Contains(projectNames, project.Name) {
+			return file, fmt.Errorf("project '%s' is defined more than once", project.Name)
+		}
+
+		if other, ok := ports[project.Port]; ok {
+			return file, fmt.Errorf("projects '%s' and '%s' use the same port %d", other, project.Name, project.Port)
+		}
+
+		err = project.validate()
+
+		if err != nil {
+			return file, err
+		}
+
+		projectNames = append(projectNames, project.Name)
+		ports[project.Port] = project.Name
+	}
+
+	return file, nil
+}
+
+// Get the command spec of the given command as it is stored in the database.
+func (c *Core) commandSpec(command Command) (CommandSpec, error) {
+	dependsOn, err := c.dbQueries.GetCommandDependencies(c.dbContext, command.ID)
+
+	if err != nil {
+		return CommandSpec{}, err
+	}
+
+	settings := GetCommandSettings(command)
+
+	return CommandSpec{
+		Name:         command.Name,
+		Command:      command.Command,
+		Shell:        settings.Shell,
+		Dir:          settings.Dir,
+		Env:          settings.Env,
+		ForceColor:   &settings.ForceColor,
+		ReadyCheck:   command.ReadyCheck,
+		ReadyValue:   command.ReadyValue,
+		Restart:      command.RestartPolicy,
+		MaxRetries:   &command.MaxRetries,
+		Watch:        settings.Watch,
+		WatchExclude: settings.WatchExclude,
+		StopSignal:   command.StopSignal,
+		StopTimeout:  command.StopTimeout,
+		DependsOn:    dependsOn,
+	}, nil
+}
+
+// Get the project spec of the given project as it is stored in the database.
+func projectSpec(project Project) ProjectSpec {
+	spec := ProjectSpec{
+		Name: project.Name,
+		Port: project.Port,
+		Dir:  project.Dir.String,
+	}
+
+	for _, command := range project.Commands {
+		spec.Commands = append(spec.Commands, command.Name)
+	}
+
+	for _, variable := range project.Variables {
+		if spec.Variables == nil {
+			spec.Variables = map[string]string{}
+		}
+
+		spec.Variables[variable.Name] = variable.Value
+	}
+
+	for _, envVariable := range project.EnvVariables {
+		if spec.Env == nil {
+			spec.Env = map[string]string{}
+		}
+
+		spec.Env[envVariable.Name] = envVariable.Value
+	}
+
+	for _, envFile := range project.EnvFiles {
+		spec.EnvFiles = append(spec.EnvFiles, envFile.Path)
+	}
+
+	for _, domainAlias := range project.DomainAliases {
+		spec.DomainAliases = append(spec.DomainAliases, domainAlias.Value)
+	}
+
+	for _, route := range project.Routes {
+		spec.Routes = append(spec.Routes, RouteSpec{Path: route.Path, Port: route.Port, StripPrefix: route.StripPrefix})
+	}
+
+	return spec
+}
+
+// Export the projects with the given names and the commands they use as a spinup file.
+//
+// When no project names are given all projects and commands are exported.
+// Project directories inside baseDir are written relative to it.
+func (c *Core) ExportProjects(projectNames []string, baseDir string) ([]byte, common.Msg) {
+	err := c.FetchProjects()
+
+	if err != nil {
+		return nil, common.NewErrMsg("Error getting projects: %s", err)
+	}
+
+	commands, err := c.dbQueries.GetCommands(c.dbContext)
+
+	if err != nil {
+		return nil, common.NewErrMsg("Error getting commands: %s", err)
+	}
+
+	file := SpinupFile{Version: SpinupFileVersion}
+	commandNames := []string{}
+
+	if len(projectNames) == 0 {
+		projectNames = c.GetProjectNames()
+
+		for _, command := range commands {
+			commandNames = append(commandNames, command.Name)
+		}
+	}
+
+	for _, projectName := range projectNames {
+		exists, project := c.ProjectExists(projectName)
+
+		if !exists {
+			return nil, common.NewErrMsg("Project '%s' does not exist", projectName)
+		}
+
+		spec := projectSpec(project)
+
+		if spec.Dir != "" {
+			relativeDir, err := filepath.Rel(baseDir, spec.Dir)
+
+			if err == nil && !strings.HasPrefix(relativeDir, "..") {
+				spec.Dir = relativeDir
+			}
+		}
+
+		for _, commandName := range spec.Commands {
+			if !slices.Contains(commandNames, commandName) {
+				commandNames = append(commandNames, commandName)
+			}
+		}
+
+		file.Projects = append(file.Projects, spec)
+	}
+
+	specs := map[string]CommandSpec{}
+
+	for _, command := range commands {
+		spec, err := c.commandSpec(command)
+
+		if err != nil {
+			return nil, common.NewErrMsg("Error getting dependencies of command '%s': %s", command.Name, err)
+		}
+
+		specs[command.Name] = spec.withoutDefaults()
+	}
+
+	// Include the dependencies of the exported commands, so the file can be imported on its own
+	for i := 0; i < len(commandNames); i++ {
+		for _, dependency := range specs[commandNames[i]].DependsOn {
+			if !slices.Contains(commandNames, dependency) {
+				commandNames = append(commandNames, dependency)
+			}
+		}
+	}
+
+	for _, command := range commands {
+		if slices.Contains(commandNames, command.Name) {
+			file.Commands = append(file.Commands, specs[command.Name])
+		}
+	}
+
+	var buffer bytes.Buffer
+
+	encoder := yaml.NewEncoder(&buffer)
+	encoder.SetIndent(2)
+
+	err = encoder.Encode(file)
+
+	if err != nil {
+		return nil, common.NewErrMsg("Error encoding spinup file: %s", err)
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// Determine what importing the given spinup file would change.
+//
+// Commands and projects that already exist with different settings are handled according to onConflict.
+// A port that is already used by another project is a conflict that can only be skipped.
+func (c *Core) planImport(file SpinupFile, onConflict string) (importPlan, error) {
+	plan := importPlan{}
+
+	for i := range file.Commands {
+		spec := &file.Commands[i]
+		change := importChange{kind: "command", name: spec.Name, command: spec}
+
+		for _, dependency := range spec.DependsOn {
+			if !slices.ContainsFunc(file.Commands, func(command CommandSpec) bool { return command.Name == dependency }) {
+				if exists, _ := c.CommandExists(dependency); !exists {
+					return nil, fmt.Errorf("command '%s' depends on unknown command '%s'", spec.Name, dependency)
+				}
+			}
+		}
+
+		exists, command := c.CommandExists(spec.Name)
+
+		if !exists {
+			change.action = importCreate
+			change.changes = diffFields(nil, nonEmptyFields(spec.fields()))
+			plan = append(plan, change)
+			continue
+		}
+
+		existing, err := c.commandSpec(command)
+
+		if err != nil {
+			return nil, err
+		}
+
+		change.changes = diffFields(existing.fields(), spec.fields())
+		change.action = conflictAction(len(change.changes) > 0, onConflict)
+
+		if change.action == importSkip || change.action == importConflict {
+			change.reason = "command already exists with different settings"
+		}
+
+		plan = append(plan, change)
+	}
+
+	for i := range file.Projects {
+		spec := &file.Projects[i]
+		change := importChange{kind: "project", name: spec.Name, project: spec}
+
+		for _, commandName := range spec.Commands {
+			if !slices.ContainsFunc(file.Commands, func(command CommandSpec) bool { return command.Name == commandName }) {
+				if exists, _ := c.CommandExists(commandName); !exists {
+					return nil, fmt.Errorf("project '%s' uses unknown command '%s'", spec.Name, commandName)
+				}
+			}
+		}
+
+		if spec.Dir != "" {
+			info, err := os.Stat(spec.Dir)
+
+			if err != nil || !info.IsDir() {
+				return nil, fmt.Errorf("directory '%s' of project '%s' does not exist", spec.Dir, spec.Name)
+			}
+		}
+
+		exists, project := c.ProjectExists(spec.Name)
+
+		// Keep the directory of an existing project when the file does not specify one
+		if exists && spec.Dir == "" {
+			spec.Dir = project.Dir.String
+		}
+
+		if portUser := c.portUser(file, *spec); portUser != "" {
+			change.reason = fmt.Sprintf("port %d is already used by project '%s'", spec.Port, portUser)
+			change.action = importConflict
+
+			if onConflict == ImportConflictSkip {
+				change.action = importSkip
+			}
+
+			plan = append(plan, change)
+			continue
+		}
+
+		if !exists {
+			change.action = importCreate
+			change.changes = diffFields(nil, nonEmptyFields(spec.fields()))
+			plan = append(plan, change)
+			continue
+		}
+
+		change.changes = diffFields(projectSpec(project).fields(), spec.fields())
+		change.action = conflictAction(len(change.changes) > 0, onConflict)
+
+		if change.action == importSkip || change.action == importConflict {
+			change.reason = "project already exists with different settings"
+		}
+
+		plan = append(plan, change)
+	}
+
+	return plan, nil
+}
+
+// Get the fields that have a value.
+func nonEmptyFields(fields []specField) []specField {
+	return slices.DeleteFunc(fields, func(field specField) bool { return field.value == "" })
+}
+
+// Get the action for an existing command or project, based on whether it differs from the imported one.
+func conflictAction(differs bool, onConflict string) string {
+	if !differs {
+		return importUnchanged
+	}
+
+	switch onConflict {
+	case ImportConflictOverwrite:
+		return importUpdate
+	case ImportConflictSkip:
+		return importSkip
+	}
+
+	return importConflict
+}
+
+// Get the name of the existing project that uses the port of the given project spec and
+// keeps using it after the import, or an empty string if there is none.
+func (c *Core) portUser(file SpinupFile, spec ProjectSpec) string {
+	for _, project := range c.projects {
+		if project.Name == spec.Name || project.Port != spec.Port {
+			continue
+		}
+
+		index := slices.IndexFunc(file.Projects, func(other ProjectSpec) bool { return other.Name == project.Name })
+
+		if index == -1 || file.Projects[index].Port == spec.Port {
+			return project.Name
+		}
+	}
+
+	return ""
+}
+
+// Create or update a command from its spec, without its dependencies.
+func (c *Core) importCommand(change importChange) common.Msg {
+	spec := change.command.withDefaults()
+
+	var msg common.Msg
+
+	if change.action == importCreate {
+		msg = c.AddCommand(spec.Name, spec.Command)
+	} else {
+		msg = c.UpdateCommand(spec.Name, spec.Command)
+	}
+
+	if _, ok := msg.(*common.ErrMsg); ok {
+		return msg
+	}
+
+	for _, msg := range []func() common.Msg{
+		func() common.Msg { return c.SetCommandSettings(spec.Name, spec.settings()) },
+		func() common.Msg { return c.SetCommandReadiness(spec.Name, spec.ReadyCheck, spec.ReadyValue) },
+		func() common.Msg { return c.SetCommandRestartPolicy(spec.Name, spec.Restart, *spec.MaxRetries) },
+		func() common.Msg { return c.SetCommandStopSignal(spec.Name, spec.StopSignal, spec.StopTimeout) },
+	} {
+		if msg, ok := msg().(*common.ErrMsg); ok {
+			return msg
+		}
+	}
+
+	return nil
+}
+
+// Create or update a project from its spec, replacing its variables, env files, domain aliases and routes.
+func (c *Core) importProject(change importChange) common.Msg {
+	spec := change.project
+
+	var msg common.Msg
+
+	if change.action == importCreate {
+		msg = c.AddProject(spec.Name, spec.Port, spec.Commands)
+	} else {
+		msg = c.UpdateProject(spec.Name, spec.Port, spec.Commands)
+	}
+
+	if _, ok := msg.(*common.ErrMsg); ok {
+		return msg
+	}
+
+	err := c.FetchProjects()
+
+	if err != nil {
+		return common.NewErrMsg("Error getting projects: %s", err)
+	}
+
+	_, project := c.ProjectExists(spec.Name)
+	msgs := []common.Msg{}
+
+	if spec.Dir != "" && spec.Dir != project.Dir.String {
+		msgs = append(msgs, c.SetProjectDir(spec.Name, &spec.Dir))
+	}
+
+	for _, variable := range project.Variables {
+		if value, ok := spec.Variables[variable.Name]; !ok || value != variable.Value {
+			msgs = append(msgs, c.RemoveVariable(spec.Name, variable.Name))
+		}
+	}
+
+	for _, envVariable := range project.EnvVariables {
+		if _, ok := spec.Env[envVariable.Name]; !ok {
+			msgs = append(msgs, c.RemoveEnvVariable(spec.Name, envVariable.Name))
+		}
+	}
+
+	for _, envFile := range project.EnvFiles {
+		if !slices.Contains(spec.EnvFiles, envFile.Path) {
+			msgs = append(msgs, c.RemoveEnvFile(spec.Name, envFile.Path))
+		}
+	}
+
+	for _, domainAlias := range project.DomainAliases {
+		if !slices.Contains(spec.DomainAliases, domainAlias.Value) {
+			msgs = append(msgs, c.RemoveDomainAlias(spec.Name, domainAlias.Value))
+		}
+	}
+
+	routeSpecs := []RouteSpec{}
+
+	for _, route := range spec.Routes {
+		routeSpecs = append(routeSpecs, route.normalized())
+	}
+
+	for _, route := range project.Routes {
+		if !slices.Contains(routeSpecs, RouteSpec{Path: route.Path, Port: route.Port, StripPrefix: route.StripPrefix}) {
+			msgs = append(msgs, c.RemoveRoute(spec.Name, route.Path))
+		}
+	}
+
+	// Refetch the project, so the removed values are not considered to still exist
+	err = c.FetchProjects()
+
+	if err != nil {
+		return common.NewErrMsg("Error getting projects: %s", err)
+	}
+
+	_, project = c.ProjectExists(spec.Name)
+
+	for _, key := range sortedKeys(spec.Variables) {
+		if !slices.ContainsFunc(project.Variables, func(variable Variable) bool { return variable.Name == key }) {
+			msgs = append(msgs, c.AddVariable(spec.Name, key, spec.Variables[key]))
+		}
+	}
+
+	for _, key := range sortedKeys(spec.Env) {
+		msgs = append(msgs, c.AddEnvVariable(spec.Name, key, spec.Env[key]))
+	}
+
+	for _, path := range spec.EnvFiles {
+		if !slices.ContainsFunc(project.EnvFiles, func(envFile EnvFile) bool { return envFile.Path == path }) {
+			msgs = append(msgs, c.AddEnvFile(spec.Name, path))
+		}
+	}
+
+	for _, alias := range spec.DomainAliases {
+		if !slices.ContainsFunc(project.DomainAliases, func(domainAlias DomainAlias) bool { return domainAlias.Value == alias }) {
+			msgs = append(msgs, c.AddDomainAlias(spec.Name, alias))
+		}
+	}
+
+	for _, routeSpec := range routeSpecs {
+		if !slices.ContainsFunc(project.Routes, func(route Route) bool { return route.Path == routeSpec.Path }) {
+			msgs = append(msgs, c.AddRoute(spec.Name, routeSpec.Path, routeSpec.Port, routeSpec.StripPrefix))
+		}
+	}
+
+	for _, msg := range msgs {
+		if _, ok := msg.(*common.ErrMsg); ok {
+			return msg
+		}
+	}
+
+	err = c.FetchProjects()
+
+	if err != nil {
+		return common.NewErrMsg("Error getting projects: %s", err)
+	}
+
+	return nil
+}
+
+// Parse the given spinup file contents and determine what importing it would change.
+//
+// Relative project directories are resolved against baseDir and projects without a directory
+// get defaultDir as their directory, unless it is empty.
+func (c *Core) planSpinupFile(data []byte, baseDir string, defaultDir string, onConflict string) (importPlan, error) {
+	file, err := ParseSpinupFile(data)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for i, project := range file.Projects {
+		if project.Dir == "" {
+			file.Projects[i].Dir = defaultDir
+		} else if !filepath.IsAbs(project.Dir) {
+			file.Projects[i].Dir = filepath.Join(baseDir, project.Dir)
+		}
+	}
+
+	err = c.FetchCommands()
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.FetchProjects()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return c.planImport(file, onConflict)
+}
+
+// Create and update the commands and projects of the given plan, commands first.
+func (c *Core) applyImport(plan importPlan) common.Msg {
+	for _, change := range plan {
+		if change.command != nil && (change.action == importCreate || change.action == importUpdate) {
+			if msg := c.importCommand(change); msg != nil {
+				return msg
+			}
+		}
+	}
+
+	// Set the dependencies once all commands exist
+	for _, change := range plan {
+		if change.command != nil && (change.action == importCreate || change.action == importUpdate) {
+			if msg, ok := c.SetCommandDependencies(change.name, change.command.DependsOn).(*common.ErrMsg); ok {
+				return msg
+			}
+		}
+	}
+
+	err := c.FetchCommands()
+
+	if err != nil {
+		return common.NewErrMsg("Error getting commands: %s", err)
+	}
+
+	for _, change := range plan {
+		if change.project != nil && (change.action == importCreate || change.action == importUpdate) {
+			if msg := c.importProject(change); msg != nil {
+				return msg
+			}
+		}
+	}
+
+	return nil
+}
+
+// Import the commands and projects of the given spinup file contents.
+//
+// Relative project directories are resolved against baseDir, which is normally the directory of the file.
+// The changes are sent as a diff before they are applied. Nothing is imported when there are conflicts
+// or when dryRun is set.
+func (c *Core) ImportProjects(data []byte, baseDir string, onConflict string, dryRun bool) common.Msg {
+	if onConflict != ImportConflictAbort && onConflict != ImportConflictSkip && onConflict != ImportConflictOverwrite {
+		return common.NewErrMsg("Invalid conflict handling '%s', expected '%s', '%s' or '%s'", onConflict, ImportConflictAbort, ImportConflictSkip, ImportConflictOverwrite)
+	}
+
+	plan, err := c.planSpinupFile(data, baseDir, "", onConflict)
+
+	if err != nil {
+		return common.NewErrMsg("Invalid spinup file: %s", err)
+	}
+
+	c.sendMsg(common.NewRegularMsg("%s", plan))
+
+	if conflicts := plan.count(importConflict); conflicts > 0 {
+		return common.NewErrMsg("Found %d conflicts, nothing was imported. Overwrite or skip the existing commands and projects to import the file", conflicts)
+	}
+
+	if dryRun {
+		return common.NewInfoMsg("Dry run, nothing was imported")
+	}
+
+	if msg := c.applyImport(plan); msg != nil {
+		return msg
+	}
+
+	return common.NewSuccessMsg(
+		"Imported spinup file: %d created, %d updated, %d unchanged, %d skipped",
+		plan.count(importCreate), plan.count(importUpdate), plan.count(importUnchanged), plan.count(importSkip),
+	)
+}
+
+// Find the spinup file of the repository the given directory is part of,
+// by looking for one of SpinupFileNames in the directory and its parents.
+func FindSpinupFile(dir string) (string, bool) {
+	dir, err := filepath.Abs(dir)
+
+	if err != nil {
+		return "", false
+	}
+
+	for {
+		for _, name := range SpinupFileNames {
+			path := filepath.Join(dir, name)
+
+			if info, err := os.Stat(path); err == nil && !info.IsDir() {
+				return path, true
+			}
+		}
+
+		parent := filepath.Dir(dir)
+
+		if parent == dir {
+			return "", false
+		}
+
+		dir = parent
+	}
+}
+
+// Register the commands and projects of the spinup file at the given path that do not exist yet,
+// so the projects can be run. Returns the names of the projects in the file.
+//
+// Projects without a directory get the directory of the file. Existing commands and projects are
+// left as they are, changes to the file can be applied with ImportProjects.
+func (c *Core) RegisterSpinupFile(path string) ([]string, common.Msg) {
+	data, err := os.ReadFile(path)
+
+	if err != nil {
+		return nil, common.NewErrMsg("Error reading spinup file: %s", err)
+	}
+
+	dir := filepath.Dir(path)
+	plan, err := c.planSpinupFile(data, dir, dir, ImportConflictSkip)
+
+	if err != nil {
+		return nil, common.NewErrMsg("Invalid spinup file '%s': %s", path, err)
+	}
+
+	projectNames := []string{}
+
+	for _, change := range plan {
+		if change.project == nil {
+			if change.action == importSkip {
+				c.sendMsg(common.NewWarnMsg("Using existing command '%s', its settings differ from the ones in %s", change.name, path))
+			}
+
+			continue
+		}
+
+		exists, project := c.ProjectExists(change.name)
+
+		// Projects that can not be registered because of a conflict can not be run either
+		if !exists && change.action == importSkip {
+			return nil, common.NewErrMsg("Can not register project '%s' from %s: %s", change.name, path, change.reason)
+		}
+
+		if change.action == importSkip {
+			if project.Dir.Valid {
+				c.sendMsg(common.NewWarnMsg("Using existing project '%s' in %s, its settings differ from the ones in %s", change.name, project.Dir.String, path))
+			} else {
+				c.sendMsg(common.NewWarnMsg("Using existing project '%s' without a directory, its settings differ from the ones in %s", change.name, path))
+			}
+		}
+
+		projectNames = append(projectNames, change.name)
+	}
+
+	if len(projectNames) == 0 {
+		return nil, common.NewErrMsg("Spinup file '%s' does not contain any projects", path)
+	}
+
+	if msg := c.applyImport(plan); msg != nil {
+		return nil, msg
+	}
+
+	for _, change := range plan {
+		if change.project != nil && change.action == importCreate {
+			c.sendMsg(common.NewSuccessMsg("Registered project '%s' from %s", change.name, path))
+		}
+	}
+
+	return projectNames, nil
+}
@@ -0,0 +1,318 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/iskandervdh/spinup/common"
+)
+
+const testSpinupFile = `version: 1
+commands:
+  - name: api
+    command: go run .
+    ready_check: http
+    ready_value: /health
+  - name: web
+    command: npm run dev
+    dir: frontend
+    restart: on-failure
+    depends_on: [api]
+projects:
+  - name: shop
+    port: 3000
+    commands: [api, web]
+    variables:
+      API_URL: http://localhost:3000
+    env:
+      NODE_ENV: development
+    domain_aliases: [admin.shop.test]
+    routes:
+      - path: /api/
+        port: 3001
+        strip_prefix: true
+`
+
+func TestParseSpinupFile(t *testing.T) {
+	_, err := ParseSpinupFile([]byte(testSpinupFile))
+
+	if err != nil {
+		t.Error("Expected spinup file to be valid, got", err)
+	}
+
+	invalidFiles := map[string]string{
+		"unknown field":    "version: 1\nprojects:\n  - name: shop\n    prot: 3000\n",
+		"missing version":  "projects:\n  - name: shop\n    port: 3000\n",
+		"duplicate port":   "version: 1\nprojects:\n  - name: a\n    port: 3000\n  - name: b\n    port: 3000\n",
+		"invalid restart":  "version: 1\ncommands:\n  - name: a\n    command: a\n    restart: sometimes\n",
+		"invalid env name": "version: 1\nprojects:\n  - name: a\n    port: 3000\n    env:\n      NOT-VALID: x\n",
+		"invalid route":    "version: 1\nprojects:\n  - name: a\n    port: 3000\n    routes:\n      - path: api\n        port: 3001\n",
+	}
+
+	for name, data := range invalidFiles {
+		if _, err := ParseSpinupFile([]byte(data)); err == nil {
+			t.Errorf("Expected error for spinup file with %s", name)
+		}
+	}
+}
+
+func TestImportProjects(t *testing.T) {
+	c := TestingCore("import_projects")
+
+	c.FetchCommands()
+	c.FetchProjects()
+
+	msg := c.ImportProjects([]byte(testSpinupFile), t.TempDir(), ImportConflictAbort, true)
+
+	if _, ok := msg.(*common.InfoMsg); !ok {
+		t.Error("Expected dry run to succeed, got", msg.GetText())
+		return
+	}
+
+	if exists, _ := c.ProjectExists("shop"); exists {
+		t.Error("Expected dry run not to import the project")
+		return
+	}
+
+	msg = c.ImportProjects([]byte(testSpinupFile), t.TempDir(), ImportConflictAbort, false)
+
+	if _, ok := msg.(*common.SuccessMsg); !ok {
+		t.Error("Expected spinup file to be imported, got", msg.GetText())
+		return
+	}
+
+	exists, project := c.ProjectExists("shop")
+
+	if !exists {
+		t.Error("Expected project to be imported")
+		return
+	}
+
+	if project.Port != 3000 || len(project.Commands) != 2 || len(project.Variables) != 1 || len(project.EnvVariables) != 1 || len(project.DomainAliases) != 1 || len(project.Routes) != 1 {
+		t.Error("Expected project to be imported with all of its settings, got", project)
+	}
+
+	_, web := c.CommandExists("web")
+
+	if web.Dir.String != "frontend" || web.RestartPolicy != RestartOnFailure || !web.ForceColor || web.MaxRetries != DefaultMaxRetries {
+		t.Error("Expected command to be imported with its settings and defaults, got", web)
+	}
+
+	if dependsOn, _ := c.GetCommandDependencies("web"); !slices.Equal(dependsOn, []string{"api"}) {
+		t.Error("Expected command dependencies to be imported, got", dependsOn)
+	}
+
+	if len(project.Routes) != 1 || project.Routes[0].Path != "/api" || project.Routes[0].Port != 3001 || !project.Routes[0].StripPrefix {
+		t.Error("Expected route to be imported with a normalized path, got", project.Routes)
+	}
+
+	// Importing the same file again should not change anything
+	msg = c.ImportProjects([]byte(testSpinupFile), t.TempDir(), ImportConflictAbort, false)
+
+	if !strings.Contains(msg.GetText(), "0 created, 0 updated, 3 unchanged") {
+		t.Error("Expected reimporting the same file to leave everything unchanged, got", msg.GetText())
+	}
+}
+
+func TestImportProjectsConflicts(t *testing.T) {
+	c := TestingCore("import_projects_conflicts")
+
+	c.FetchCommands()
+	c.FetchProjects()
+
+	c.AddCommand("api", "go run ./cmd/api")
+	c.AddProject("blog", 3000, []string{})
+
+	// "Refetch" the projects from the config file
+	c.FetchProjects()
+
+	msg := c.ImportProjects([]byte(testSpinupFile), t.TempDir(), ImportConflictOverwrite, false)
+
+	if _, ok := msg.(*common.ErrMsg); !ok {
+		t.Error("Expected a port that is used by another project to be a conflict, got", msg.GetText())
+		return
+	}
+
+	c.RemoveProject("blog")
+	c.FetchProjects()
+
+	msg = c.ImportProjects([]byte(testSpinupFile), t.TempDir(), ImportConflictAbort, false)
+
+	if _, ok := msg.(*common.ErrMsg); !ok {
+		t.Error("Expected an existing command with different settings to be a conflict, got", msg.GetText())
+		return
+	}
+
+	if exists, _ := c.ProjectExists("shop"); exists {
+		t.Error("Expected nothing to be imported when there are conflicts")
+	}
+
+	msg = c.ImportProjects([]byte(testSpinupFile), t.TempDir(), ImportConflictSkip, false)
+
+	if _, ok := msg.(*common.SuccessMsg); !ok {
+		t.Error("Expected import to skip the conflicting command, got", msg.GetText())
+		return
+	}
+
+	if _, api := c.CommandExists("api"); api.Command != "go run ./cmd/api" {
+		t.Error("Expected skipped command to keep its settings, got", api.Command)
+	}
+
+	msg = c.ImportProjects([]byte(testSpinupFile), t.TempDir(), ImportConflictOverwrite, false)
+
+	if _, ok := msg.(*common.SuccessMsg); !ok {
+		t.Error("Expected import to overwrite the conflicting command, got", msg.GetText())
+		return
+	}
+
+	if _, api := c.CommandExists("api"); api.Command != "go run ." {
+		t.Error("Expected overwritten command to be updated, got", api.Command)
+	}
+}
+
+func TestExportProjects(t *testing.T) {
+	c := TestingCore("export_projects")
+
+	c.FetchCommands()
+	c.FetchProjects()
+
+	baseDir := t.TempDir()
+	os.Mkdir(filepath.Join(baseDir, "shop"), 0755)
+
+	c.AddCommand("unused", "sleep 10")
+	c.ImportProjects([]byte(strings.Replace(testSpinupFile, "port: 3000", "port: 3000\n    dir: shop", 1)), baseDir, ImportConflictAbort, false)
+
+	data, msg := c.ExportProjects([]string{"shop"}, baseDir)
+
+	if msg != nil {
+		t.Error("Expected project to be exported, got", msg.GetText())
+		return
+	}
+
+	file, err := ParseSpinupFile(data)
+
+	if err != nil {
+		t.Error("Expected exported file to be valid, got", err)
+		return
+	}
+
+	if len(file.Commands) != 2 || len(file.Projects) != 1 {
+		t.Error("Expected only the project and the commands it uses to be exported, got", string(data))
+	}
+
+	if file.Projects[0].Dir != "shop" {
+		t.Error("Expected project directory to be relative to the base directory, got", file.Projects[0].Dir)
+	}
+
+	if strings.Contains(string(data), "force_color") || strings.Contains(string(data), "stop_signal") {
+		t.Error("Expected settings with their default value to be omitted, got", string(data))
+	}
+
+	// Exporting and importing into a new core should result in the same projects and commands
+	other := TestingCore("export_projects_import")
+
+	other.FetchCommands()
+	other.FetchProjects()
+
+	msg = other.ImportProjects(data, baseDir, ImportConflictAbort, false)
+
+	if _, ok := msg.(*common.SuccessMsg); !ok {
+		t.Error("Expected exported file to be imported, got", msg.GetText())
+		return
+	}
+
+	_, project := c.ProjectExists("shop")
+	_, otherProject := other.ProjectExists("shop")
+
+	if changes := diffFields(projectSpec(project).fields(), projectSpec(otherProject).fields()); len(changes) != 0 {
+		t.Error("Expected imported project to be equal to the exported one, got", changes)
+	}
+
+	if _, msg := c.ExportProjects([]string{"unknown"}, baseDir); msg == nil {
+		t.Error("Expected error when exporting a project that does not exist")
+	}
+}
+
+func TestFindSpinupFile(t *testing.T) {
+	repoDir := t.TempDir()
+	nestedDir := filepath.Join(repoDir, "frontend", "src")
+
+	os.MkdirAll(nestedDir, 0755)
+
+	if _, found := FindSpinupFile(nestedDir); found {
+		t.Error("Expected no spinup file to be found")
+		return
+	}
+
+	os.WriteFile(filepath.Join(repoDir, ".spinup.yaml"), []byte(testSpinupFile), 0644)
+
+	path, found := FindSpinupFile(nestedDir)
+
+	if !found || path != filepath.Join(repoDir, ".spinup.yaml") {
+		t.Error("Expected spinup file in a parent directory to be found, got", path)
+	}
+}
+
+func TestRegisterSpinupFile(t *testing.T) {
+	warnings := make(chan string, 10)
+	c := testingCoreWithMsgHandler("register_spinup_file", func(msg common.Msg) {
+		if _, ok := msg.(*common.WarnMsg); ok {
+			warnings <- msg.GetText()
+		}
+	})
+
+	c.FetchCommands()
+	c.FetchProjects()
+
+	repoDir := t.TempDir()
+	path := filepath.Join(repoDir, ".spinup.yaml")
+
+	os.WriteFile(path, []byte(testSpinupFile), 0644)
+
+	projectNames, msg := c.RegisterSpinupFile(path)
+
+	if msg != nil {
+		t.Error("Expected spinup file to be registered, got", msg.GetText())
+		return
+	}
+
+	if !slices.Equal(projectNames, []string{"shop"}) {
+		t.Error("Expected the projects of the spinup file to be returned, got", projectNames)
+	}
+
+	exists, project := c.ProjectExists("shop")
+
+	if !exists || project.Dir.String != repoDir {
+		t.Error("Expected project to be registered with the directory of the spinup file, got", project.Dir.String)
+	}
+
+	// Changes to the file are not applied to a project that is already registered
+	os.WriteFile(path, []byte(strings.Replace(testSpinupFile, "port: 3000", "port: 3001", 1)), 0644)
+
+	if _, msg := c.RegisterSpinupFile(path); msg != nil {
+		t.Error("Expected registered project to be used as it is, got", msg.GetText())
+	}
+
+	if _, project := c.ProjectExists("shop"); project.Port != 3000 {
+		t.Error("Expected registered project to keep its port, got", project.Port)
+	}
+
+	select {
+	case warning := <-warnings:
+		if !strings.Contains(warning, "Using existing project 'shop' in "+repoDir) {
+			t.Error("Expected warning about the existing project and its directory, got", warning)
+		}
+	case <-time.After(time.Second):
+		t.Error("Expected warning when using an existing project with different settings")
+	}
+
+	os.WriteFile(path, []byte(strings.Replace(testSpinupFile, "name: shop", "name: other", 1)), 0644)
+
+	if _, msg := c.RegisterSpinupFile(path); msg == nil {
+		t.Error("Expected error when registering a project with a port that is already used")
+	}
+}
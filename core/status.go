@@ -0,0 +1,95 @@
+package core
+
+import (
+	"time"
+)
+
+// States of a command of a running project.
+const (
+	CommandWaiting    = "waiting"
+	CommandRunning    = "running"
+	CommandRestarting = "restarting"
+	CommandExited     = "exited"
+	CommandStopped    = "stopped"
+)
+
+// CommandStatus describes the state of a command of a running project.
+type CommandStatus struct {
+	Project string `json:"project"`
+	Command string `json:"command"`
+	State   string `json:"state"`
+
+	// Process ID of the command, 0 if the command is not running
+	PID int `json:"pid"`
+
+	// When the command was last started, zero if it has not been started yet
+	StartedAt time.Time `json:"startedAt"`
+
+	// Number of seconds the command has been running since it was last started
+	Uptime int64 `json:"uptime"`
+
+	// Total number of times the command was restarted, either by its restart policy or because watched files changed
+	Restarts int `json:"restarts"`
+
+	// Exit code of the last run of the command, nil if it has not exited yet
+	ExitCode *int `json:"exitCode"`
+
+	// Port the project listens on
+	Port int64 `json:"port"`
+}
+
+// ProjectStatus describes a running project and the state of its commands.
+type ProjectStatus struct {
+	Project   string          `json:"project"`
+	Port      int64           `json:"port"`
+	StartedAt time.Time       `json:"startedAt"`
+	Commands  []CommandStatus `json:"commands"`
+}
+
+// Get the status of the given command.
+func (rc *runningCommand) status(project Project) CommandStatus {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	status := CommandStatus{
+		Project:   project.Name,
+		Command:   rc.name,
+		State:     rc.state,
+		StartedAt: rc.startedAt,
+		Restarts:  rc.restarts,
+		ExitCode:  rc.exitCode,
+		Port:      project.Port,
+	}
+
+	if rc.running {
+		status.PID = rc.cmd.Process.Pid
+		status.Uptime = int64(time.Since(rc.startedAt).Seconds())
+	}
+
+	return status
+}
+
+// Get the status of the project that is being run by this Core instance.
+//
+// Returns false if no project is running.
+func (c *Core) GetStatus() (ProjectStatus, bool) {
+	c.runMu.Lock()
+	defer c.runMu.Unlock()
+
+	if c.currentRun == nil {
+		return ProjectStatus{}, false
+	}
+
+	status := ProjectStatus{
+		Project:   c.currentRun.project.Name,
+		Port:      c.currentRun.project.Port,
+		StartedAt: c.currentRun.startedAt,
+		Commands:  []CommandStatus{},
+	}
+
+	for _, command := range c.currentRun.commands {
+		status.Commands = append(status.Commands, command.status(c.currentRun.project))
+	}
+
+	return status, true
+}
@@ -0,0 +1,75 @@
+package core
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestGetStatus(t *testing.T) {
+	c := TestingCore("get_status")
+
+	c.FetchCommands()
+	c.FetchProjects()
+
+	if _, ok := c.GetStatus(); ok {
+		t.Error("Expected no status when no project is running")
+	}
+
+	c.AddCommand("server", "sleep 10")
+	c.AddCommand("crash", "exit 3")
+	c.SetCommandShell("crash", true)
+
+	c.AddProject("test", 1234, []string{"server", "crash"})
+
+	// "Refetch" the projects from the config file
+	c.FetchProjects()
+
+	// Create the signal channel before running, so the test can stop the project
+	sigChan := make(chan os.Signal, 1)
+	c.sigChan = &sigChan
+
+	done := make(chan struct{})
+
+	go func() {
+		c.TryToRun("test")
+		close(done)
+	}()
+
+	time.Sleep(500 * time.Millisecond)
+
+	status, ok := c.GetStatus()
+
+	if !ok {
+		t.Error("Expected status of running project")
+		return
+	}
+
+	if status.Project != "test" || status.Port != 1234 || len(status.Commands) != 2 {
+		t.Error("Expected status of project 'test' with 2 commands, got", status)
+		return
+	}
+
+	server := status.Commands[0]
+
+	if server.Command != "server" || server.State != CommandRunning || server.PID == 0 || server.ExitCode != nil {
+		t.Error("Expected command 'server' to be running, got", server)
+	}
+
+	crash := status.Commands[1]
+
+	if crash.Command != "crash" || crash.State != CommandExited || crash.PID != 0 {
+		t.Error("Expected command 'crash' to have exited, got", crash)
+	}
+
+	if crash.ExitCode == nil || *crash.ExitCode != 3 {
+		t.Error("Expected command 'crash' to have exit code 3, got", crash.ExitCode)
+	}
+
+	sigChan <- os.Interrupt
+	<-done
+
+	if _, ok := c.GetStatus(); ok {
+		t.Error("Expected no status after the project stopped")
+	}
+}
@@ -0,0 +1,106 @@
+package core
+
+import (
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/iskandervdh/spinup/common"
+)
+
+// Signals that can be used to stop a command.
+var stopSignals = map[string]syscall.Signal{
+	"SIGINT":  syscall.SIGINT,
+	"SIGTERM": syscall.SIGTERM,
+	"SIGQUIT": syscall.SIGQUIT,
+}
+
+// Signal that is used to stop a command if none is configured.
+const defaultStopSignal = "SIGTERM"
+
+// How long to wait for a command to stop if no grace period is configured.
+const DefaultStopTimeout = 10 * time.Second
+
+// Get the names of the signals that can be used to stop a command, sorted alphabetically.
+func stopSignalNames() []string {
+	names := []string{}
+
+	for name := range stopSignals {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	return names
+}
+
+// Normalize the given signal name, so "int", "INT" and "SIGINT" all result in "SIGINT".
+func normalizeStopSignal(signal string) string {
+	signal = strings.ToUpper(strings.TrimSpace(signal))
+
+	if !strings.HasPrefix(signal, "SIG") {
+		signal = "SIG" + signal
+	}
+
+	return signal
+}
+
+// Check if the given signal can be used to stop a command.
+func isValidStopSignal(signal string) bool {
+	_, ok := stopSignals[signal]
+
+	return ok
+}
+
+// Get the signal and grace period that should be used to stop the given command.
+func commandStopSignal(command Command) (string, time.Duration) {
+	signal := command.StopSignal
+
+	if !isValidStopSignal(signal) {
+		signal = defaultStopSignal
+	}
+
+	timeout := time.Duration(command.StopTimeout) * time.Second
+
+	if timeout <= 0 {
+		timeout = DefaultStopTimeout
+	}
+
+	return signal, timeout
+}
+
+// Stop the processes of the given command with its stop signal, killing them if the command did not exit
+// within its grace period. Must be called while holding the lock of the command.
+func (c *Core) stopProcess(command *runningCommand) {
+	process := command.cmd.Process
+	exited := command.exited
+	err := signalProcess(process, stopSignals[command.stopSignal])
+
+	if err != nil {
+		c.sendMsg(common.NewErrMsg("Failed to send %s to command '%s': %s", command.stopSignal, command.name, err))
+	}
+
+	// runCommand waits for the processes to be stopped before restarting the command
+	command.stops.Add(1)
+
+	go func() {
+		defer command.stops.Done()
+
+		select {
+		case <-exited:
+			// Kill processes started by the command that are left behind, so they do not keep holding ports
+			forceKillProcess(process)
+			return
+		case <-time.After(command.stopTimeout):
+		}
+
+		c.sendMsg(common.NewWarnMsg("Command '%s' did not stop within %s after %s, killing it", command.name, command.stopTimeout, command.stopSignal))
+
+		err := forceKillProcess(process)
+
+		if err != nil {
+			c.sendMsg(common.NewErrMsg("Failed to kill command '%s': %s", command.name, err))
+		}
+	}()
+}
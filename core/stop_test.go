@@ -0,0 +1,82 @@
+package core
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestNormalizeStopSignal(t *testing.T) {
+	tests := map[string]string{
+		"SIGINT":   "SIGINT",
+		"int":      "SIGINT",
+		" TERM ":   "SIGTERM",
+		"sigquit":  "SIGQUIT",
+		"SIGUSR1":  "SIGUSR1",
+		"notvalid": "SIGNOTVALID",
+	}
+
+	for signal, expected := range tests {
+		if normalized := normalizeStopSignal(signal); normalized != expected {
+			t.Errorf("Expected %q to be normalized to %q, got %q", signal, expected, normalized)
+		}
+	}
+}
+
+func TestCommandStopSignal(t *testing.T) {
+	signal, timeout := commandStopSignal(Command{StopSignal: "SIGINT", StopTimeout: 3})
+
+	if signal != "SIGINT" || timeout != 3*time.Second {
+		t.Error("Expected SIGINT with a grace period of 3s, got", signal, timeout)
+	}
+
+	signal, timeout = commandStopSignal(Command{})
+
+	if signal != defaultStopSignal || timeout != DefaultStopTimeout {
+		t.Error("Expected default stop signal and grace period, got", signal, timeout)
+	}
+}
+
+func TestRunForceKill(t *testing.T) {
+	c := TestingCore("run_force_kill")
+
+	c.FetchCommands()
+	c.FetchProjects()
+
+	// Ignore SIGTERM in both the shell and the sleep command it starts
+	c.AddCommand("stubborn", "trap '' TERM; sleep 30")
+	c.SetCommandShell("stubborn", true)
+	c.SetCommandStopSignal("stubborn", "SIGTERM", 1)
+
+	c.AddProject("test", 1234, []string{"stubborn"})
+
+	// "Refetch" the projects from the config file
+	c.FetchProjects()
+
+	// Create the signal channel before running, so the test can stop the project
+	sigChan := make(chan os.Signal, 1)
+	c.sigChan = &sigChan
+
+	done := make(chan struct{})
+
+	go func() {
+		c.TryToRun("test")
+		close(done)
+	}()
+
+	time.Sleep(500 * time.Millisecond)
+
+	stoppedAt := time.Now()
+	sigChan <- os.Interrupt
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Error("Expected command to be killed after its grace period")
+		return
+	}
+
+	if elapsed := time.Since(stoppedAt); elapsed < time.Second {
+		t.Error("Expected command to be killed after its grace period of 1 second, got", elapsed)
+	}
+}
@@ -0,0 +1,145 @@
+package core
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/iskandervdh/spinup/common"
+	"github.com/iskandervdh/spinup/config"
+	"github.com/iskandervdh/spinup/database/sqlc"
+)
+
+// Get the top-level domain of the domains of projects.
+func (c *Core) GetTLD() string {
+	return c.config.GetTLD()
+}
+
+// Change the top-level domain of the domains of projects and migrate everything that uses it.
+//
+// Domain aliases under the previous TLD are moved to the new one, the reverse proxy configuration files
+// and certificates are generated for the new domains, and the dnsmasq configuration file is rewritten.
+func (c *Core) SetTLD(tld string) common.Msg {
+	newTLD, err := config.NormalizeTLD(tld)
+
+	if err != nil {
+		return common.NewErrMsg("%s", err)
+	}
+
+	oldTLD := c.config.GetTLD()
+
+	if newTLD == oldTLD {
+		return common.NewInfoMsg("The TLD is already .%s", newTLD)
+	}
+
+	err = c.FetchProjects()
+
+	if err != nil {
+		return common.NewErrMsg("Error getting projects: %s", err)
+	}
+
+	// Check that the moved domain aliases do not become the domain or domain alias of another project
+	domains := map[string]string{}
+
+	for _, project := range c.projects {
+		domains[project.Name+"."+newTLD] = project.Name
+
+		for _, domainAlias := range project.DomainAliases {
+			if !strings.HasSuffix(domainAlias.Value, "."+oldTLD) {
+				domains[domainAlias.Value] = project.Name
+			}
+		}
+	}
+
+	aliasUpdates := []sqlc.UpdateDomainAliasParams{}
+
+	for _, project := range c.projects {
+		for _, domainAlias := range project.DomainAliases {
+			name, ok := strings.CutSuffix(domainAlias.Value, "."+oldTLD)
+
+			if !ok {
+				continue
+			}
+
+			movedAlias := name + "." + newTLD
+
+			if other, exists := domains[movedAlias]; exists {
+				return common.NewErrMsg("Domain alias '%s' of project '%s' would become '%s', which is already used by project '%s'", domainAlias.Value, project.Name, movedAlias, other)
+			}
+
+			domains[movedAlias] = project.Name
+			aliasUpdates = append(aliasUpdates, sqlc.UpdateDomainAliasParams{
+				Value:     movedAlias,
+				Value_2:   domainAlias.Value,
+				ProjectID: project.ID,
+			})
+		}
+	}
+
+	// Save the setting before anything else is changed, so nothing is written if saving it fails
+	err = c.config.SetTLD(newTLD)
+
+	if err != nil {
+		return common.NewErrMsg("Error saving TLD setting: %s", err)
+	}
+
+	movedAliases := []sqlc.UpdateDomainAliasParams{}
+
+	for _, aliasUpdate := range aliasUpdates {
+		err = c.dbQueries.UpdateDomainAlias(c.dbContext, aliasUpdate)
+
+		if err != nil {
+			return c.restoreTLD(oldTLD, movedAliases, common.NewErrMsg("Error updating domain alias '%s': %s", aliasUpdate.Value_2, err))
+		}
+
+		movedAliases = append(movedAliases, aliasUpdate)
+		c.sendMsg(common.NewInfoMsg("Moved domain alias '%s' to '%s'", aliasUpdate.Value_2, aliasUpdate.Value))
+	}
+
+	msg := c.SyncProxyConfigs(false)
+
+	if _, ok := msg.(*common.ErrMsg); ok {
+		return c.restoreTLD(oldTLD, movedAliases, msg)
+	}
+
+	c.sendMsg(msg)
+
+	err = c.config.WriteDnsmasqConfig()
+
+	if err != nil {
+		return c.restoreTLD(oldTLD, movedAliases, common.NewErrMsg("Error updating dnsmasq config %s: %s", c.config.GetDnsmasqConfigPath(), err))
+	}
+
+	return common.NewSuccessMsg("Changed the TLD from .%s to .%s", oldTLD, newTLD)
+}
+
+// Undo a failed change of the TLD by moving the given domain aliases back, saving the given previous TLD
+// and syncing the reverse proxy configuration files again.
+//
+// Returns the given error message, extended with the reason the TLD could not be restored if that failed.
+func (c *Core) restoreTLD(oldTLD string, movedAliases []sqlc.UpdateDomainAliasParams, errMsg common.Msg) common.Msg {
+	for _, aliasUpdate := range slices.Backward(movedAliases) {
+		err := c.dbQueries.UpdateDomainAlias(c.dbContext, sqlc.UpdateDomainAliasParams{
+			Value:     aliasUpdate.Value_2,
+			Value_2:   aliasUpdate.Value,
+			ProjectID: aliasUpdate.ProjectID,
+		})
+
+		if err != nil {
+			return common.NewErrMsg("%s, could not move domain alias '%s' back: %s", errMsg.GetText(), aliasUpdate.Value, err)
+		}
+	}
+
+	err := c.config.SetTLD(oldTLD)
+
+	if err != nil {
+		return common.NewErrMsg("%s, could not restore the TLD .%s: %s", errMsg.GetText(), oldTLD, err)
+	}
+
+	_, err = c.syncProxyConfigs(false)
+
+	if err != nil {
+		return common.NewErrMsg("%s, could not restore the reverse proxy configs: %s", errMsg.GetText(), err)
+	}
+
+	return errMsg
+}
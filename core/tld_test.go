@@ -0,0 +1,130 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/iskandervdh/spinup/common"
+	"github.com/iskandervdh/spinup/config"
+)
+
+func TestSetTLD(t *testing.T) {
+	c := TestingCore("set_tld")
+
+	c.FetchCommands()
+	c.FetchProjects()
+
+	c.AddProject("example", 1234, []string{})
+	c.FetchProjects()
+	c.AddDomainAlias("example", "api.example.test")
+	c.AddDomainAlias("example", "example.localhost")
+
+	msg := c.SetTLD(".Dev.Internal")
+
+	if _, ok := msg.(*common.SuccessMsg); !ok {
+		t.Fatal("Expected TLD to be changed, got", msg.GetText())
+	}
+
+	if c.GetTLD() != "dev.internal" {
+		t.Error("Expected TLD to be dev.internal, got", c.GetTLD())
+	}
+
+	c.FetchProjects()
+	_, project := c.ProjectExists("example")
+
+	if project.Domain != "example.dev.internal" {
+		t.Error("Expected domain to be example.dev.internal, got", project.Domain)
+	}
+
+	aliases := []string{}
+
+	for _, domainAlias := range project.DomainAliases {
+		aliases = append(aliases, domainAlias.Value)
+	}
+
+	if strings.Join(aliases, " ") != "api.example.dev.internal example.localhost" {
+		t.Error("Expected domain aliases under the previous TLD to be moved, got", aliases)
+	}
+
+	nginxConfig, err := os.ReadFile(filepath.Join(c.config.GetNginxConfigDir(), "example.conf"))
+
+	if err != nil || !strings.Contains(string(nginxConfig), "server_name example.dev.internal api.example.dev.internal example.localhost;") {
+		t.Error("Expected nginx config to use the new TLD, got", string(nginxConfig), err)
+	}
+
+	dnsmasqConfig, err := os.ReadFile(c.config.GetDnsmasqConfigPath())
+
+	if err != nil || string(dnsmasqConfig) != config.RenderDnsmasqConfig("dev.internal") {
+		t.Error("Expected dnsmasq config to use the new TLD, got", string(dnsmasqConfig), err)
+	}
+
+	if _, ok := c.SetTLD("dev.internal").(*common.InfoMsg); !ok {
+		t.Error("Expected setting the same TLD to do nothing")
+	}
+
+	if _, ok := c.SetTLD("dev..internal").(*common.ErrMsg); !ok {
+		t.Error("Expected an invalid TLD to be rejected")
+	}
+}
+
+func TestSetTLDConflict(t *testing.T) {
+	c := TestingCore("set_tld_conflict")
+
+	c.FetchCommands()
+	c.FetchProjects()
+
+	c.AddProject("example", 1234, []string{})
+	c.AddProject("other", 1235, []string{})
+	c.FetchProjects()
+	c.AddDomainAlias("example", "shop.test")
+	c.AddDomainAlias("other", "shop.localhost")
+
+	if _, ok := c.SetTLD("localhost").(*common.ErrMsg); !ok {
+		t.Error("Expected moving a domain alias onto a domain alias of another project to fail")
+	}
+
+	if c.GetTLD() != config.DefaultTLD {
+		t.Error("Expected TLD not to be changed, got", c.GetTLD())
+	}
+}
+
+func TestSetTLDRestoredOnFailure(t *testing.T) {
+	c := TestingCore("set_tld_restored_on_failure")
+
+	c.FetchCommands()
+	c.FetchProjects()
+
+	c.AddProject("example", 1234, []string{})
+	c.FetchProjects()
+	c.AddDomainAlias("example", "api.example.test")
+
+	// Make writing the dnsmasq config fail by putting a directory in its place
+	err := os.MkdirAll(c.config.GetDnsmasqConfigPath(), 0755)
+
+	if err != nil {
+		t.Fatal("Could not create directory in place of the dnsmasq config:", err)
+	}
+
+	if _, ok := c.SetTLD("internal").(*common.ErrMsg); !ok {
+		t.Fatal("Expected changing the TLD to fail when the dnsmasq config cannot be written")
+	}
+
+	if c.GetTLD() != config.DefaultTLD {
+		t.Error("Expected TLD to be restored, got", c.GetTLD())
+	}
+
+	c.FetchProjects()
+	_, project := c.ProjectExists("example")
+
+	if len(project.DomainAliases) != 1 || project.DomainAliases[0].Value != "api.example.test" {
+		t.Error("Expected domain alias to be moved back, got", project.DomainAliases)
+	}
+
+	nginxConfig, err := os.ReadFile(filepath.Join(c.config.GetNginxConfigDir(), "example.conf"))
+
+	if err != nil || !strings.Contains(string(nginxConfig), "server_name example.test api.example.test;") {
+		t.Error("Expected nginx config to use the previous TLD, got", string(nginxConfig), err)
+	}
+}
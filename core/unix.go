@@ -11,6 +11,23 @@ func createProcessGroup() *syscall.SysProcAttr {
 	return &syscall.SysProcAttr{Setpgid: true}
 }
 
-func killProcess(process *os.Process) error {
-	return syscall.Kill(-process.Pid, syscall.SIGTERM)
+// Send the given signal to all processes in the process group of the given process.
+func signalProcess(process *os.Process, signal syscall.Signal) error {
+	return syscall.Kill(-process.Pid, signal)
+}
+
+// Kill all processes in the process group of the given process.
+func forceKillProcess(process *os.Process) error {
+	err := syscall.Kill(-process.Pid, syscall.SIGKILL)
+
+	// The processes may have exited in the meantime
+	if err == syscall.ESRCH {
+		return nil
+	}
+
+	return err
+}
+
+func shellCommandArgs(command string) []string {
+	return []string{"sh", "-c", command}
 }
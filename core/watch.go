@@ -0,0 +1,237 @@
+package core
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// How often the watched files of a command are checked for changes.
+const watchInterval = 500 * time.Millisecond
+
+// How long the watched files need to stay unchanged before the command is restarted,
+// so saving multiple files at once only results in a single restart.
+const watchDebounce = 300 * time.Millisecond
+
+// Directories that are never watched since they are large and rarely contain source files.
+var defaultWatchExcludes = []string{".git", "node_modules"}
+
+// Check if the given glob pattern is valid.
+func validateGlob(pattern string) error {
+	if pattern == "" || strings.Contains(pattern, "\n") {
+		return fmt.Errorf("watch pattern can not be empty")
+	}
+
+	if path.IsAbs(pattern) || filepath.IsAbs(pattern) {
+		return fmt.Errorf("watch pattern '%s' must be relative to the project directory", pattern)
+	}
+
+	for _, segment := range strings.Split(pattern, "/") {
+		if segment == ".." {
+			return fmt.Errorf("watch pattern '%s' must be inside the project directory", pattern)
+		}
+
+		if _, err := path.Match(segment, ""); err != nil {
+			return fmt.Errorf("invalid watch pattern '%s': %s", pattern, err)
+		}
+	}
+
+	return nil
+}
+
+// Check if the given slash separated path relative to the project directory matches the given glob pattern.
+//
+// Patterns without a slash match any file or directory with a matching name, like `*.go` or `node_modules`.
+// Other patterns are matched from the project directory, where `**` matches any number of directories.
+func matchGlob(pattern string, relativePath string) bool {
+	segments := strings.Split(relativePath, "/")
+
+	if !strings.Contains(pattern, "/") {
+		for _, segment := range segments {
+			if matched, _ := path.Match(pattern, segment); matched {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	return matchSegments(strings.Split(pattern, "/"), segments)
+}
+
+func matchSegments(pattern []string, segments []string) bool {
+	if len(pattern) == 0 {
+		return len(segments) == 0
+	}
+
+	if pattern[0] == "**" {
+		for i := 0; i <= len(segments); i++ {
+			if matchSegments(pattern[1:], segments[i:]) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	if len(segments) == 0 {
+		return false
+	}
+
+	if matched, _ := path.Match(pattern[0], segments[0]); !matched {
+		return false
+	}
+
+	return matchSegments(pattern[1:], segments[1:])
+}
+
+type watchedFile struct {
+	modTime time.Time
+	size    int64
+}
+
+// fileWatcher polls the files in a directory that match its patterns for changes.
+type fileWatcher struct {
+	root    string
+	include []string
+	exclude []string
+}
+
+// Create a new file watcher for the given directory, returns nil if there is nothing to watch.
+func newFileWatcher(root string, include []string, exclude []string) *fileWatcher {
+	if len(include) == 0 {
+		return nil
+	}
+
+	if root == "" {
+		root = "."
+	}
+
+	return &fileWatcher{
+		root:    root,
+		include: include,
+		exclude: slices.Concat(exclude, defaultWatchExcludes),
+	}
+}
+
+func (w *fileWatcher) isExcluded(relativePath string) bool {
+	for _, pattern := range w.exclude {
+		if matchGlob(pattern, relativePath) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (w *fileWatcher) isIncluded(relativePath string) bool {
+	for _, pattern := range w.include {
+		if matchGlob(pattern, relativePath) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Get the modification time and size of all watched files.
+func (w *fileWatcher) snapshot() map[string]watchedFile {
+	files := map[string]watchedFile{}
+
+	filepath.WalkDir(w.root, func(filePath string, entry fs.DirEntry, err error) error {
+		// Files can be removed while walking the directory, so errors are ignored
+		if err != nil {
+			return nil
+		}
+
+		relativePath, err := filepath.Rel(w.root, filePath)
+
+		if err != nil || relativePath == "." {
+			return nil
+		}
+
+		relativePath = filepath.ToSlash(relativePath)
+
+		if w.isExcluded(relativePath) {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if entry.IsDir() || !w.isIncluded(relativePath) {
+			return nil
+		}
+
+		info, err := entry.Info()
+
+		if err != nil {
+			return nil
+		}
+
+		files[relativePath] = watchedFile{modTime: info.ModTime(), size: info.Size()}
+
+		return nil
+	})
+
+	return files
+}
+
+// Get the path of a file that was added, changed or removed between the given snapshots.
+func changedFile(previous map[string]watchedFile, current map[string]watchedFile) (string, bool) {
+	for filePath, file := range current {
+		if previousFile, ok := previous[filePath]; !ok || previousFile != file {
+			return filePath, true
+		}
+	}
+
+	for filePath := range previous {
+		if _, ok := current[filePath]; !ok {
+			return filePath, true
+		}
+	}
+
+	return "", false
+}
+
+// Watch the files until the given stop channel is closed, calling onChange with
+// the path of a changed file once the files stopped changing.
+func (w *fileWatcher) watch(stop <-chan struct{}, onChange func(string)) {
+	previous := w.snapshot()
+
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+
+	pendingChange := ""
+	var lastChange time.Time
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+
+		current := w.snapshot()
+
+		if filePath, changed := changedFile(previous, current); changed {
+			if pendingChange == "" {
+				pendingChange = filePath
+			}
+
+			lastChange = time.Now()
+		}
+
+		previous = current
+
+		if pendingChange != "" && time.Since(lastChange) >= watchDebounce {
+			onChange(pendingChange)
+			pendingChange = ""
+		}
+	}
+}
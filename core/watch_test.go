@@ -0,0 +1,137 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestMatchGlob(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		path     string
+		expected bool
+	}{
+		{"*.go", "main.go", true},
+		{"*.go", "core/run.go", true},
+		{"*.go", "core/run_test.js", false},
+		{"node_modules", "frontend/node_modules", true},
+		{"**/*.go", "main.go", true},
+		{"**/*.go", "core/sub/run.go", true},
+		{"core/*.go", "core/run.go", true},
+		{"core/*.go", "core/sub/run.go", false},
+		{"core/**", "core/sub/run.go", true},
+		{"core/**", "cli/cli.go", false},
+		{"frontend/src/**/*.tsx", "frontend/src/components/app.tsx", true},
+	}
+
+	for _, test := range tests {
+		if matchGlob(test.pattern, test.path) != test.expected {
+			t.Error("Expected", test.pattern, "matching", test.path, "to be", test.expected)
+		}
+	}
+}
+
+func TestValidateGlob(t *testing.T) {
+	for _, pattern := range []string{"*.go", "**/*.go", "src/[a-z]*.ts"} {
+		if err := validateGlob(pattern); err != nil {
+			t.Error("Expected pattern", pattern, "to be valid, got", err)
+		}
+	}
+
+	for _, pattern := range []string{"", "/etc/*", "../*.go", "[a-"} {
+		if err := validateGlob(pattern); err == nil {
+			t.Error("Expected pattern", pattern, "to be invalid, got nil")
+		}
+	}
+}
+
+func TestFileWatcherSnapshot(t *testing.T) {
+	root := t.TempDir()
+
+	os.MkdirAll(filepath.Join(root, "core"), 0755)
+	os.MkdirAll(filepath.Join(root, "node_modules", "lib"), 0755)
+	os.MkdirAll(filepath.Join(root, "tmp"), 0755)
+	os.WriteFile(filepath.Join(root, "main.go"), []byte("package main"), 0644)
+	os.WriteFile(filepath.Join(root, "core", "run.go"), []byte("package core"), 0644)
+	os.WriteFile(filepath.Join(root, "node_modules", "lib", "lib.go"), []byte("package lib"), 0644)
+	os.WriteFile(filepath.Join(root, "tmp", "main.go"), []byte("package main"), 0644)
+	os.WriteFile(filepath.Join(root, "README.md"), []byte("# Readme"), 0644)
+
+	watcher := newFileWatcher(root, []string{"**/*.go"}, []string{"tmp"})
+	previous := watcher.snapshot()
+
+	if len(previous) != 2 {
+		t.Error("Expected 2 watched files, got", previous)
+	}
+
+	if _, changed := changedFile(previous, watcher.snapshot()); changed {
+		t.Error("Expected no changes between snapshots")
+	}
+
+	os.WriteFile(filepath.Join(root, "core", "run.go"), []byte("package core\n\nfunc run() {}"), 0644)
+
+	if filePath, changed := changedFile(previous, watcher.snapshot()); !changed || filePath != "core/run.go" {
+		t.Error("Expected core/run.go to have changed, got", filePath)
+	}
+
+	if newFileWatcher(root, nil, nil) != nil {
+		t.Error("Expected no watcher without watch patterns")
+	}
+}
+
+func TestRunWatchRestart(t *testing.T) {
+	c := TestingCore("run_watch_restart")
+
+	c.FetchCommands()
+	c.FetchProjects()
+
+	projectDir := t.TempDir()
+	output := filepath.Join(projectDir, "output.txt")
+	watched := filepath.Join(projectDir, "main.go")
+
+	os.WriteFile(watched, []byte("package main"), 0644)
+
+	c.AddCommand("server", "echo started >> "+output+" && sleep 10")
+	c.SetCommandSettings("server", CommandSettings{
+		Shell: true,
+		Watch: []string{"*.go"},
+	})
+
+	c.AddProject("test", 1234, []string{"server"})
+	c.FetchProjects()
+	c.SetProjectDir("test", &projectDir)
+
+	// "Refetch" the projects from the config file
+	c.FetchProjects()
+
+	// Create the signal channel before running, so the test can stop the project
+	sigChan := make(chan os.Signal, 1)
+	c.sigChan = &sigChan
+
+	done := make(chan struct{})
+
+	go func() {
+		c.TryToRun("test")
+		close(done)
+	}()
+
+	time.Sleep(time.Second)
+	os.WriteFile(watched, []byte("package main\n\nfunc main() {}"), 0644)
+	time.Sleep(2 * time.Second)
+
+	sigChan <- os.Interrupt
+	<-done
+
+	contents, err := os.ReadFile(output)
+
+	if err != nil {
+		t.Error("Expected output file to exist, got", err)
+		return
+	}
+
+	if string(contents) != "started\nstarted\n" {
+		t.Errorf("Expected command to be restarted once after the file changed, got %q", contents)
+	}
+}
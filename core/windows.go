@@ -11,6 +11,16 @@ func createProcessGroup() *syscall.SysProcAttr {
 	return &syscall.SysProcAttr{CreationFlags: syscall.CREATE_NEW_PROCESS_GROUP}
 }
 
-func killProcess(process *os.Process) error {
+// Windows does not support sending signals to processes, so the process is killed instead.
+func signalProcess(process *os.Process, signal syscall.Signal) error {
 	return process.Kill()
 }
+
+// Kill the given process.
+func forceKillProcess(process *os.Process) error {
+	return process.Kill()
+}
+
+func shellCommandArgs(command string) []string {
+	return []string{"cmd", "/C", command}
+}
@@ -0,0 +1,175 @@
+package daemon
+
+import (
+	"fmt"
+	"net/rpc"
+	"net/rpc/jsonrpc"
+	"os"
+	"os/exec"
+	"path"
+	"time"
+
+	"github.com/iskandervdh/spinup/common"
+	"github.com/iskandervdh/spinup/config"
+	"github.com/iskandervdh/spinup/core"
+)
+
+// How long to wait for a daemon that was started in the background to accept connections.
+const startTimeout = 5 * time.Second
+
+// Client is used to send requests to the daemon.
+type Client struct {
+	rpc *rpc.Client
+}
+
+// Connect to the daemon that is listening on the socket in the given config directory.
+//
+// Returns an error if the daemon is not running.
+func Dial(config *config.Config) (*Client, error) {
+	rpcClient, err := jsonrpc.Dial("unix", config.GetDaemonSocketPath())
+
+	if err != nil {
+		return nil, fmt.Errorf("%s is not running", common.DaemonName)
+	}
+
+	return &Client{rpc: rpcClient}, nil
+}
+
+// Connect to the daemon, starting it in the background if it is not running yet.
+func Connect(config *config.Config) (*Client, error) {
+	client, err := Dial(config)
+
+	if err == nil {
+		return client, nil
+	}
+
+	err = startInBackground(config)
+
+	if err != nil {
+		return nil, err
+	}
+
+	deadline := time.Now().Add(startTimeout)
+
+	for time.Now().Before(deadline) {
+		client, err = Dial(config)
+
+		if err == nil {
+			return client, nil
+		}
+
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	return nil, fmt.Errorf("%s did not start within %s", common.DaemonName, startTimeout)
+}
+
+// Start the daemon as a detached process of the current executable.
+//
+// The output of the daemon itself is written to spinupd.log in the logs directory.
+func startInBackground(config *config.Config) error {
+	executable, err := os.Executable()
+
+	if err != nil {
+		return fmt.Errorf("error getting executable: %s", err)
+	}
+
+	err = os.MkdirAll(config.GetLogsDir(), 0755)
+
+	if err != nil {
+		return fmt.Errorf("error creating logs directory: %s", err)
+	}
+
+	logFile, err := os.Create(path.Join(config.GetLogsDir(), common.DaemonName+".log"))
+
+	if err != nil {
+		return fmt.Errorf("error creating log file: %s", err)
+	}
+
+	defer logFile.Close()
+
+	cmd := exec.Command(executable, "daemon")
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+	cmd.SysProcAttr = detachedProcess()
+
+	err = cmd.Start()
+
+	if err != nil {
+		return fmt.Errorf("error starting %s: %s", common.DaemonName, err)
+	}
+
+	return cmd.Process.Release()
+}
+
+// Close the connection to the daemon.
+func (c *Client) Close() error {
+	return c.rpc.Close()
+}
+
+// Start running the given project in the daemon.
+func (c *Client) Start(project string) (string, error) {
+	var reply Reply
+	err := c.rpc.Call("Daemon.Start", ProjectArgs{Project: project}, &reply)
+
+	return reply.Message, err
+}
+
+// Stop the given project in the daemon and wait until it stopped.
+func (c *Client) Stop(project string) (string, error) {
+	var reply Reply
+	err := c.rpc.Call("Daemon.Stop", ProjectArgs{Project: project}, &reply)
+
+	return reply.Message, err
+}
+
+// Restart the given project in the daemon.
+func (c *Client) Restart(project string) (string, error) {
+	var reply Reply
+	err := c.rpc.Call("Daemon.Restart", ProjectArgs{Project: project}, &reply)
+
+	return reply.Message, err
+}
+
+// Stop a single command of the given project in the daemon.
+func (c *Client) StopCommand(project string, command string) (string, error) {
+	var reply Reply
+	err := c.rpc.Call("Daemon.StopCommand", CommandArgs{Project: project, Command: command}, &reply)
+
+	return reply.Message, err
+}
+
+// Start a single command of the given project in the daemon.
+func (c *Client) StartCommand(project string, command string) (string, error) {
+	var reply Reply
+	err := c.rpc.Call("Daemon.StartCommand", CommandArgs{Project: project, Command: command}, &reply)
+
+	return reply.Message, err
+}
+
+// Restart a single command of the given project in the daemon.
+func (c *Client) RestartCommand(project string, command string) (string, error) {
+	var reply Reply
+	err := c.rpc.Call("Daemon.RestartCommand", CommandArgs{Project: project, Command: command}, &reply)
+
+	return reply.Message, err
+}
+
+// Get the status of all projects running in the daemon.
+func (c *Client) Status() ([]core.ProjectStatus, error) {
+	var reply StatusReply
+	err := c.rpc.Call("Daemon.Status", StatusArgs{}, &reply)
+
+	return reply.Projects, err
+}
+
+// Get the logs of the given command of a project, or the combined logs of all its commands
+// if no command is given, starting at the given offset.
+//
+// Returns the logs and the offset to use to get the logs that are written afterwards.
+func (c *Client) Logs(project string, command string, offset int64) (string, int64, error) {
+	var reply LogsReply
+	err := c.rpc.Call("Daemon.Logs", LogsArgs{Project: project, Command: command, Offset: offset}, &reply)
+
+	return reply.Data, reply.Offset, err
+}
@@ -0,0 +1,435 @@
+package daemon
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/rpc"
+	"net/rpc/jsonrpc"
+	"os"
+	"os/signal"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/iskandervdh/spinup/common"
+	"github.com/iskandervdh/spinup/config"
+	"github.com/iskandervdh/spinup/core"
+	"github.com/iskandervdh/spinup/proxy"
+)
+
+// How long to wait for a project to report errors that prevent it from starting.
+const startupErrorTimeout = 500 * time.Millisecond
+
+// How long to wait for a project to stop before giving up.
+const stopTimeout = 30 * time.Second
+
+// Maximum number of bytes of logs returned by a single logs request.
+const maxLogsChunkSize = 1024 * 1024
+
+// Daemon owns all projects that are running in the background.
+//
+// It exposes a JSON-RPC API on a Unix socket in the config directory,
+// so both the CLI and the app can start, stop and inspect the same projects.
+type Daemon struct {
+	config   *config.Config
+	listener net.Listener
+
+	// The built-in reverse proxy, when it is the selected reverse proxy
+	proxy *proxy.Server
+
+	mu       sync.Mutex
+	projects map[string]*runningProject
+}
+
+// A project that is running in the daemon.
+type runningProject struct {
+	name      string
+	core      *core.Core
+	sigChan   chan os.Signal
+	msgChan   chan common.Msg
+	startedAt time.Time
+
+	// Closed once the project stopped running, result contains the message returned by the core
+	done   chan struct{}
+	result common.Msg
+}
+
+// Create a new daemon with the given config.
+func New(config *config.Config) *Daemon {
+	return &Daemon{
+		config:   config,
+		projects: map[string]*runningProject{},
+	}
+}
+
+// Run the daemon using the default config until it receives SIGINT or SIGTERM.
+func Main() {
+	config, err := config.New()
+
+	if err != nil {
+		fmt.Println("Error getting config:", err)
+		os.Exit(1)
+	}
+
+	err = New(config).Run()
+
+	if err != nil {
+		fmt.Println("Error running daemon:", err)
+		os.Exit(1)
+	}
+}
+
+// Listen on the socket of the daemon and serve requests until SIGINT or SIGTERM is received.
+//
+// All running projects are stopped before returning.
+func (d *Daemon) Run() error {
+	err := d.Listen()
+
+	if err != nil {
+		return err
+	}
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+
+	go func() {
+		<-sigChan
+
+		d.Close()
+	}()
+
+	fmt.Printf("%s listening on %s\n", common.DaemonName, d.config.GetDaemonSocketPath())
+
+	err = d.startProxy()
+
+	if err != nil {
+		fmt.Println("Error starting reverse proxy:", err)
+	}
+
+	d.Serve()
+
+	return nil
+}
+
+// Start listening on the socket of the daemon.
+//
+// Returns an error if another daemon is already listening on the socket.
+func (d *Daemon) Listen() error {
+	socketPath := d.config.GetDaemonSocketPath()
+
+	err := os.MkdirAll(path.Dir(socketPath), 0755)
+
+	if err != nil {
+		return fmt.Errorf("error creating config directory: %s", err)
+	}
+
+	if _, err := os.Stat(socketPath); err == nil {
+		conn, err := net.Dial("unix", socketPath)
+
+		if err == nil {
+			conn.Close()
+			return fmt.Errorf("%s is already running", common.DaemonName)
+		}
+
+		// Remove the socket left behind by a daemon that did not exit cleanly
+		os.Remove(socketPath)
+	}
+
+	listener, err := net.Listen("unix", socketPath)
+
+	if err != nil {
+		return fmt.Errorf("error listening on %s: %s", socketPath, err)
+	}
+
+	d.listener = listener
+
+	return nil
+}
+
+// Serve the built-in reverse proxy in the background if it is the selected reverse proxy.
+func (d *Daemon) startProxy() error {
+	reverseProxy, err := d.config.GetReverseProxy()
+
+	if err != nil || reverseProxy.Name() != config.ReverseProxyBuiltin {
+		return err
+	}
+
+	port, err := d.config.GetProxyPort()
+
+	if err != nil {
+		return err
+	}
+
+	routesCore := core.New(core.WithConfig(d.config))
+	server := proxy.NewServer(port, routesCore.GetProxyRoutes)
+
+	err = server.Listen()
+
+	if err != nil {
+		return err
+	}
+
+	d.proxy = server
+
+	fmt.Printf("Reverse proxy listening on %s\n", server.Addr())
+
+	go func() {
+		err := server.Serve(func(err error) {
+			fmt.Println("Error reloading routes:", err)
+		})
+
+		if err != nil {
+			fmt.Println("Error serving reverse proxy:", err)
+		}
+	}()
+
+	return nil
+}
+
+// Accept connections on the socket of the daemon until it is closed.
+func (d *Daemon) Serve() {
+	server := rpc.NewServer()
+	server.RegisterName("Daemon", &Service{daemon: d})
+
+	for {
+		conn, err := d.listener.Accept()
+
+		if err != nil {
+			return
+		}
+
+		go server.ServeCodec(jsonrpc.NewServerCodec(conn))
+	}
+}
+
+// Stop all running projects and stop listening on the socket of the daemon.
+func (d *Daemon) Close() {
+	for _, status := range d.status() {
+		err := d.stopProject(status.Project)
+
+		if err != nil {
+			fmt.Println("Error stopping project:", err)
+		}
+	}
+
+	if d.proxy != nil {
+		d.proxy.Close()
+	}
+
+	if d.listener != nil {
+		d.listener.Close()
+	}
+}
+
+// Create a new running project for the project with the given name.
+//
+// The output of the project is only written to its log files by the core, not to the output of the daemon.
+func (d *Daemon) newRunningProject(projectName string) (*runningProject, error) {
+	rp := &runningProject{
+		name:      projectName,
+		sigChan:   make(chan os.Signal, 1),
+		msgChan:   make(chan common.Msg, 100),
+		startedAt: time.Now(),
+		done:      make(chan struct{}),
+	}
+
+	rp.core = core.New(core.WithConfig(d.config), core.WithMsgChan(&rp.msgChan), core.WithSigChan(&rp.sigChan))
+	rp.core.FetchCommands()
+	rp.core.FetchProjects()
+
+	if exists, _ := rp.core.ProjectExists(projectName); !exists {
+		return nil, fmt.Errorf("project '%s' does not exist", projectName)
+	}
+
+	rp.core.SetOut(io.Discard)
+	rp.core.SetErr(io.Discard)
+
+	// The messages of the core are written to the log files by the core itself, so they only have to be drained
+	go func() {
+		for {
+			select {
+			case <-rp.msgChan:
+			case <-rp.done:
+				return
+			}
+		}
+	}()
+
+	return rp, nil
+}
+
+// Start running the project with the given name in the background.
+func (d *Daemon) startProject(projectName string) error {
+	d.mu.Lock()
+
+	if _, ok := d.projects[projectName]; ok {
+		d.mu.Unlock()
+		return fmt.Errorf("project '%s' is already running", projectName)
+	}
+
+	rp, err := d.newRunningProject(projectName)
+
+	if err != nil {
+		d.mu.Unlock()
+		return err
+	}
+
+	d.projects[projectName] = rp
+	d.mu.Unlock()
+
+	go func() {
+		rp.result = rp.core.TryToRun(projectName)
+
+		d.mu.Lock()
+
+		if d.projects[projectName] == rp {
+			delete(d.projects, projectName)
+		}
+
+		d.mu.Unlock()
+
+		close(rp.done)
+	}()
+
+	// Errors like invalid commands or env files are returned right away by the core
+	select {
+	case <-rp.done:
+		if _, ok := rp.result.(*common.ErrMsg); ok {
+			return fmt.Errorf("%s", strings.TrimSpace(rp.result.GetText()))
+		}
+	case <-time.After(startupErrorTimeout):
+	}
+
+	return nil
+}
+
+// Gracefully stop the project with the given name and wait until it stopped.
+func (d *Daemon) stopProject(projectName string) error {
+	d.mu.Lock()
+	rp, ok := d.projects[projectName]
+	d.mu.Unlock()
+
+	if !ok {
+		return fmt.Errorf("project '%s' is not running", projectName)
+	}
+
+	// The core only reads a single signal, so there is no need to send another one if it is already stopping
+	select {
+	case rp.sigChan <- syscall.SIGINT:
+	default:
+	}
+
+	select {
+	case <-rp.done:
+		return nil
+	case <-time.After(stopTimeout):
+		return fmt.Errorf("project '%s' did not stop within %s", projectName, stopTimeout)
+	}
+}
+
+// Stop the project with the given name and start it again.
+func (d *Daemon) restartProject(projectName string) error {
+	err := d.stopProject(projectName)
+
+	if err != nil {
+		return err
+	}
+
+	return d.startProject(projectName)
+}
+
+// Stop, start or restart a single command of the project with the given name.
+func (d *Daemon) controlCommand(projectName string, action func(*core.Core) common.Msg) (string, error) {
+	d.mu.Lock()
+	rp, ok := d.projects[projectName]
+	d.mu.Unlock()
+
+	if !ok {
+		return "", fmt.Errorf("project '%s' is not running", projectName)
+	}
+
+	msg := action(rp.core)
+
+	if _, ok := msg.(*common.ErrMsg); ok {
+		return "", fmt.Errorf("%s", msg.GetText())
+	}
+
+	return msg.GetText(), nil
+}
+
+// Get the status of the given running project.
+func (rp *runningProject) status() core.ProjectStatus {
+	status, ok := rp.core.GetStatus()
+
+	// The commands of the project are not known until the core started running it
+	if !ok {
+		return core.ProjectStatus{
+			Project:   rp.name,
+			StartedAt: rp.startedAt,
+			Commands:  []core.CommandStatus{},
+		}
+	}
+
+	return status
+}
+
+// Get the status of all running projects, sorted by name.
+func (d *Daemon) status() []core.ProjectStatus {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	statuses := []core.ProjectStatus{}
+
+	for _, rp := range d.projects {
+		statuses = append(statuses, rp.status())
+	}
+
+	slices.SortFunc(statuses, func(a, b core.ProjectStatus) int {
+		return strings.Compare(a.Project, b.Project)
+	})
+
+	return statuses
+}
+
+// Read the logs of the given command of a project, or the combined logs of all its commands
+// if no command is given, starting at the given offset.
+//
+// Returns the logs and the offset to continue reading from. The logs of the last run
+// of a project are kept after it stopped, so they can still be read.
+func (d *Daemon) logs(projectName string, commandName string, offset int64) (string, int64, error) {
+	logFile, err := os.Open(d.config.GetLogFilePath(projectName, commandName))
+
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", offset, fmt.Errorf("no logs available for project '%s'", projectName)
+		}
+
+		return "", offset, err
+	}
+
+	defer logFile.Close()
+
+	info, err := logFile.Stat()
+
+	if err != nil {
+		return "", offset, err
+	}
+
+	// Start from the beginning if the log file was recreated by restarting the project
+	if offset > info.Size() {
+		offset = 0
+	}
+
+	data := make([]byte, min(info.Size()-offset, maxLogsChunkSize))
+	n, err := logFile.ReadAt(data, offset)
+
+	if err != nil && err != io.EOF {
+		return "", offset, err
+	}
+
+	return string(data[:n]), offset + int64(n), nil
+}
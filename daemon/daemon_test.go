@@ -0,0 +1,232 @@
+package daemon
+
+import (
+	"os"
+	"path"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/iskandervdh/spinup/common"
+	"github.com/iskandervdh/spinup/config"
+	"github.com/iskandervdh/spinup/core"
+)
+
+func TestingConfig(testName string) *config.Config {
+	// Remove old tmp config dir
+	testingConfigDir := path.Join(os.TempDir(), common.ProgramName, testName)
+	err := os.RemoveAll(testingConfigDir)
+
+	if err != nil {
+		panic(err)
+	}
+
+	return config.NewTesting(testingConfigDir)
+}
+
+// Create a daemon listening on the socket in the testing config directory with a project named "test".
+func TestingDaemon(testName string, command string) (*Daemon, *Client) {
+	cfg := TestingConfig(testName)
+
+	// Mock msgChan to prevent blocking during testing
+	msgChan := make(chan common.Msg)
+
+	go func() {
+		for {
+			<-msgChan
+		}
+	}()
+
+	c := core.New(core.WithConfig(cfg), core.WithMsgChan(&msgChan))
+	c.Init()
+
+	c.FetchCommands()
+	c.FetchProjects()
+	c.AddCommand("test", command)
+	c.AddProject("test", 1234, []string{"test"})
+
+	d := New(cfg)
+	err := d.Listen()
+
+	if err != nil {
+		panic(err)
+	}
+
+	go d.Serve()
+
+	client, err := Dial(cfg)
+
+	if err != nil {
+		panic(err)
+	}
+
+	return d, client
+}
+
+func TestDaemonStartStop(t *testing.T) {
+	d, client := TestingDaemon("daemon_start_stop", "sh -c 'echo hello; sleep 10'")
+	defer d.Close()
+	defer client.Close()
+
+	_, err := client.Start("test")
+
+	if err != nil {
+		t.Error("Expected project to start, got", err)
+		return
+	}
+
+	_, err = client.Start("test")
+
+	if err == nil {
+		t.Error("Expected error when starting a project that is already running, got nil")
+	}
+
+	statuses, err := client.Status()
+
+	if err != nil || len(statuses) != 1 || statuses[0].Project != "test" {
+		t.Error("Expected project to be running, got", statuses, err)
+	} else if len(statuses[0].Commands) != 1 || statuses[0].Commands[0].State != core.CommandRunning {
+		t.Error("Expected command of project to be running, got", statuses[0].Commands)
+	}
+
+	logs, offset, err := client.Logs("test", "", 0)
+
+	if err != nil || !strings.Contains(logs, `"command":"test","text":"hello"`) {
+		t.Errorf("Expected logs to contain the output of the command, got %q %v", logs, err)
+	}
+
+	logs, _, _ = client.Logs("test", "", offset)
+
+	if logs != "" {
+		t.Errorf("Expected no new logs after the offset, got %q", logs)
+	}
+
+	_, err = client.Stop("test")
+
+	if err != nil {
+		t.Error("Expected project to stop, got", err)
+	}
+
+	statuses, _ = client.Status()
+
+	if len(statuses) != 0 {
+		t.Error("Expected no running projects, got", statuses)
+	}
+
+	_, err = client.Stop("test")
+
+	if err == nil {
+		t.Error("Expected error when stopping a project that is not running, got nil")
+	}
+}
+
+func TestDaemonStartUnknownProject(t *testing.T) {
+	d, client := TestingDaemon("daemon_start_unknown_project", "sleep 10")
+	defer d.Close()
+	defer client.Close()
+
+	_, err := client.Start("does_not_exist")
+
+	if err == nil {
+		t.Error("Expected error when starting an unknown project, got nil")
+	}
+}
+
+func TestDaemonStartInvalidCommand(t *testing.T) {
+	d, client := TestingDaemon("daemon_start_invalid_command", "echo 'unterminated")
+	defer d.Close()
+	defer client.Close()
+
+	_, err := client.Start("test")
+
+	if err == nil {
+		t.Error("Expected error when starting a project with an invalid command, got nil")
+	}
+}
+
+func TestDaemonRestart(t *testing.T) {
+	d, client := TestingDaemon("daemon_restart", "sleep 10")
+	defer d.Close()
+	defer client.Close()
+
+	client.Start("test")
+
+	statuses, _ := client.Status()
+	startedAt := statuses[0].StartedAt
+
+	time.Sleep(10 * time.Millisecond)
+
+	_, err := client.Restart("test")
+
+	if err != nil {
+		t.Error("Expected project to restart, got", err)
+		return
+	}
+
+	statuses, _ = client.Status()
+
+	if len(statuses) != 1 || !statuses[0].StartedAt.After(startedAt) {
+		t.Error("Expected project to be started again, got", statuses)
+	}
+}
+
+func TestDaemonCommandControl(t *testing.T) {
+	d, client := TestingDaemon("daemon_command_control", "sleep 10")
+	defer d.Close()
+	defer client.Close()
+
+	_, err := client.StopCommand("test", "test")
+
+	if err == nil {
+		t.Error("Expected error when stopping a command of a project that is not running, got nil")
+	}
+
+	client.Start("test")
+
+	_, err = client.StopCommand("test", "test")
+
+	if err != nil {
+		t.Error("Expected command to stop, got", err)
+		return
+	}
+
+	time.Sleep(200 * time.Millisecond)
+
+	statuses, _ := client.Status()
+
+	if len(statuses) != 1 || statuses[0].Commands[0].State != core.CommandStopped {
+		t.Error("Expected command to be stopped while the project keeps running, got", statuses)
+	}
+
+	_, err = client.StartCommand("test", "test")
+
+	if err != nil {
+		t.Error("Expected command to start, got", err)
+	}
+
+	_, err = client.RestartCommand("test", "unknown")
+
+	if err == nil || !strings.Contains(err.Error(), "not part of project") {
+		t.Error("Expected error when restarting an unknown command, got", err)
+	}
+
+	time.Sleep(200 * time.Millisecond)
+
+	statuses, _ = client.Status()
+
+	if len(statuses) != 1 || statuses[0].Commands[0].State != core.CommandRunning {
+		t.Error("Expected command to be running again, got", statuses)
+	}
+}
+
+func TestDaemonListenTwice(t *testing.T) {
+	d, client := TestingDaemon("daemon_listen_twice", "sleep 10")
+	defer d.Close()
+	defer client.Close()
+
+	err := New(d.config).Listen()
+
+	if err == nil {
+		t.Error("Expected error when another daemon is already listening, got nil")
+	}
+}
@@ -0,0 +1,149 @@
+package daemon
+
+import (
+	"github.com/iskandervdh/spinup/common"
+	"github.com/iskandervdh/spinup/core"
+)
+
+// Service contains the methods of the JSON-RPC API of the daemon.
+//
+// The methods are registered under the name "Daemon", so a request to start
+// a project looks like {"method": "Daemon.Start", "params": [{"Project": "example"}], "id": 1}.
+type Service struct {
+	daemon *Daemon
+}
+
+// ProjectArgs are the arguments of the requests that act on a single project.
+type ProjectArgs struct {
+	Project string
+}
+
+// CommandArgs are the arguments of the requests that act on a single command of a running project.
+type CommandArgs struct {
+	Project string
+	Command string
+}
+
+// StatusArgs are the arguments of a status request.
+type StatusArgs struct{}
+
+// LogsArgs are the arguments of a logs request.
+//
+// Command is the name of the command to get the logs of, the combined logs of all
+// commands are returned if it is empty. Offset is the number of bytes of the log
+// file that have already been read.
+type LogsArgs struct {
+	Project string
+	Command string
+	Offset  int64
+}
+
+// Reply is the reply to requests that only report whether they succeeded.
+type Reply struct {
+	Message string
+}
+
+// StatusReply contains the status of all projects running in the daemon.
+type StatusReply struct {
+	Projects []core.ProjectStatus
+}
+
+// LogsReply contains the logs of a project starting at the requested offset
+// and the offset to use for the next request.
+type LogsReply struct {
+	Data   string
+	Offset int64
+}
+
+// Start running the given project.
+func (s *Service) Start(args ProjectArgs, reply *Reply) error {
+	err := s.daemon.startProject(args.Project)
+
+	if err != nil {
+		return err
+	}
+
+	reply.Message = "Started project '" + args.Project + "'"
+
+	return nil
+}
+
+// Stop the given project and wait until it stopped.
+func (s *Service) Stop(args ProjectArgs, reply *Reply) error {
+	err := s.daemon.stopProject(args.Project)
+
+	if err != nil {
+		return err
+	}
+
+	reply.Message = "Stopped project '" + args.Project + "'"
+
+	return nil
+}
+
+// Stop the given project and start it again.
+func (s *Service) Restart(args ProjectArgs, reply *Reply) error {
+	err := s.daemon.restartProject(args.Project)
+
+	if err != nil {
+		return err
+	}
+
+	reply.Message = "Restarted project '" + args.Project + "'"
+
+	return nil
+}
+
+// Stop a single command of the given project, without stopping the other commands.
+func (s *Service) StopCommand(args CommandArgs, reply *Reply) error {
+	message, err := s.daemon.controlCommand(args.Project, func(c *core.Core) common.Msg {
+		return c.StopCommand(args.Command)
+	})
+
+	reply.Message = message
+
+	return err
+}
+
+// Start a single command of the given project after it was stopped or exited.
+func (s *Service) StartCommand(args CommandArgs, reply *Reply) error {
+	message, err := s.daemon.controlCommand(args.Project, func(c *core.Core) common.Msg {
+		return c.StartCommand(args.Command)
+	})
+
+	reply.Message = message
+
+	return err
+}
+
+// Restart a single command of the given project, without restarting the other commands.
+func (s *Service) RestartCommand(args CommandArgs, reply *Reply) error {
+	message, err := s.daemon.controlCommand(args.Project, func(c *core.Core) common.Msg {
+		return c.RestartCommand(args.Command)
+	})
+
+	reply.Message = message
+
+	return err
+}
+
+// Get the status of all running projects.
+func (s *Service) Status(args StatusArgs, reply *StatusReply) error {
+	reply.Projects = s.daemon.status()
+
+	return nil
+}
+
+// Get the logs of the given project.
+func (s *Service) Logs(args LogsArgs, reply *LogsReply) error {
+	data, offset, err := s.daemon.logs(args.Project, args.Command, args.Offset)
+
+	if err != nil {
+		return err
+	}
+
+	reply.Data = data
+	reply.Offset = offset
+
+	return nil
+}
@@ -0,0 +1,12 @@
+//go:build linux || darwin
+
+package daemon
+
+import (
+	"syscall"
+)
+
+// Run the daemon in its own session so it keeps running after the terminal is closed.
+func detachedProcess() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{Setsid: true}
+}
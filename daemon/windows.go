@@ -0,0 +1,15 @@
+//go:build windows
+
+package daemon
+
+import (
+	"syscall"
+)
+
+// DETACHED_PROCESS is not defined in the syscall package.
+const detachedProcessFlag = 0x00000008
+
+// Run the daemon without a console so it keeps running after the terminal is closed.
+func detachedProcess() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{CreationFlags: detachedProcessFlag | syscall.CREATE_NEW_PROCESS_GROUP}
+}
@@ -0,0 +1 @@
+ALTER TABLE commands DROP COLUMN shell;
@@ -0,0 +1 @@
+ALTER TABLE commands ADD COLUMN shell BOOLEAN NOT NULL DEFAULT FALSE;
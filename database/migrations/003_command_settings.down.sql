@@ -0,0 +1,5 @@
+ALTER TABLE commands DROP COLUMN force_color;
+
+ALTER TABLE commands DROP COLUMN env;
+
+ALTER TABLE commands DROP COLUMN dir;
@@ -0,0 +1,5 @@
+ALTER TABLE commands ADD COLUMN dir TEXT;
+
+ALTER TABLE commands ADD COLUMN env TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE commands ADD COLUMN force_color BOOLEAN NOT NULL DEFAULT TRUE;
@@ -0,0 +1,8 @@
+CREATE TABLE env_variables (
+  id            INTEGER PRIMARY KEY AUTOINCREMENT,
+  name          TEXT NOT NULL,
+  value         TEXT NOT NULL,
+
+  project_id    INTEGER NOT NULL,
+  FOREIGN KEY (project_id) REFERENCES projects(id) ON DELETE CASCADE
+);
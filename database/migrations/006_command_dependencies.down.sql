@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS command_dependencies;
+
+ALTER TABLE commands DROP COLUMN ready_value;
+
+ALTER TABLE commands DROP COLUMN ready_check;
@@ -0,0 +1,13 @@
+ALTER TABLE commands ADD COLUMN ready_check TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE commands ADD COLUMN ready_value TEXT NOT NULL DEFAULT '';
+
+CREATE TABLE command_dependencies (
+  command_id    INTEGER NOT NULL,
+  depends_on_id INTEGER NOT NULL,
+
+  FOREIGN KEY (command_id) REFERENCES commands(id) ON DELETE CASCADE,
+  FOREIGN KEY (depends_on_id) REFERENCES commands(id) ON DELETE CASCADE,
+
+  PRIMARY KEY (command_id, depends_on_id)
+);
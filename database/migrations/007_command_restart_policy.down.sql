@@ -0,0 +1,3 @@
+ALTER TABLE commands DROP COLUMN max_retries;
+
+ALTER TABLE commands DROP COLUMN restart_policy;
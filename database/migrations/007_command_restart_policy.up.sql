@@ -0,0 +1,3 @@
+ALTER TABLE commands ADD COLUMN restart_policy TEXT NOT NULL DEFAULT 'never';
+
+ALTER TABLE commands ADD COLUMN max_retries INTEGER NOT NULL DEFAULT 5;
@@ -0,0 +1,3 @@
+ALTER TABLE commands DROP COLUMN watch_exclude;
+
+ALTER TABLE commands DROP COLUMN watch;
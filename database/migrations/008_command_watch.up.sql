@@ -0,0 +1,3 @@
+ALTER TABLE commands ADD COLUMN watch TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE commands ADD COLUMN watch_exclude TEXT NOT NULL DEFAULT '';
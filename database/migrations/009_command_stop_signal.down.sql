@@ -0,0 +1,3 @@
+ALTER TABLE commands DROP COLUMN stop_timeout;
+
+ALTER TABLE commands DROP COLUMN stop_signal;
@@ -0,0 +1,3 @@
+ALTER TABLE commands ADD COLUMN stop_signal TEXT NOT NULL DEFAULT 'SIGTERM';
+
+ALTER TABLE commands ADD COLUMN stop_timeout INTEGER NOT NULL DEFAULT 10;
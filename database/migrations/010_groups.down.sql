@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS group_projects;
+
+DROP TABLE IF EXISTS groups;
@@ -0,0 +1,14 @@
+CREATE TABLE groups (
+  id            INTEGER PRIMARY KEY AUTOINCREMENT,
+  name          TEXT NOT NULL UNIQUE
+);
+
+CREATE TABLE group_projects (
+  group_id      INTEGER NOT NULL,
+  project_id    INTEGER NOT NULL,
+
+  FOREIGN KEY (group_id) REFERENCES groups(id) ON DELETE CASCADE,
+  FOREIGN KEY (project_id) REFERENCES projects(id) ON DELETE CASCADE,
+
+  PRIMARY KEY (group_id, project_id)
+);
@@ -0,0 +1,11 @@
+CREATE TABLE routes (
+  id            INTEGER PRIMARY KEY AUTOINCREMENT,
+  path          TEXT NOT NULL,
+  port          INT NOT NULL,
+  strip_prefix  BOOLEAN NOT NULL DEFAULT FALSE,
+
+  project_id    INTEGER NOT NULL,
+  FOREIGN KEY (project_id) REFERENCES projects(id) ON DELETE CASCADE,
+
+  UNIQUE (project_id, path)
+);
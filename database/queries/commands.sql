@@ -36,3 +36,49 @@ WHERE name = ?;
 -- name: DeleteCommandById :exec
 DELETE FROM commands
 WHERE id = ?;
+
+-- name: SetCommandShell :exec
+UPDATE commands
+SET shell = ?
+WHERE name = ?;
+
+-- name: UpdateCommandSettings :exec
+UPDATE commands
+SET shell = ?, dir = ?, env = ?, force_color = ?, watch = ?, watch_exclude = ?
+WHERE id = ?;
+
+-- name: SetCommandReadiness :exec
+UPDATE commands
+SET ready_check = ?, ready_value = ?
+WHERE name = ?;
+
+-- name: SetCommandRestartPolicy :exec
+UPDATE commands
+SET restart_policy = ?, max_retries = ?
+WHERE name = ?;
+
+-- name: SetCommandStopSignal :exec
+UPDATE commands
+SET stop_signal = ?, stop_timeout = ?
+WHERE name = ?;
+
+-- name: GetCommandDependencies :many
+SELECT c.name
+FROM commands c
+JOIN command_dependencies cd ON c.id = cd.depends_on_id
+WHERE cd.command_id = ?;
+
+-- name: GetAllCommandDependencies :many
+SELECT *
+FROM command_dependencies;
+
+-- name: CreateCommandDependency :exec
+INSERT INTO command_dependencies (
+  command_id, depends_on_id
+) VALUES (
+  ?, ?
+);
+
+-- name: DeleteCommandDependencies :exec
+DELETE FROM command_dependencies
+WHERE command_id = ?;
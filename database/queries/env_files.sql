@@ -0,0 +1,10 @@
+-- name: CreateEnvFile :exec
+INSERT INTO env_files (
+  path, project_id
+) VALUES (
+  ?, ?
+);
+
+-- name: DeleteEnvFile :exec
+DELETE FROM env_files
+WHERE path = ? AND project_id = ?;
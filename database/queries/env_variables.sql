@@ -0,0 +1,15 @@
+-- name: CreateEnvVariable :exec
+INSERT INTO env_variables (
+  name, value, project_id
+) VALUES (
+  ?, ?, ?
+);
+
+-- name: UpdateEnvVariable :exec
+UPDATE env_variables
+SET value = ?
+WHERE name = ? AND project_id = ?;
+
+-- name: DeleteEnvVariable :exec
+DELETE FROM env_variables
+WHERE name = ? AND project_id = ?;
@@ -0,0 +1,38 @@
+-- name: GetGroups :many
+SELECT *
+FROM groups
+ORDER BY name;
+
+-- name: GetGroupProjects :many
+SELECT p.*
+FROM projects p
+JOIN group_projects gp ON p.id = gp.project_id
+WHERE gp.group_id = ?
+ORDER BY gp.rowid;
+
+-- name: CreateGroup :one
+INSERT INTO groups (
+  name
+) VALUES (
+  ?
+)
+RETURNING *;
+
+-- name: DeleteGroup :exec
+DELETE FROM groups
+WHERE id = ?;
+
+-- name: CreateGroupProject :exec
+INSERT INTO group_projects (
+  group_id, project_id
+) VALUES (
+  ?, ?
+);
+
+-- name: DeleteGroupProject :exec
+DELETE FROM group_projects
+WHERE group_id = ? AND project_id = ?;
+
+-- name: DeleteGroupProjects :exec
+DELETE FROM group_projects
+WHERE group_id = ?;
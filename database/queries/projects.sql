@@ -18,11 +18,28 @@ SELECT *
 FROM variables
 WHERE project_id = ?;
 
+-- name: GetProjectEnvVariables :many
+SELECT *
+FROM env_variables
+WHERE project_id = ?;
+
+-- name: GetProjectEnvFiles :many
+SELECT *
+FROM env_files
+WHERE project_id = ?
+ORDER BY id;
+
 -- name: GetProjectDomainAliases :many
 SELECT *
 FROM domain_aliases
 WHERE project_id = ?;
 
+-- name: GetProjectRoutes :many
+SELECT *
+FROM routes
+WHERE project_id = ?
+ORDER BY path;
+
 -- name: CreateProject :one
 INSERT INTO projects (
   name, port
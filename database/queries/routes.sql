@@ -0,0 +1,10 @@
+-- name: CreateRoute :exec
+INSERT INTO routes (
+  path, port, strip_prefix, project_id
+) VALUES (
+  ?, ?, ?, ?
+);
+
+-- name: DeleteRoute :exec
+DELETE FROM routes
+WHERE path = ? AND project_id = ?;
@@ -7,6 +7,7 @@ package sqlc
 
 import (
 	"context"
+	"database/sql"
 )
 
 const createCommand = `-- name: CreateCommand :exec
@@ -27,6 +28,24 @@ func (q *Queries) CreateCommand(ctx context.Context, arg CreateCommandParams) er
 	return err
 }
 
+const createCommandDependency = `-- name: CreateCommandDependency :exec
+INSERT INTO command_dependencies (
+  command_id, depends_on_id
+) VALUES (
+  ?, ?
+)
+`
+
+type CreateCommandDependencyParams struct {
+	CommandID   int64
+	DependsOnID int64
+}
+
+func (q *Queries) CreateCommandDependency(ctx context.Context, arg CreateCommandDependencyParams) error {
+	_, err := q.db.ExecContext(ctx, createCommandDependency, arg.CommandID, arg.DependsOnID)
+	return err
+}
+
 const deleteCommand = `-- name: DeleteCommand :exec
 DELETE FROM commands
 WHERE name = ?
@@ -47,8 +66,46 @@ func (q *Queries) DeleteCommandById(ctx context.Context, id int64) error {
 	return err
 }
 
+const deleteCommandDependencies = `-- name: DeleteCommandDependencies :exec
+DELETE FROM command_dependencies
+WHERE command_id = ?
+`
+
+func (q *Queries) DeleteCommandDependencies(ctx context.Context, commandID int64) error {
+	_, err := q.db.ExecContext(ctx, deleteCommandDependencies, commandID)
+	return err
+}
+
+const getAllCommandDependencies = `-- name: GetAllCommandDependencies :many
+SELECT command_id, depends_on_id
+FROM command_dependencies
+`
+
+func (q *Queries) GetAllCommandDependencies(ctx context.Context) ([]CommandDependency, error) {
+	rows, err := q.db.QueryContext(ctx, getAllCommandDependencies)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CommandDependency
+	for rows.Next() {
+		var i CommandDependency
+		if err := rows.Scan(&i.CommandID, &i.DependsOnID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getCommand = `-- name: GetCommand :one
-SELECT id, name, command
+SELECT id, name, command, shell, dir, env, force_color, ready_check, ready_value, restart_policy, max_retries, watch, watch_exclude, stop_signal, stop_timeout
 FROM commands
 WHERE name = ? LIMIT 1
 `
@@ -56,12 +113,58 @@ WHERE name = ? LIMIT 1
 func (q *Queries) GetCommand(ctx context.Context, name string) (Command, error) {
 	row := q.db.QueryRowContext(ctx, getCommand, name)
 	var i Command
-	err := row.Scan(&i.ID, &i.Name, &i.Command)
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Command,
+		&i.Shell,
+		&i.Dir,
+		&i.Env,
+		&i.ForceColor,
+		&i.ReadyCheck,
+		&i.ReadyValue,
+		&i.RestartPolicy,
+		&i.MaxRetries,
+		&i.Watch,
+		&i.WatchExclude,
+		&i.StopSignal,
+		&i.StopTimeout,
+	)
 	return i, err
 }
 
+const getCommandDependencies = `-- name: GetCommandDependencies :many
+SELECT c.name
+FROM commands c
+JOIN command_dependencies cd ON c.id = cd.depends_on_id
+WHERE cd.command_id = ?
+`
+
+func (q *Queries) GetCommandDependencies(ctx context.Context, commandID int64) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, getCommandDependencies, commandID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		items = append(items, name)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getCommands = `-- name: GetCommands :many
-SELECT id, name, command
+SELECT id, name, command, shell, dir, env, force_color, ready_check, ready_value, restart_policy, max_retries, watch, watch_exclude, stop_signal, stop_timeout
 FROM commands
 `
 
@@ -74,7 +177,23 @@ func (q *Queries) GetCommands(ctx context.Context) ([]Command, error) {
 	var items []Command
 	for rows.Next() {
 		var i Command
-		if err := rows.Scan(&i.ID, &i.Name, &i.Command); err != nil {
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Command,
+			&i.Shell,
+			&i.Dir,
+			&i.Env,
+			&i.ForceColor,
+			&i.ReadyCheck,
+			&i.ReadyValue,
+			&i.RestartPolicy,
+			&i.MaxRetries,
+			&i.Watch,
+			&i.WatchExclude,
+			&i.StopSignal,
+			&i.StopTimeout,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -104,6 +223,73 @@ func (q *Queries) RenameCommand(ctx context.Context, arg RenameCommandParams) er
 	return err
 }
 
+const setCommandReadiness = `-- name: SetCommandReadiness :exec
+UPDATE commands
+SET ready_check = ?, ready_value = ?
+WHERE name = ?
+`
+
+type SetCommandReadinessParams struct {
+	ReadyCheck string
+	ReadyValue string
+	Name       string
+}
+
+func (q *Queries) SetCommandReadiness(ctx context.Context, arg SetCommandReadinessParams) error {
+	_, err := q.db.ExecContext(ctx, setCommandReadiness, arg.ReadyCheck, arg.ReadyValue, arg.Name)
+	return err
+}
+
+const setCommandRestartPolicy = `-- name: SetCommandRestartPolicy :exec
+UPDATE commands
+SET restart_policy = ?, max_retries = ?
+WHERE name = ?
+`
+
+type SetCommandRestartPolicyParams struct {
+	RestartPolicy string
+	MaxRetries    int64
+	Name          string
+}
+
+func (q *Queries) SetCommandRestartPolicy(ctx context.Context, arg SetCommandRestartPolicyParams) error {
+	_, err := q.db.ExecContext(ctx, setCommandRestartPolicy, arg.RestartPolicy, arg.MaxRetries, arg.Name)
+	return err
+}
+
+const setCommandShell = `-- name: SetCommandShell :exec
+UPDATE commands
+SET shell = ?
+WHERE name = ?
+`
+
+type SetCommandShellParams struct {
+	Shell bool
+	Name  string
+}
+
+func (q *Queries) SetCommandShell(ctx context.Context, arg SetCommandShellParams) error {
+	_, err := q.db.ExecContext(ctx, setCommandShell, arg.Shell, arg.Name)
+	return err
+}
+
+const setCommandStopSignal = `-- name: SetCommandStopSignal :exec
+UPDATE commands
+SET stop_signal = ?, stop_timeout = ?
+WHERE name = ?
+`
+
+type SetCommandStopSignalParams struct {
+	StopSignal  string
+	StopTimeout int64
+	Name        string
+}
+
+func (q *Queries) SetCommandStopSignal(ctx context.Context, arg SetCommandStopSignalParams) error {
+	_, err := q.db.ExecContext(ctx, setCommandStopSignal, arg.StopSignal, arg.StopTimeout, arg.Name)
+	return err
+}
+
 const updateCommand = `-- name: UpdateCommand :exec
 UPDATE commands
 SET command = ?
@@ -136,3 +322,32 @@ func (q *Queries) UpdateCommandById(ctx context.Context, arg UpdateCommandByIdPa
 	_, err := q.db.ExecContext(ctx, updateCommandById, arg.Name, arg.Command, arg.ID)
 	return err
 }
+
+const updateCommandSettings = `-- name: UpdateCommandSettings :exec
+UPDATE commands
+SET shell = ?, dir = ?, env = ?, force_color = ?, watch = ?, watch_exclude = ?
+WHERE id = ?
+`
+
+type UpdateCommandSettingsParams struct {
+	Shell        bool
+	Dir          sql.NullString
+	Env          string
+	ForceColor   bool
+	Watch        string
+	WatchExclude string
+	ID           int64
+}
+
+func (q *Queries) UpdateCommandSettings(ctx context.Context, arg UpdateCommandSettingsParams) error {
+	_, err := q.db.ExecContext(ctx, updateCommandSettings,
+		arg.Shell,
+		arg.Dir,
+		arg.Env,
+		arg.ForceColor,
+		arg.Watch,
+		arg.WatchExclude,
+		arg.ID,
+	)
+	return err
+}
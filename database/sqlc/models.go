@@ -9,9 +9,26 @@ import (
 )
 
 type Command struct {
-	ID      int64
-	Name    string
-	Command string
+	ID            int64
+	Name          string
+	Command       string
+	Shell         bool
+	Dir           sql.NullString
+	Env           string
+	ForceColor    bool
+	ReadyCheck    string
+	ReadyValue    string
+	RestartPolicy string
+	MaxRetries    int64
+	Watch         string
+	WatchExclude  string
+	StopSignal    string
+	StopTimeout   int64
+}
+
+type CommandDependency struct {
+	CommandID   int64
+	DependsOnID int64
 }
 
 type DomainAlias struct {
@@ -20,6 +37,29 @@ type DomainAlias struct {
 	ProjectID int64
 }
 
+type EnvFile struct {
+	ID        int64
+	Path      string
+	ProjectID int64
+}
+
+type Group struct {
+	ID   int64
+	Name string
+}
+
+type GroupProject struct {
+	GroupID   int64
+	ProjectID int64
+}
+
+type EnvVariable struct {
+	ID        int64
+	Name      string
+	Value     string
+	ProjectID int64
+}
+
 type Project struct {
 	ID   int64
 	Name string
@@ -32,6 +72,14 @@ type ProjectCommand struct {
 	CommandID int64
 }
 
+type Route struct {
+	ID          int64
+	Path        string
+	Port        int64
+	StripPrefix bool
+	ProjectID   int64
+}
+
 type Variable struct {
 	ID        int64
 	Name      string
@@ -120,7 +120,7 @@ func (q *Queries) GetProject(ctx context.Context, name string) (Project, error)
 }
 
 const getProjectCommands = `-- name: GetProjectCommands :many
-SELECT c.id, c.name, c.command
+SELECT c.id, c.name, c.command, c.shell, c.dir, c.env, c.force_color, c.ready_check, c.ready_value, c.restart_policy, c.max_retries, c.watch, c.watch_exclude, c.stop_signal, c.stop_timeout
 FROM commands c
 JOIN project_commands cp ON c.id = cp.command_id
 WHERE cp.project_id = ?
@@ -135,7 +135,23 @@ func (q *Queries) GetProjectCommands(ctx context.Context, projectID int64) ([]Co
 	var items []Command
 	for rows.Next() {
 		var i Command
-		if err := rows.Scan(&i.ID, &i.Name, &i.Command); err != nil {
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Command,
+			&i.Shell,
+			&i.Dir,
+			&i.Env,
+			&i.ForceColor,
+			&i.ReadyCheck,
+			&i.ReadyValue,
+			&i.RestartPolicy,
+			&i.MaxRetries,
+			&i.Watch,
+			&i.WatchExclude,
+			&i.StopSignal,
+			&i.StopTimeout,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -178,6 +194,106 @@ func (q *Queries) GetProjectDomainAliases(ctx context.Context, projectID int64)
 	return items, nil
 }
 
+const getProjectEnvFiles = `-- name: GetProjectEnvFiles :many
+SELECT id, path, project_id
+FROM env_files
+WHERE project_id = ?
+ORDER BY id
+`
+
+func (q *Queries) GetProjectEnvFiles(ctx context.Context, projectID int64) ([]EnvFile, error) {
+	rows, err := q.db.QueryContext(ctx, getProjectEnvFiles, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []EnvFile
+	for rows.Next() {
+		var i EnvFile
+		if err := rows.Scan(&i.ID, &i.Path, &i.ProjectID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getProjectEnvVariables = `-- name: GetProjectEnvVariables :many
+SELECT id, name, value, project_id
+FROM env_variables
+WHERE project_id = ?
+`
+
+func (q *Queries) GetProjectEnvVariables(ctx context.Context, projectID int64) ([]EnvVariable, error) {
+	rows, err := q.db.QueryContext(ctx, getProjectEnvVariables, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []EnvVariable
+	for rows.Next() {
+		var i EnvVariable
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Value,
+			&i.ProjectID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getProjectRoutes = `-- name: GetProjectRoutes :many
+SELECT id, path, port, strip_prefix, project_id
+FROM routes
+WHERE project_id = ?
+ORDER BY path
+`
+
+func (q *Queries) GetProjectRoutes(ctx context.Context, projectID int64) ([]Route, error) {
+	rows, err := q.db.QueryContext(ctx, getProjectRoutes, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Route
+	for rows.Next() {
+		var i Route
+		if err := rows.Scan(
+			&i.ID,
+			&i.Path,
+			&i.Port,
+			&i.StripPrefix,
+			&i.ProjectID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getProjectVariables = `-- name: GetProjectVariables :many
 SELECT id, name, value, project_id
 FROM variables
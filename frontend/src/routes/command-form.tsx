@@ -3,6 +3,7 @@ import { Input } from '~/components/input';
 import { PageTitle } from '~/components/page-title';
 import { useCommandsStore } from '~/stores/commandsStore';
 import { Button } from '~/components/button';
+import { Checkbox } from '~/components/checkbox';
 import toast from 'react-hot-toast';
 import { createFileRoute, useNavigate } from '@tanstack/react-router';
 import { getCommandIcon } from '~/utils/command';
@@ -21,6 +22,12 @@ function CommandForm() {
 
   const [name, setName] = useState('');
   const [command, setCommand] = useState('');
+  const [shell, setShell] = useState(false);
+  const [dir, setDir] = useState('');
+  const [env, setEnv] = useState('');
+  const [forceColor, setForceColor] = useState(true);
+  const [watch, setWatch] = useState('');
+  const [watchExclude, setWatchExclude] = useState('');
 
   const pageTitle = useMemo(
     () =>
@@ -39,29 +46,39 @@ function CommandForm() {
       e.preventDefault();
 
       await toast
-        .promise(commandFormSubmit(name, command), {
-          loading: editingCommand ? 'Saving command...' : 'Creating command...',
-          success: editingCommand ? <b>Command saved</b> : <b>Command created</b>,
-          error: (err: any) =>
-            editingCommand ? (
-              <b>
-                Failed to save command:
-                <br />
-                {err}
-              </b>
-            ) : (
-              <b>
-                Failed to create command:
-                <br />
-                {err}
-              </b>
-            ),
-        })
+        .promise(
+          commandFormSubmit(name, command, {
+            Shell: shell,
+            Dir: dir,
+            Env: env.split('\n').filter((line) => line.trim() !== ''),
+            ForceColor: forceColor,
+            Watch: watch.split('\n').filter((line) => line.trim() !== ''),
+            WatchExclude: watchExclude.split('\n').filter((line) => line.trim() !== ''),
+          }),
+          {
+            loading: editingCommand ? 'Saving command...' : 'Creating command...',
+            success: editingCommand ? <b>Command saved</b> : <b>Command created</b>,
+            error: (err: any) =>
+              editingCommand ? (
+                <b>
+                  Failed to save command:
+                  <br />
+                  {err}
+                </b>
+              ) : (
+                <b>
+                  Failed to create command:
+                  <br />
+                  {err}
+                </b>
+              ),
+          }
+        )
         .then(() => {
           navigate({ to: '/commands' });
         });
     },
-    [name, command, editingCommand, commandFormSubmit]
+    [name, command, shell, dir, env, forceColor, watch, watchExclude, editingCommand, commandFormSubmit]
   );
 
   useEffect(() => {
@@ -71,9 +88,15 @@ function CommandForm() {
       if (command) {
         setName(command.Name);
         setCommand(command.Command);
+        setShell(command.Shell);
+        setDir(command.Dir.Valid ? command.Dir.String : '');
+        setEnv(command.Env);
+        setForceColor(command.ForceColor);
+        setWatch(command.Watch);
+        setWatchExclude(command.WatchExclude);
       }
     }
-  }, [editingCommand, setName, setCommand]);
+  }, [editingCommand, setName, setCommand, setShell, setDir, setEnv, setForceColor, setWatch, setWatchExclude]);
 
   return (
     <form id="command-form" onSubmit={submit} className="flex flex-col w-full max-w-6xl mx-auto">
@@ -103,6 +126,70 @@ function CommandForm() {
           />
         </div>
 
+        <div className="flex items-center gap-2">
+          <Checkbox id="shell" name="shell" checked={shell} onChange={(e) => setShell(e.target.checked)} />
+          <label htmlFor="shell">Run in shell</label>
+        </div>
+
+        <div className="flex flex-col gap-2">
+          <label htmlFor="dir" className="w-max">
+            Directory (relative to the project directory)
+          </label>
+          <Input id="dir" name="dir" type="text" value={dir} onChange={(e) => setDir(e.target.value)} />
+        </div>
+
+        <div className="flex flex-col gap-2">
+          <label htmlFor="env" className="w-max">
+            Environment variables (KEY=VALUE, one per line)
+          </label>
+          <textarea
+            id="env"
+            name="env"
+            rows={3}
+            className="w-full px-3 py-2 font-mono transition-colors duration-200 ease-in-out border rounded-lg appearance-none outline-offset-2 focus-visible:outline-solid outline-1 outline-primary bg-background text-white border-primary hover:outline-solid"
+            value={env}
+            onChange={(e) => setEnv(e.target.value)}
+          />
+        </div>
+
+        <div className="flex items-center gap-2">
+          <Checkbox
+            id="force-color"
+            name="force-color"
+            checked={forceColor}
+            onChange={(e) => setForceColor(e.target.checked)}
+          />
+          <label htmlFor="force-color">Force color output (FORCE_COLOR=1)</label>
+        </div>
+
+        <div className="flex flex-col gap-2">
+          <label htmlFor="watch" className="w-max">
+            Restart when files change (glob patterns like **/*.go, one per line)
+          </label>
+          <textarea
+            id="watch"
+            name="watch"
+            rows={2}
+            className="w-full px-3 py-2 font-mono transition-colors duration-200 ease-in-out border rounded-lg appearance-none outline-offset-2 focus-visible:outline-solid outline-1 outline-primary bg-background text-white border-primary hover:outline-solid"
+            value={watch}
+            onChange={(e) => setWatch(e.target.value)}
+          />
+        </div>
+
+        <div className="flex flex-col gap-2">
+          <label htmlFor="watch-exclude" className="w-max">
+            Excluded files (glob patterns, one per line)
+          </label>
+          <textarea
+            id="watch-exclude"
+            name="watch-exclude"
+            rows={2}
+            className="w-full px-3 py-2 font-mono transition-colors duration-200 ease-in-out border rounded-lg appearance-none outline-offset-2 focus-visible:outline-solid outline-1 outline-primary bg-background text-white border-primary hover:outline-solid"
+            value={watchExclude}
+            onChange={(e) => setWatchExclude(e.target.value)}
+          />
+        </div>
+
         {showCommandIcons && <div className="flex items-center gap-2">Icon: {commandIcon}</div>}
 
         <Button type="submit" className="mt-2">
@@ -22,7 +22,7 @@ export const Route = createFileRoute('/projects')({
 });
 
 export function Projects() {
-  const { projects, setProjects, setEditingProject } = useProjectsStore();
+  const { projects, setProjects, setEditingProject, fetchRunningProjects } = useProjectsStore();
 
   const { setSetting } = useSettingsStore();
 
@@ -30,7 +30,8 @@ export function Projects() {
 
   const fetchProjects = useCallback(() => {
     GetProjects().then((projects) => setProjects(projects || []));
-  }, [setProjects]);
+    fetchRunningProjects();
+  }, [setProjects, fetchRunningProjects]);
 
   useEffect(() => {
     fetchProjects();
@@ -1,22 +1,68 @@
 import { ArrowDownIcon, XMarkIcon } from '@heroicons/react/20/solid';
 import { useCallback, useEffect, useMemo, useRef, useState } from 'react';
 import { useProjectsStore } from '~/stores/projectsStore';
-import { FollowProjectLogs, StopFollowingProjectLogs } from 'wjs/go/app/App';
-import AnsiToHtml from 'ansi-to-html';
+import { FollowProjectLogs, SearchProjectLogs, StopFollowingProjectLogs } from 'wjs/go/app/App';
 import { EventsOn } from 'wjs/runtime/runtime';
 import { Button } from '~/components/button';
+import { Checkbox } from '~/components/checkbox';
+import { Input } from '~/components/input';
+import { Select } from '~/components/select';
 import { cn } from '~/utils/helpers';
-
-const LOG_SIZE_LIMIT = 100000;
+import { LogLine } from '~/types';
+import { core } from 'wjs/go/models';
+
+// Limit the number of lines that are shown to prevent the browser from freezing
+const LOG_LINE_LIMIT = 5000;
+
+const QUERY_DEBOUNCE_MS = 300;
+
+const levelClassNames: Record<string, string> = {
+  error: 'text-red-400',
+  warn: 'text-yellow-400',
+  debug: 'text-gray-400',
+};
+
+function LogSegments({ line }: { line: LogLine }) {
+  return line.segments.map((segment, i) => (
+    <span
+      key={i}
+      className={cn(
+        segment.bold && 'font-bold',
+        segment.dim && 'opacity-60',
+        segment.italic && 'italic',
+        segment.underline && 'underline'
+      )}
+      style={{ color: segment.foreground || undefined, backgroundColor: segment.background || undefined }}
+    >
+      {segment.text}
+    </span>
+  ));
+}
 
 export function LogsPopover() {
-  const ansiToHtml = useMemo(() => new AnsiToHtml({ stream: true }), []);
-
-  const { currentProject, setCurrentProject } = useProjectsStore();
-  const [ansiLogs, setAnsiLogs] = useState('');
+  const { currentProject, setCurrentProject, projects } = useProjectsStore();
+  const [lines, setLines] = useState<LogLine[]>([]);
+  const [before, setBefore] = useState(0);
+  const [hasMore, setHasMore] = useState(false);
+  const [error, setError] = useState<string | null>(null);
   const [followLogs, setFollowLogs] = useState(true);
 
-  const logs = useMemo(() => ansiToHtml.toHtml(ansiLogs), [ansiLogs]);
+  const [command, setCommand] = useState('');
+  const [stream, setStream] = useState('');
+  const [level, setLevel] = useState('');
+  const [query, setQuery] = useState('');
+  const [debouncedQuery, setDebouncedQuery] = useState('');
+  const [regex, setRegex] = useState(false);
+
+  const filter = useMemo(
+    () => new core.LogFilter({ command, stream, level, query: debouncedQuery, regex }),
+    [command, stream, level, debouncedQuery, regex]
+  );
+
+  const commandNames = useMemo(
+    () => projects?.find((project) => project.Name === currentProject)?.Commands.map((command) => command.Name) ?? [],
+    [projects, currentProject]
+  );
 
   const logsRef = useRef<HTMLDivElement | null>(null);
 
@@ -30,24 +76,67 @@ export function LogsPopover() {
     setCurrentProject(null);
   }, [setCurrentProject]);
 
+  const loadOlderLines = useCallback(async () => {
+    if (!currentProject) return;
+
+    const page = await SearchProjectLogs(currentProject, filter, before, 0);
+
+    setLines((prevLines) => [...page.lines, ...prevLines]);
+    setBefore(page.before);
+    setHasMore(page.hasMore);
+  }, [currentProject, filter, before]);
+
+  useEffect(() => {
+    const timeout = setTimeout(() => setDebouncedQuery(query), QUERY_DEBOUNCE_MS);
+
+    return () => clearTimeout(timeout);
+  }, [query]);
+
   useEffect(() => {
     if (!currentProject) return;
 
-    FollowProjectLogs(currentProject);
+    let stopped = false;
 
-    const stopListeningForLogs = EventsOn('log', (newLogs: string) => {
-      setAnsiLogs((prevLogs) => {
-        const logs = prevLogs + newLogs;
+    const stopListeningForLogs = EventsOn('log', (line: LogLine) => {
+      setLines((prevLines) => {
+        const lines = [...prevLines, line];
 
-        // Limit logs to LOG_SIZE_LIMIT characters to prevent browser from freezing
-        if (logs.length > LOG_SIZE_LIMIT) {
-          return logs.slice(-LOG_SIZE_LIMIT);
+        if (lines.length > LOG_LINE_LIMIT) {
+          setHasMore(true);
+          return lines.slice(-LOG_LINE_LIMIT);
         }
 
-        return logs;
+        return lines;
       });
     });
 
+    SearchProjectLogs(currentProject, filter, 0, 0)
+      .then((page) => {
+        if (stopped) return;
+
+        setError(null);
+        setLines(page.lines);
+        setBefore(page.before);
+        setHasMore(page.hasMore);
+
+        FollowProjectLogs(currentProject, filter, page.offset);
+      })
+      .catch((err) => {
+        setError(String(err));
+        setLines([]);
+      });
+
+    return () => {
+      stopped = true;
+
+      StopFollowingProjectLogs(currentProject).catch(() => {});
+      stopListeningForLogs();
+    };
+  }, [currentProject, filter]);
+
+  useEffect(() => {
+    if (!currentProject) return;
+
     const scrollListener = (e: Event) => {
       const target = e.target as HTMLElement;
 
@@ -65,10 +154,7 @@ export function LogsPopover() {
     window.addEventListener('keydown', closeOnEscape);
 
     return () => {
-      StopFollowingProjectLogs(currentProject);
-
-      setAnsiLogs('');
-      stopListeningForLogs();
+      setLines([]);
       currentLogsRef?.removeEventListener('scroll', scrollListener);
       window?.removeEventListener('keydown', closeOnEscape);
     };
@@ -78,7 +164,7 @@ export function LogsPopover() {
     if (followLogs) {
       scrollToBottom();
     }
-  }, [followLogs, scrollToBottom, logsRef.current?.scrollHeight, ansiLogs]);
+  }, [followLogs, scrollToBottom, logsRef.current?.scrollHeight, lines]);
 
   if (!currentProject) return null;
 
@@ -95,8 +181,51 @@ export function LogsPopover() {
               <XMarkIcon width={24} height={24} />
             </button>
           </div>
+
+          <div className="flex items-center gap-2 mt-4">
+            <Select value={command} onChange={(e) => setCommand(e.target.value)} className="w-40">
+              <option value="">All commands</option>
+              <option value="spinup">spinup</option>
+              {commandNames.map((commandName) => (
+                <option key={commandName} value={commandName}>
+                  {commandName}
+                </option>
+              ))}
+            </Select>
+            <Select value={stream} onChange={(e) => setStream(e.target.value)} className="w-32">
+              <option value="">All streams</option>
+              <option value="stdout">stdout</option>
+              <option value="stderr">stderr</option>
+            </Select>
+            <Select value={level} onChange={(e) => setLevel(e.target.value)} className="w-32">
+              <option value="">All levels</option>
+              <option value="debug">debug</option>
+              <option value="info">info</option>
+              <option value="warn">warn</option>
+              <option value="error">error</option>
+            </Select>
+            <Input value={query} onChange={(e) => setQuery(e.target.value)} placeholder="Search logs..." />
+            <label className="flex items-center gap-2">
+              <Checkbox checked={regex} onChange={(e) => setRegex(e.target.checked)} />
+              <span>Regex</span>
+            </label>
+          </div>
+
           <div ref={logsRef} className="w-full h-full mt-4 overflow-y-auto rounded-lg bg-black/50">
-            <pre className="p-4 text-sm text-wrap" dangerouslySetInnerHTML={{ __html: logs }} />
+            {hasMore && (
+              <div className="flex justify-center pt-4">
+                <Button onClick={loadOlderLines}>Load older lines</Button>
+              </div>
+            )}
+            {error && <p className="p-4 text-sm text-red-400">{error}</p>}
+            <pre className="p-4 text-sm text-wrap">
+              {lines.map((line, i) => (
+                <div key={i} className={cn(levelClassNames[line.level], line.stream === 'stderr' && 'bg-red-500/10')}>
+                  <span className="text-gray-500">[{line.command}] </span>
+                  <LogSegments line={line} />
+                </div>
+              ))}
+            </pre>
           </div>
 
           <div className={cn('absolute -translate-x-1/2 left-1/2 bottom-8 w-fit', followLogs ? 'hidden' : 'flex')}>
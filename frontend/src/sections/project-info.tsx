@@ -186,15 +186,19 @@ export function ProjectInfo({ project }: { project: core.Project }) {
 
   const projectViewLayout = useSettingsStore((state) => state.getSetting(SettingKey.ProjectViewLayout));
 
-  const isRunning = useMemo(() => runningProjects.includes(project.Name), [runningProjects]);
+  const isRunning = useMemo(() => runningProjects.some((p) => p.project === project.Name), [runningProjects]);
   const commands = useMemo(() => project.Commands?.map((c) => c.Name).join(', '), [project.Commands]);
   // const variables = useMemo(
   //   () => project.Variables?.map((v) => `${v.Name}=${v.Value}`).join(', '),
   //   [project.Variables]
   // );
   const domainAliases = useMemo(() => project.DomainAliases?.map((da) => da.Value).join(', '), [project.DomainAliases]);
+  const routes = useMemo(
+    () => project.Routes?.map((r) => `${r.Path} → ${r.Port}${r.StripPrefix ? ' (strip prefix)' : ''}`).join(', '),
+    [project.Routes]
+  );
 
-  const projectDomain = useMemo(() => `${project.Name}.test`, [project.Name]);
+  const projectDomain = project.Domain;
 
   const commandInfos = useMemo<CommandInfo[]>(() => {
     return (
@@ -325,6 +329,9 @@ export function ProjectInfo({ project }: { project: core.Project }) {
 
         <div>Domain aliases</div>
         <div className="text-sm">{domainAliases || '-'}</div>
+
+        <div>Routes</div>
+        <div className="text-sm">{routes || '-'}</div>
       </div>
     </div>
   );
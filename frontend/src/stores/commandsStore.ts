@@ -1,6 +1,7 @@
 import { create } from 'zustand';
 import { Commands } from '~/types';
 import { AddCommand, GetCommands, RemoveCommand, UpdateCommand } from 'wjs/go/app/App';
+import { core } from 'wjs/go/models';
 
 interface CommandsState {
   commands: Commands | null;
@@ -9,7 +10,7 @@ interface CommandsState {
   editingCommand: number | null;
   setEditingCommand: (commandName: number | null) => void;
 
-  commandFormSubmit: (commandName: string, command: string) => Promise<void>;
+  commandFormSubmit: (commandName: string, command: string, settings: core.CommandSettings) => Promise<void>;
   removeCommand: (commandID: number) => Promise<void>;
 }
 
@@ -20,14 +21,14 @@ export const useCommandsStore = create<CommandsState>((set, get) => ({
   editingCommand: null,
   setEditingCommand: (commandName) => set({ editingCommand: commandName }),
 
-  commandFormSubmit: async (commandName, command) => {
+  commandFormSubmit: async (commandName, command, settings) => {
     const commandID = get().editingCommand;
 
     if (commandID !== null) {
-      await UpdateCommand(commandID, commandName, command);
+      await UpdateCommand(commandID, commandName, command, settings);
       set({ editingCommand: null });
     } else {
-      await AddCommand(commandName, command);
+      await AddCommand(commandName, command, settings);
     }
 
     const commands = await GetCommands();
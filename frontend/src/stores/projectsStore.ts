@@ -1,10 +1,16 @@
 import { create } from 'zustand';
-import { Projects } from '~/types';
+import { Projects, RunningProjects } from '~/types';
 import {
   GetProjects,
+  GetRunningProjects,
+  StartCommand,
+  StopCommand,
+  RestartCommand,
   RunProject,
   UpdateProjectDirectory,
   StopProject,
+  StartGroup,
+  StopGroup,
   AddProject,
   RemoveProject,
   UpdateProject,
@@ -18,9 +24,15 @@ interface ProjectsState {
   editingProject: number | null;
   setEditingProject: (projectName: number | null) => void;
 
-  runningProjects: string[];
+  runningProjects: RunningProjects;
+  fetchRunningProjects: () => Promise<void>;
   runProject: (projectName: string) => Promise<void>;
   stopProject: (projectName: string) => Promise<void>;
+  startGroup: (groupName: string) => Promise<void>;
+  stopGroup: (groupName: string) => Promise<void>;
+  startCommand: (projectName: string, commandName: string) => Promise<void>;
+  stopCommand: (projectName: string, commandName: string) => Promise<void>;
+  restartCommand: (projectName: string, commandName: string) => Promise<void>;
   updateProjectDir: (projectName: string, defaultDir: string | undefined) => Promise<void>;
   selectProjectDir: (projectName: string, defaultDir: string | null) => Promise<string>;
   projectFormSubmit: (
@@ -43,19 +55,37 @@ export const useProjectsStore = create<ProjectsState>((set, get) => ({
   setEditingProject: (projectName) => set(() => ({ editingProject: projectName })),
 
   runningProjects: [],
+  async fetchRunningProjects() {
+    const runningProjects = await GetRunningProjects();
+    set(() => ({ runningProjects: runningProjects || [] }));
+  },
   async runProject(projectName) {
-    set((state) => ({
-      runningProjects: [...state.runningProjects, projectName],
-    }));
-
     await RunProject(projectName);
+    await get().fetchRunningProjects();
   },
   async stopProject(projectName) {
-    set((state) => ({
-      runningProjects: state.runningProjects.filter((p) => p !== projectName),
-    }));
-
     await StopProject(projectName);
+    await get().fetchRunningProjects();
+  },
+  async startGroup(groupName) {
+    await StartGroup(groupName);
+    await get().fetchRunningProjects();
+  },
+  async stopGroup(groupName) {
+    await StopGroup(groupName);
+    await get().fetchRunningProjects();
+  },
+  async startCommand(projectName, commandName) {
+    await StartCommand(projectName, commandName);
+    await get().fetchRunningProjects();
+  },
+  async stopCommand(projectName, commandName) {
+    await StopCommand(projectName, commandName);
+    await get().fetchRunningProjects();
+  },
+  async restartCommand(projectName, commandName) {
+    await RestartCommand(projectName, commandName);
+    await get().fetchRunningProjects();
   },
   async updateProjectDir(projectName, defaultDir) {
     await UpdateProjectDirectory(projectName, defaultDir ?? '');
@@ -1,5 +1,11 @@
-import { GetCommands, GetProjects } from 'wjs/go/app/App';
+import { GetCommands, GetProjects, GetRunningProjects, SearchProjectLogs } from 'wjs/go/app/App';
 
 export type Projects = Awaited<ReturnType<typeof GetProjects>>;
 
 export type Commands = Awaited<ReturnType<typeof GetCommands>>;
+
+export type RunningProjects = Awaited<ReturnType<typeof GetRunningProjects>>;
+
+export type LogPage = Awaited<ReturnType<typeof SearchProjectLogs>>;
+
+export type LogLine = LogPage['lines'][number];
@@ -3,9 +3,13 @@ package main
 import (
 	"embed"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/iskandervdh/spinup/app"
 	"github.com/iskandervdh/spinup/cli"
+	"github.com/iskandervdh/spinup/common"
+	"github.com/iskandervdh/spinup/daemon"
 	"github.com/wailsapp/wails/v2"
 	"github.com/wailsapp/wails/v2/pkg/options"
 	"github.com/wailsapp/wails/v2/pkg/options/assetserver"
@@ -15,6 +19,12 @@ import (
 var assets embed.FS
 
 func main() {
+	// Run as the daemon when the binary is invoked as spinupd, for example through a symlink
+	if strings.TrimSuffix(filepath.Base(os.Args[0]), ".exe") == common.DaemonName {
+		daemon.Main()
+		return
+	}
+
 	if len(os.Args) > 1 {
 		c := cli.New()
 		c.Handle()
@@ -0,0 +1,230 @@
+package proxy
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// How often the routes are reloaded, so changes to projects, their domain aliases and routes are picked up.
+const reloadInterval = time.Second
+
+// Route forwards the requests to a path prefix of a host to a port, optionally stripping the prefix from the path.
+//
+// The path of a route is / or a prefix without a trailing slash, which only matches whole path segments.
+type Route struct {
+	Path        string
+	Port        int64
+	StripPrefix bool
+}
+
+// Check if the route matches the given path.
+func (route Route) matches(path string) bool {
+	return route.Path == "/" || path == route.Path || strings.HasPrefix(path, route.Path+"/")
+}
+
+// Remove the path prefix of the route from the given path, keeping it absolute.
+func (route Route) strip(path string) string {
+	stripped := strings.TrimPrefix(path, route.Path)
+
+	if !strings.HasPrefix(stripped, "/") {
+		return "/" + stripped
+	}
+
+	return stripped
+}
+
+// Router routes requests to the port of a project by their Host header and the path prefixes of the routes of the project.
+//
+// The routes map domains to the routes of their project, including one for / with the port of the project,
+// and are loaded with the given function, which reads them from the database.
+// WebSocket and other upgrade requests are proxied as well.
+type Router struct {
+	load func() (map[string][]Route, error)
+
+	mu     sync.RWMutex
+	routes map[string][]Route
+}
+
+// Create a new router that loads its routes with the given function.
+func NewRouter(load func() (map[string][]Route, error)) *Router {
+	return &Router{
+		load:   load,
+		routes: map[string][]Route{},
+	}
+}
+
+// Load the routes again, replacing the current ones.
+//
+// The routes of every domain are sorted by the length of their paths, so the longest matching path prefix is used.
+func (r *Router) Reload() error {
+	routes, err := r.load()
+
+	if err != nil {
+		return err
+	}
+
+	for _, domainRoutes := range routes {
+		slices.SortStableFunc(domainRoutes, func(a Route, b Route) int { return len(b.Path) - len(a.Path) })
+	}
+
+	r.mu.Lock()
+	r.routes = routes
+	r.mu.Unlock()
+
+	return nil
+}
+
+// Reload the routes every interval until stop is closed.
+//
+// Errors are passed to onError, the previous routes are kept when loading fails.
+func (r *Router) Watch(interval time.Duration, stop <-chan struct{}, onError func(error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			if err := r.Reload(); err != nil && onError != nil {
+				onError(err)
+			}
+		}
+	}
+}
+
+// Get the route of the given path of the project with the given host, which can include a port.
+func (r *Router) Lookup(host string, path string) (Route, bool) {
+	if hostname, _, err := net.SplitHostPort(host); err == nil {
+		host = hostname
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, route := range r.routes[strings.ToLower(strings.TrimSuffix(host, "."))] {
+		if route.matches(path) {
+			return route, true
+		}
+	}
+
+	return Route{}, false
+}
+
+// Proxy the request to the port of the route of its host and path, with the same headers the generated reverse
+// proxy configuration files set.
+func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	route, ok := r.Lookup(req.Host, req.URL.Path)
+
+	if !ok {
+		http.Error(w, fmt.Sprintf("No project with domain %s", req.Host), http.StatusNotFound)
+		return
+	}
+
+	target := &url.URL{Scheme: "http", Host: fmt.Sprintf("127.0.0.1:%d", route.Port)}
+
+	reverseProxy := &httputil.ReverseProxy{
+		Rewrite: func(pr *httputil.ProxyRequest) {
+			pr.SetURL(target)
+			pr.SetXForwarded()
+
+			pr.Out.Host = pr.In.Host
+
+			if route.StripPrefix {
+				pr.Out.URL.Path = route.strip(pr.Out.URL.Path)
+
+				// The path prefix only contains characters that are not escaped
+				if pr.Out.URL.RawPath != "" {
+					pr.Out.URL.RawPath = route.strip(pr.Out.URL.RawPath)
+				}
+			}
+
+			if clientIP, _, err := net.SplitHostPort(pr.In.RemoteAddr); err == nil {
+				pr.Out.Header.Set("X-Real-IP", clientIP)
+			}
+		},
+		ErrorHandler: func(w http.ResponseWriter, req *http.Request, err error) {
+			http.Error(w, fmt.Sprintf("Project of %s is not responding on port %d: %s", req.Host, route.Port, err), http.StatusBadGateway)
+		},
+	}
+
+	reverseProxy.ServeHTTP(w, req)
+}
+
+// Server serves a router on a port and reloads its routes while it is serving.
+type Server struct {
+	router *Router
+	server *http.Server
+
+	listener  net.Listener
+	stop      chan struct{}
+	closeOnce sync.Once
+}
+
+// Create a new server that listens on the given port and routes requests with the routes loaded with the given function.
+func NewServer(port int64, load func() (map[string][]Route, error)) *Server {
+	router := NewRouter(load)
+
+	return &Server{
+		router: router,
+		server: &http.Server{
+			Addr:              fmt.Sprintf(":%d", port),
+			Handler:           router,
+			ReadHeaderTimeout: 10 * time.Second,
+		},
+		stop: make(chan struct{}),
+	}
+}
+
+// Load the routes and start listening on the port of the server.
+func (s *Server) Listen() error {
+	err := s.router.Reload()
+
+	if err != nil {
+		return fmt.Errorf("error loading routes: %s", err)
+	}
+
+	listener, err := net.Listen("tcp", s.server.Addr)
+
+	if err != nil {
+		return fmt.Errorf("error listening on %s: %s", s.server.Addr, err)
+	}
+
+	s.listener = listener
+
+	return nil
+}
+
+// Get the address the server is listening on.
+func (s *Server) Addr() string {
+	return s.listener.Addr().String()
+}
+
+// Serve requests until the server is closed, reloading the routes in the background.
+//
+// Errors while reloading the routes are passed to onError.
+func (s *Server) Serve(onError func(error)) error {
+	go s.router.Watch(reloadInterval, s.stop, onError)
+
+	err := s.server.Serve(s.listener)
+
+	if err == http.ErrServerClosed {
+		return nil
+	}
+
+	return err
+}
+
+// Stop serving requests and reloading the routes.
+func (s *Server) Close() error {
+	s.closeOnce.Do(func() { close(s.stop) })
+
+	return s.server.Close()
+}
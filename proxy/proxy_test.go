@@ -0,0 +1,284 @@
+package proxy
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// Start a backend that responds with the given name and the Host header it received.
+func testingBackend(t *testing.T, name string) int64 {
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "%s %s %s", name, r.Host, r.Header.Get("X-Forwarded-Host"))
+	}))
+
+	t.Cleanup(backend.Close)
+
+	return backendPort(t, backend)
+}
+
+func backendPort(t *testing.T, backend *httptest.Server) int64 {
+	backendURL, _ := url.Parse(backend.URL)
+	port, err := strconv.ParseInt(backendURL.Port(), 10, 64)
+
+	if err != nil {
+		t.Fatal("Expected port in backend url, got", backend.URL)
+	}
+
+	return port
+}
+
+// Get routes that route the given domains to the given ports.
+func portRoutes(ports map[string]int64) map[string][]Route {
+	routes := map[string][]Route{}
+
+	for domain, port := range ports {
+		routes[domain] = []Route{{Path: "/", Port: port}}
+	}
+
+	return routes
+}
+
+func get(t *testing.T, handler http.Handler, host string) (int, string) {
+	return getPath(t, handler, host, "/")
+}
+
+func getPath(t *testing.T, handler http.Handler, host string, path string) (int, string) {
+	req := httptest.NewRequest(http.MethodGet, "http://"+host+path, nil)
+	recorder := httptest.NewRecorder()
+
+	handler.ServeHTTP(recorder, req)
+
+	body, _ := io.ReadAll(recorder.Result().Body)
+
+	return recorder.Code, string(body)
+}
+
+func TestRouter(t *testing.T) {
+	frontend := testingBackend(t, "frontend")
+	api := testingBackend(t, "api")
+
+	router := NewRouter(func() (map[string][]Route, error) {
+		return portRoutes(map[string]int64{"frontend.test": frontend, "api.test": api, "api.local": api}), nil
+	})
+
+	router.Reload()
+
+	tests := []struct {
+		host string
+		code int
+		body string
+	}{
+		{"frontend.test", http.StatusOK, "frontend frontend.test frontend.test"},
+		{"api.test:8480", http.StatusOK, "api api.test:8480 api.test:8480"},
+		{"API.local", http.StatusOK, "api API.local API.local"},
+		{"unknown.test", http.StatusNotFound, "No project with domain unknown.test\n"},
+	}
+
+	for _, test := range tests {
+		code, body := get(t, router, test.host)
+
+		if code != test.code || body != test.body {
+			t.Errorf("Expected %d %q for host %s, got %d %q", test.code, test.body, test.host, code, body)
+		}
+	}
+}
+
+func TestRouterPaths(t *testing.T) {
+	// Backend that responds with the given name and the path it received
+	pathBackend := func(name string) int64 {
+		backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintf(w, "%s %s", name, r.URL.EscapedPath())
+		}))
+
+		t.Cleanup(backend.Close)
+
+		return backendPort(t, backend)
+	}
+
+	frontend := pathBackend("frontend")
+	api := pathBackend("api")
+	apiV2 := pathBackend("api-v2")
+	docs := pathBackend("docs")
+
+	router := NewRouter(func() (map[string][]Route, error) {
+		return map[string][]Route{"example.test": {
+			{Path: "/", Port: frontend},
+			{Path: "/api", Port: api, StripPrefix: true},
+			{Path: "/api/v2", Port: apiV2, StripPrefix: true},
+			{Path: "/docs", Port: docs},
+		}}, nil
+	})
+
+	router.Reload()
+
+	tests := []struct {
+		path string
+		body string
+	}{
+		{"/", "frontend /"},
+		{"/apis", "frontend /apis"},
+		{"/api", "api /"},
+		{"/api/users?page=2", "api /users"},
+		{"/api/users%2F1", "api /users%2F1"},
+		{"/api/v2/users", "api-v2 /users"},
+		{"/api/v20", "api /v20"},
+		{"/docs/index.html", "docs /docs/index.html"},
+	}
+
+	for _, test := range tests {
+		code, body := getPath(t, router, "example.test", test.path)
+
+		if code != http.StatusOK || body != test.body {
+			t.Errorf("Expected %q for path %s, got %d %q", test.body, test.path, code, body)
+		}
+	}
+}
+
+func TestRouterReload(t *testing.T) {
+	port := testingBackend(t, "example")
+	routes := map[string]int64{}
+
+	router := NewRouter(func() (map[string][]Route, error) {
+		return portRoutes(routes), nil
+	})
+
+	router.Reload()
+
+	if code, _ := get(t, router, "example.test"); code != http.StatusNotFound {
+		t.Error("Expected no route before the project is added, got", code)
+	}
+
+	routes = map[string]int64{"example.test": port}
+
+	stop := make(chan struct{})
+	defer close(stop)
+
+	go router.Watch(10*time.Millisecond, stop, nil)
+
+	deadline := time.Now().Add(time.Second)
+
+	for time.Now().Before(deadline) {
+		if _, ok := router.Lookup("example.test", "/"); ok {
+			break
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if code, body := get(t, router, "example.test"); code != http.StatusOK {
+		t.Error("Expected route to be picked up after reloading, got", code, body)
+	}
+}
+
+func TestRouterReloadError(t *testing.T) {
+	port := testingBackend(t, "example")
+	fail := false
+
+	router := NewRouter(func() (map[string][]Route, error) {
+		if fail {
+			return nil, fmt.Errorf("database is locked")
+		}
+
+		return portRoutes(map[string]int64{"example.test": port}), nil
+	})
+
+	router.Reload()
+	fail = true
+
+	if router.Reload() == nil {
+		t.Error("Expected error when loading the routes fails")
+	}
+
+	if _, ok := router.Lookup("example.test", "/"); !ok {
+		t.Error("Expected previous routes to be kept when loading the routes fails")
+	}
+}
+
+func TestRouterBadGateway(t *testing.T) {
+	backend := httptest.NewServer(http.NotFoundHandler())
+	port := backendPort(t, backend)
+	backend.Close()
+
+	router := NewRouter(func() (map[string][]Route, error) {
+		return portRoutes(map[string]int64{"stopped.test": port}), nil
+	})
+
+	router.Reload()
+
+	if code, _ := get(t, router, "stopped.test"); code != http.StatusBadGateway {
+		t.Error("Expected bad gateway for a project that is not running, got", code)
+	}
+}
+
+func TestServerWebSocket(t *testing.T) {
+	// Backend that switches to an echo protocol after an upgrade request, like a WebSocket server
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Upgrade") != "websocket" {
+			http.Error(w, "expected upgrade", http.StatusBadRequest)
+			return
+		}
+
+		conn, buf, err := w.(http.Hijacker).Hijack()
+
+		if err != nil {
+			return
+		}
+
+		defer conn.Close()
+
+		buf.WriteString("HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n\r\n")
+		buf.Flush()
+
+		io.Copy(conn, buf)
+	}))
+
+	t.Cleanup(backend.Close)
+
+	port := backendPort(t, backend)
+
+	server := NewServer(0, func() (map[string][]Route, error) {
+		return portRoutes(map[string]int64{"socket.test": port}), nil
+	})
+
+	if err := server.Listen(); err != nil {
+		t.Fatal("Expected server to listen, got", err)
+	}
+
+	defer server.Close()
+
+	go server.Serve(nil)
+
+	conn, err := net.Dial("tcp", server.Addr())
+
+	if err != nil {
+		t.Fatal("Expected to connect to the server, got", err)
+	}
+
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	fmt.Fprint(conn, "GET /socket HTTP/1.1\r\nHost: socket.test\r\nConnection: Upgrade\r\nUpgrade: websocket\r\n\r\n")
+
+	reader := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(reader, nil)
+
+	if err != nil || resp.StatusCode != http.StatusSwitchingProtocols {
+		t.Fatal("Expected protocol to be switched, got", resp, err)
+	}
+
+	fmt.Fprint(conn, "ping")
+
+	message := make([]byte, 4)
+
+	if _, err := io.ReadFull(reader, message); err != nil || string(message) != "ping" {
+		t.Errorf("Expected message to be echoed through the proxy, got %q %s", message, err)
+	}
+}